@@ -9,36 +9,76 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
 	"strconv"
 	"strings"
 
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 )
 
-// https://cp-par1..com/products/servers
-// https://cp-ams1..com/products/servers
-// Default values
+// Default values, the regional endpoints are in the region registry
 var (
-	AccountAPI          = "https://account.scaleway.com/"
-	MetadataAPI         = "http://169.254.42.42/"
-	MarketplaceAPI      = "https://api-marketplace.scaleway.com"
-	ComputeAPIPar1      = "https://cp-par1.scaleway.com/"
-	ComputeAPIAms1      = "https://cp-ams1.scaleway.com/"
-	AvailabilityAPIPar1 = "https://availability.scaleway.com/"
-	AvailabilityAPIAms1 = "https://availability-ams1.scaleway.com/"
-
-	URLPublicDNS  = ".pub.cloud.scaleway.com"
-	URLPrivateDNS = ".priv.cloud.scaleway.com"
+	AccountAPI     = "https://account.scaleway.com/"
+	MetadataAPI    = "http://169.254.42.42/"
+	MarketplaceAPI = "https://api-marketplace.scaleway.com"
 )
 
+// Endpoints of the builtin regions, a value reassigned by the caller replaces
+// the endpoint of the par1 or ams1 entry of the region registry
+//
+// Deprecated: use RegisterRegion
+var (
+	ComputeAPIPar1      = regions["par1"].ComputeAPI
+	ComputeAPIAms1      = regions["ams1"].ComputeAPI
+	AvailabilityAPIPar1 = regions["par1"].AvailabilityAPI
+	AvailabilityAPIAms1 = regions["ams1"].AvailabilityAPI
+
+	URLPublicDNS  = regions["par1"].PublicDNS
+	URLPrivateDNS = regions["par1"].PrivateDNS
+)
+
+// builtinEndpoints are the initial values of the deprecated endpoints
+var builtinEndpoints = map[*string]string{
+	&ComputeAPIPar1:      ComputeAPIPar1,
+	&ComputeAPIAms1:      ComputeAPIAms1,
+	&AvailabilityAPIPar1: AvailabilityAPIPar1,
+	&AvailabilityAPIAms1: AvailabilityAPIAms1,
+	&URLPublicDNS:        URLPublicDNS,
+	&URLPrivateDNS:       URLPrivateDNS,
+}
+
+// withDeprecatedEndpoints returns region with the deprecated endpoints
+// reassigned by the caller, if it is par1 or ams1
+func withDeprecatedEndpoints(region Region) Region {
+	set := func(field, endpoint *string) {
+		if *endpoint != builtinEndpoints[endpoint] {
+			*field = *endpoint
+		}
+	}
+	switch region.Name {
+	case "par1":
+		set(&region.ComputeAPI, &ComputeAPIPar1)
+		set(&region.AvailabilityAPI, &AvailabilityAPIPar1)
+	case "ams1":
+		set(&region.ComputeAPI, &ComputeAPIAms1)
+		set(&region.AvailabilityAPI, &AvailabilityAPIAms1)
+	default:
+		return region
+	}
+	set(&region.PublicDNS, &URLPublicDNS)
+	set(&region.PrivateDNS, &URLPrivateDNS)
+	return region
+}
+
 func init() {
 	if url := os.Getenv("SCW_ACCOUNT_API"); url != "" {
 		AccountAPI = url
@@ -53,6 +93,9 @@ func init() {
 
 const (
 	perPage = 50
+
+	// maxConcurrentPages is the number of pages fetched at once by GetResponsePaginate
+	maxConcurrentPages = 4
 )
 
 // HTTPClient wraps the net/http Client Do method
@@ -76,99 +119,137 @@ type API struct {
 	client          HTTPClient
 	computeAPI      string
 	availabilityAPI string
+	accountAPI      string
+	marketplaceAPI  string
+	metadataAPI     string
+	retryPolicy     RetryPolicy
+	limiter         *RateLimiter
+	middlewares     []Middleware
+	logger          *slog.Logger
+	tracer          trace.Tracer
+	metrics         Metrics
+	region          Region
 
 	Region string
 }
 
-// APIError represents a  API Error
-type APIError struct {
-	// Message is a human-friendly error message
-	APIMessage string `json:"message,omitempty"`
-
-	// Type is a string code that defines the kind of error
-	Type string `json:"type,omitempty"`
-
-	// Fields contains detail about validation error
-	Fields map[string][]string `json:"fields,omitempty"`
-
-	// StatusCode is the HTTP status code received
-	StatusCode int `json:"-"`
-
-	// Message
-	Message string `json:"-"`
-}
-
-// Error returns a string representing the error
-func (e APIError) Error() string {
-	var b bytes.Buffer
-
-	fmt.Fprintf(&b, "StatusCode: %v, ", e.StatusCode)
-	fmt.Fprintf(&b, "Type: %v, ", e.Type)
-	fmt.Fprintf(&b, "APIMessage: \x1b[31m%v\x1b[0m", e.APIMessage)
-	if len(e.Fields) > 0 {
-		fmt.Fprintf(&b, ", Details: %v", e.Fields)
-	}
-	return b.String()
-}
-
 // New creates a ready-to-use  SDK client
 func New(organization, token, region string, options ...func(*API)) (*API, error) {
+	return newAPI(organization, token, region, true, options...)
+}
+
+// newAPI creates a client, the endpoints of SCW_COMPUTE_API and
+// SCW_AVAILABILITY_API are used if environment is true
+func newAPI(organization, token, region string, environment bool, options ...func(*API)) (*API, error) {
 	s := &API{
 		// exposed
 		Organization: organization,
 		Token:        token,
 
 		// internal
-		client:    &http.Client{},
-		password:  "",
-		userAgent: "-sdk",
+		client:      &http.Client{},
+		password:    "",
+		userAgent:   "-sdk",
+		retryPolicy: DefaultRetryPolicy,
+		limiter:     NewRateLimiter(),
+		tracer:      noopTracer(),
+
+		accountAPI:     AccountAPI,
+		marketplaceAPI: MarketplaceAPI,
+		metadataAPI:    MetadataAPI,
 	}
-	for _, option := range options {
-		option(s)
+	r, err := LookupRegion(region)
+	if err != nil {
+		return nil, err
 	}
-	switch region {
-	case "par1", "":
-		s.computeAPI = ComputeAPIPar1
-		s.availabilityAPI = AvailabilityAPIPar1
-	case "ams1":
-		s.computeAPI = ComputeAPIAms1
-		s.availabilityAPI = AvailabilityAPIAms1
-	default:
-		return nil, fmt.Errorf("%s isn't a valid region", region)
+	s.region = r
+	s.Region = r.Name
+	s.computeAPI = r.ComputeAPI
+	s.availabilityAPI = r.AvailabilityAPI
+	if r.MetadataAPI != "" {
+		s.metadataAPI = r.MetadataAPI
 	}
-	s.Region = region
-	if url := os.Getenv("SCW_COMPUTE_API"); url != "" {
-		s.computeAPI = url
+	if environment {
+		if url := os.Getenv("SCW_COMPUTE_API"); url != "" {
+			s.computeAPI = url
+		}
+		if url := os.Getenv("SCW_AVAILABILITY_API"); url != "" {
+			s.availabilityAPI = url
+		}
 	}
-	if url := os.Getenv("SCW_AVAILABILITY_API"); url != "" {
-		s.availabilityAPI = url
+	// options are applied last, to override the environment
+	for _, option := range options {
+		option(s)
 	}
 	return s, nil
 }
 
-func (s *API) response(method, uri string, content io.Reader) (resp *http.Response, err error) {
-	var (
-		req *http.Request
-	)
+func (s *API) response(ctx context.Context, method, uri string, content io.Reader) (*http.Response, error) {
+	return s.request(ctx, method, uri, "application/json", content)
+}
 
-	req, err = http.NewRequest(method, uri, content)
-	if err != nil {
-		err = fmt.Errorf("response %s %s", method, uri)
-		return
+// request sends a request to the API, retrying it according to the retry policy
+func (s *API) request(ctx context.Context, method, uri, contentType string, content io.Reader) (resp *http.Response, err error) {
+	var payload []byte
+
+	if content != nil {
+		// the payload is kept in memory to be sent again on retries
+		if payload, err = ioutil.ReadAll(content); err != nil {
+			return nil, err
+		}
+	}
+	family := s.apiFamily(uri)
+	for attempt := 1; ; attempt++ {
+		var (
+			req  *http.Request
+			body io.Reader
+		)
+
+		if content != nil {
+			body = bytes.NewReader(payload)
+		}
+		req, err = http.NewRequestWithContext(ctx, method, uri, body)
+		if err != nil {
+			err = fmt.Errorf("response %s %s", method, uri)
+			return
+		}
+		req.Header.Set("X-Auth-Token", s.Token)
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("User-Agent", s.userAgent)
+		if err = s.limiter.Wait(ctx, family); err != nil {
+			return nil, err
+		}
+		resp, err = s.transport().Do(req)
+		if attempt >= s.retryPolicy.MaxAttempts || !s.retryPolicy.shouldRetry(ctx, method, resp, err) {
+			return
+		}
+		wait := s.retryPolicy.backoff(attempt, resp)
+		if isRateLimited(resp) {
+			// the other requests to this family would be throttled as well
+			s.limiter.pause(family, wait)
+		}
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if err = sleep(ctx, wait); err != nil {
+			return nil, err
+		}
 	}
-	req.Header.Set("X-Auth-Token", s.Token)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", s.userAgent)
-	resp, err = s.client.Do(req)
-	return
 }
 
 // GetResponsePaginate fetchs all resources and returns an http.Response object for the requested resource
 func (s *API) GetResponsePaginate(apiURL, resource string, values url.Values) (*http.Response, error) {
-	resp, err := s.response("HEAD", fmt.Sprintf("%s/%s?%s", strings.TrimRight(apiURL, "/"), resource, values.Encode()), nil)
+	return s.GetResponsePaginateContext(context.Background(), apiURL, resource, values)
+}
+
+// GetResponsePaginateContext is like GetResponsePaginate but uses ctx for the underlying requests
+func (s *API) GetResponsePaginateContext(ctx context.Context, apiURL, resource string, values url.Values) (*http.Response, error) {
+	resp, err := s.response(ctx, "HEAD", fmt.Sprintf("%s/%s?%s", strings.TrimRight(apiURL, "/"), resource, values.Encode()), nil)
 	if err != nil {
 		return nil, err
 	}
+	resp.Body.Close()
 
 	count := resp.Header.Get("X-Total-Count")
 	var maxElem int
@@ -186,101 +267,182 @@ func (s *API) GetResponsePaginate(apiURL, resource string, values url.Values) (*
 		get++
 	}
 
-	if get <= 1 { // If there is 0 or 1 page of result, the response is not paginated
-		if len(values) == 0 {
-			return s.response("GET", fmt.Sprintf("%s/%s", strings.TrimRight(apiURL, "/"), resource), nil)
+	fetchAll := !(values.Get("per_page") != "" || values.Get("page") != "")
+	if get <= 1 || !fetchAll { // If there is 0 or 1 page of result, the response is not paginated
+		uri := fmt.Sprintf("%s/%s", strings.TrimRight(apiURL, "/"), resource)
+		if len(values) > 0 {
+			uri += "?" + values.Encode()
 		}
-		return s.response("GET", fmt.Sprintf("%s/%s?%s", strings.TrimRight(apiURL, "/"), resource, values.Encode()), nil)
+		resp, err = s.response(ctx, "GET", uri, nil)
+		if err != nil || !fetchAll || resp.StatusCode != http.StatusOK {
+			s.observePages(ctx, 1)
+			return resp, err
+		}
+		content, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if countItems(content) < perPage {
+			s.observePages(ctx, 1)
+			resp.Body = ioutil.NopCloser(bytes.NewReader(content))
+			return resp, nil
+		}
+		// the API returns perPage resources by default, a full page means
+		// X-Total-Count is lower than the number of resources: they are
+		// fetched page by page
+		get = 1
 	}
 
-	fetchAll := !(values.Get("per_page") != "" || values.Get("page") != "")
-	if fetchAll {
-		var g errgroup.Group
-
-		ch := make(chan *http.Response, get)
-		for i := 1; i <= get; i++ {
-			i := i // closure tricks
-			g.Go(func() (err error) {
-				var resp *http.Response
-
-				val := url.Values{}
-				val.Set("per_page", fmt.Sprintf("%v", perPage))
-				val.Set("page", fmt.Sprintf("%v", i))
-				resp, err = s.response("GET", fmt.Sprintf("%s/%s?%s", strings.TrimRight(apiURL, "/"), resource, val.Encode()), nil)
-				ch <- resp
-				return
-			})
+	// the first failing page cancels gctx, which aborts the other in-flight pages
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConcurrentPages)
+
+	pages := make([]*http.Response, get)
+	contents := make([][]byte, get)
+	for i := 1; i <= get; i++ {
+		i := i // closure tricks
+		g.Go(func() (err error) {
+			pages[i-1], contents[i-1], err = s.fetchPage(gctx, apiURL, resource, values, i)
+			return err
+		})
+	}
+	if err = g.Wait(); err != nil {
+		return nil, err
+	}
+	// X-Total-Count may be lower than the number of resources, the pages
+	// following a full last page are fetched until a short one
+	for last := len(pages) - 1; pages[last].StatusCode == http.StatusOK && countItems(contents[last]) >= perPage; last++ {
+		res, content, err := s.fetchPage(ctx, apiURL, resource, values, last+2)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(content, contents[last]) {
+			// the API doesn't paginate this resource
+			break
 		}
-		if err = g.Wait(); err != nil {
+		pages = append(pages, res)
+		contents = append(contents, content)
+	}
+	s.observePages(ctx, len(pages))
+
+	newBody := make(map[string][]json.RawMessage)
+	body := make(map[string][]json.RawMessage)
+	key := ""
+	for i, res := range pages {
+		if res.StatusCode != http.StatusOK {
+			res.Body = ioutil.NopCloser(bytes.NewReader(contents[i]))
+			return res, nil
+		}
+		if err := json.Unmarshal(contents[i], &body); err != nil {
 			return nil, err
 		}
-		newBody := make(map[string][]json.RawMessage)
-		body := make(map[string][]json.RawMessage)
-		key := ""
-		for i := 0; i < get; i++ {
-			res := <-ch
-			if res.StatusCode != http.StatusOK {
-				return res, nil
-			}
-			content, err := ioutil.ReadAll(res.Body)
-			res.Body.Close()
-			if err != nil {
-				return nil, err
-			}
-			if err := json.Unmarshal(content, &body); err != nil {
-				return nil, err
-			}
 
-			if i == 0 {
-				resp = res
-				for k := range body {
-					key = k
-					break
-				}
+		if i == 0 {
+			resp = res
+			for k := range body {
+				key = k
+				break
 			}
-			newBody[key] = append(newBody[key], body[key]...)
 		}
-		payload := new(bytes.Buffer)
-		if err := json.NewEncoder(payload).Encode(newBody); err != nil {
-			return nil, err
+		newBody[key] = append(newBody[key], body[key]...)
+	}
+	payload := new(bytes.Buffer)
+	if err := json.NewEncoder(payload).Encode(newBody); err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(payload)
+	return resp, nil
+}
+
+// fetchPage fetches a page of perPage resources, keeping the filters of values
+func (s *API) fetchPage(ctx context.Context, apiURL, resource string, values url.Values, page int) (*http.Response, []byte, error) {
+	val := url.Values{}
+	for key, value := range values {
+		val[key] = value
+	}
+	val.Set("per_page", fmt.Sprintf("%v", perPage))
+	val.Set("page", fmt.Sprintf("%v", page))
+	res, err := s.response(ctx, "GET", fmt.Sprintf("%s/%s?%s", strings.TrimRight(apiURL, "/"), resource, val.Encode()), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the body is read here since the context of the page may be canceled
+	// once it is returned
+	content, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, content, nil
+}
+
+// countItems returns the number of resources of a page, 0 if it isn't a
+// list of resources
+func countItems(content []byte) int {
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(content, &body); err != nil || len(body) != 1 {
+		return 0
+	}
+	for _, value := range body {
+		var items []json.RawMessage
+		if err := json.Unmarshal(value, &items); err == nil {
+			return len(items)
 		}
-		resp.Body = ioutil.NopCloser(payload)
-	} else {
-		resp, err = s.response("GET", fmt.Sprintf("%s/%s?%s", strings.TrimRight(apiURL, "/"), resource, values.Encode()), nil)
 	}
-	return resp, err
+	return 0
 }
 
 // PostResponse returns an http.Response object for the updated resource
 func (s *API) PostResponse(apiURL, resource string, data interface{}) (*http.Response, error) {
+	return s.PostResponseContext(context.Background(), apiURL, resource, data)
+}
+
+// PostResponseContext is like PostResponse but uses ctx for the underlying requests
+func (s *API) PostResponseContext(ctx context.Context, apiURL, resource string, data interface{}) (*http.Response, error) {
 	payload := new(bytes.Buffer)
 	if err := json.NewEncoder(payload).Encode(data); err != nil {
 		return nil, err
 	}
-	return s.response("POST", fmt.Sprintf("%s/%s", strings.TrimRight(apiURL, "/"), resource), payload)
+	return s.response(ctx, "POST", fmt.Sprintf("%s/%s", strings.TrimRight(apiURL, "/"), resource), payload)
 }
 
 // PatchResponse returns an http.Response object for the updated resource
 func (s *API) PatchResponse(apiURL, resource string, data interface{}) (*http.Response, error) {
+	return s.PatchResponseContext(context.Background(), apiURL, resource, data)
+}
+
+// PatchResponseContext is like PatchResponse but uses ctx for the underlying requests
+func (s *API) PatchResponseContext(ctx context.Context, apiURL, resource string, data interface{}) (*http.Response, error) {
 	payload := new(bytes.Buffer)
 	if err := json.NewEncoder(payload).Encode(data); err != nil {
 		return nil, err
 	}
-	return s.response("PATCH", fmt.Sprintf("%s/%s", strings.TrimRight(apiURL, "/"), resource), payload)
+	return s.response(ctx, "PATCH", fmt.Sprintf("%s/%s", strings.TrimRight(apiURL, "/"), resource), payload)
 }
 
 // PutResponse returns an http.Response object for the updated resource
 func (s *API) PutResponse(apiURL, resource string, data interface{}) (*http.Response, error) {
+	return s.PutResponseContext(context.Background(), apiURL, resource, data)
+}
+
+// PutResponseContext is like PutResponse but uses ctx for the underlying requests
+func (s *API) PutResponseContext(ctx context.Context, apiURL, resource string, data interface{}) (*http.Response, error) {
 	payload := new(bytes.Buffer)
 	if err := json.NewEncoder(payload).Encode(data); err != nil {
 		return nil, err
 	}
-	return s.response("PUT", fmt.Sprintf("%s/%s", strings.TrimRight(apiURL, "/"), resource), payload)
+	return s.response(ctx, "PUT", fmt.Sprintf("%s/%s", strings.TrimRight(apiURL, "/"), resource), payload)
 }
 
 // DeleteResponse returns an http.Response object for the deleted resource
 func (s *API) DeleteResponse(apiURL, resource string) (*http.Response, error) {
-	return s.response("DELETE", fmt.Sprintf("%s/%s", strings.TrimRight(apiURL, "/"), resource), nil)
+	return s.DeleteResponseContext(context.Background(), apiURL, resource)
+}
+
+// DeleteResponseContext is like DeleteResponse but uses ctx for the underlying requests
+func (s *API) DeleteResponseContext(ctx context.Context, apiURL, resource string) (*http.Response, error) {
+	return s.response(ctx, "DELETE", fmt.Sprintf("%s/%s", strings.TrimRight(apiURL, "/"), resource), nil)
 }
 
 // handleHTTPError checks the statusCode and displays the error
@@ -290,25 +452,12 @@ func (s *API) handleHTTPError(goodStatusCode []int, resp *http.Response) ([]byte
 		return nil, err
 	}
 
-	if resp.StatusCode >= http.StatusInternalServerError {
-		return nil, errors.New(string(body))
-	}
-	good := false
 	for _, code := range goodStatusCode {
 		if code == resp.StatusCode {
-			good = true
-		}
-	}
-	if !good {
-		var scwError APIError
-
-		if err := json.Unmarshal(body, &scwError); err != nil {
-			return nil, err
+			return body, nil
 		}
-		scwError.StatusCode = resp.StatusCode
-		return nil, scwError
 	}
-	return body, nil
+	return nil, s.newAPIError(resp, body)
 }
 
 // SetPassword register the password
@@ -2,8 +2,12 @@ package api
 
 import (
 	"log"
+	"net/http"
 	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/smola/scaleway-sdk/cassette"
 )
 
 var client *API
@@ -20,3 +24,47 @@ func TestMain(m *testing.M) {
 	code := m.Run()
 	os.Exit(code)
 }
+
+// newCassetteAPI returns a client replaying testdata/cassettes/<name>.json,
+// or <name>.synthetic.json, written by hand, if it wasn't recorded. With
+// SCW_RECORD set, the cassette is recorded against the API instead.
+func newCassetteAPI(t *testing.T, name string) *API {
+	t.Helper()
+	path := filepath.Join("testdata", "cassettes", name+".json")
+
+	if os.Getenv("SCW_RECORD") != "" {
+		if client == nil {
+			t.Skip("recording cassettes requires SCALEWAY_ORGANIZATION and SCALEWAY_TOKEN")
+		}
+		recorder := cassette.NewRecorder(&http.Client{})
+		s, err := New(client.Organization, client.Token, "par1", WithHTTPClient(recorder))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			if err := recorder.Save(path); err != nil {
+				t.Error(err)
+			}
+		})
+		return s
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		path = filepath.Join("testdata", "cassettes", name+".synthetic.json")
+	}
+	c, err := cassette.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayer := cassette.NewReplayer(c)
+	s, err := New("organization", "token", "par1", WithHTTPClient(replayer), WithRetryPolicy(NoRetry))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if unused := replayer.Unused(); len(unused) > 0 {
+			t.Errorf("%d interactions of %s weren't replayed", len(unused), path)
+		}
+	})
+	return s
+}
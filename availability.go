@@ -1,9 +1,10 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"net/http"
 )
 
 type ServerAvailabilities map[string]interface{}
@@ -20,17 +21,25 @@ func (a ServerAvailabilities) CommercialTypes() []string {
 }
 
 func (s *API) GetServerAvailabilities() (ServerAvailabilities, error) {
-	resp, err := s.response("GET", fmt.Sprintf("%s/availability.json", s.availabilityAPI), nil)
+	return s.GetServerAvailabilitiesContext(context.Background())
+}
+
+// GetServerAvailabilitiesContext is like GetServerAvailabilities but uses ctx for the underlying requests
+func (s *API) GetServerAvailabilitiesContext(ctx context.Context) (_ ServerAvailabilities, err error) {
+	ctx, op := s.startOperation(ctx, "GetServerAvailabilities", "availability", "")
+	defer op.End(&err)
+
+	resp, err := s.response(ctx, "GET", fmt.Sprintf("%s/availability.json", s.availabilityAPI), nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
-	bs, err := ioutil.ReadAll(resp.Body)
+	body, err := s.handleHTTPError([]int{http.StatusOK}, resp)
 	if err != nil {
 		return nil, err
 	}
 	content := ServerAvailabilities{}
-	if err := json.Unmarshal(bs, &content); err != nil {
+	if err := json.Unmarshal(body, &content); err != nil {
 		return nil, err
 	}
 	return content, nil
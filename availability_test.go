@@ -5,10 +5,9 @@ import (
 )
 
 func TestScalewayAPI_GetServerAvailabilities(t *testing.T) {
-	if client == nil {
-		t.Skip("skipping GetServerAvailabilities due to missing client credentials")
-	}
-	availabilities, err := client.GetServerAvailabilities()
+	s := newCassetteAPI(t, "GetServerAvailabilities")
+
+	availabilities, err := s.GetServerAvailabilities()
 	if err != nil {
 		t.Errorf("failed to get server availabilities: %v", err.Error())
 	}
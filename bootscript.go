@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/url"
@@ -41,9 +42,17 @@ type getBootscriptsResponse struct {
 
 // GetBootscripts gets the list of bootscripts from the API
 func (s *API) GetBootscripts() ([]Bootscript, error) {
+	return s.GetBootscriptsContext(context.Background())
+}
+
+// GetBootscriptsContext is like GetBootscripts but uses ctx for the underlying requests
+func (s *API) GetBootscriptsContext(ctx context.Context) (_ []Bootscript, err error) {
+	ctx, op := s.startOperation(ctx, "GetBootscripts", "bootscript", "")
+	defer op.End(&err)
+
 	query := url.Values{}
 
-	resp, err := s.GetResponsePaginate(s.computeAPI, "bootscripts", query)
+	resp, err := s.GetResponsePaginateContext(ctx, s.computeAPI, "bootscripts", query)
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +72,15 @@ func (s *API) GetBootscripts() ([]Bootscript, error) {
 
 // GetBootscript gets a bootscript from the API
 func (s *API) GetBootscript(bootscriptID string) (*Bootscript, error) {
-	resp, err := s.GetResponsePaginate(s.computeAPI, "bootscripts/"+bootscriptID, url.Values{})
+	return s.GetBootscriptContext(context.Background(), bootscriptID)
+}
+
+// GetBootscriptContext is like GetBootscript but uses ctx for the underlying requests
+func (s *API) GetBootscriptContext(ctx context.Context, bootscriptID string) (_ *Bootscript, err error) {
+	ctx, op := s.startOperation(ctx, "GetBootscript", "bootscript", bootscriptID)
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.computeAPI, "bootscripts/"+bootscriptID, url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -81,3 +98,14 @@ func (s *API) GetBootscript(bootscriptID string) (*Bootscript, error) {
 	// FIXME region, arch, owner, title
 	return &oneBootscript.Bootscript, nil
 }
+
+// ListBootscriptsIter returns an iterator over the bootscripts
+func (s *API) ListBootscriptsIter(ctx context.Context) *Iterator[Bootscript] {
+	return newIterator[Bootscript](ctx, s, listing{
+		operation:    "ListBootscriptsIter",
+		resourceType: "bootscript",
+		apiURL:       s.computeAPI,
+		resource:     "bootscripts",
+		key:          "bootscripts",
+	}, nil)
+}
@@ -0,0 +1,289 @@
+// Package cassette records the interactions of a client with the  API
+// into files, and replays them to run the tests offline:
+//
+//	// record once, against the API
+//	recorder := cassette.NewRecorder(&http.Client{})
+//	client, err := api.New(organization, token, "par1", api.WithHTTPClient(recorder))
+//	...
+//	err = recorder.Save("testdata/cassettes/servers.json")
+//
+//	// then replay
+//	c, err := cassette.Load("testdata/cassettes/servers.json")
+//	client, err := api.New("organization", "token", "par1", api.WithHTTPClient(cassette.NewReplayer(c)))
+//
+// The tokens are scrubbed from the recorded interactions.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Scrubbed replaces the tokens in the cassettes
+const Scrubbed = "REDACTED"
+
+// ErrNoInteraction is returned by Replayer for the requests which weren't recorded
+var ErrNoInteraction = errors.New("no recorded interaction")
+
+// HTTPClient wraps the net/http Client Do method, as the HTTPClient of the API
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Cassette is a list of recorded interactions
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a request and the response it got
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request
+type Request struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// Response is a recorded response
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Load reads a cassette file
+func Load(path string) (*Cassette, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c Cassette
+	if err = json.Unmarshal(content, &c); err != nil {
+		return nil, fmt.Errorf("cassette %s: %v", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette to path
+func (c *Cassette) Save(path string) error {
+	content, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(content, '\n'), 0644)
+}
+
+// Recorder is an HTTPClient recording the interactions of client
+type Recorder struct {
+	client HTTPClient
+
+	mu       sync.Mutex
+	cassette Cassette
+	secrets  []string
+}
+
+// NewRecorder returns a Recorder sending the requests with client
+func NewRecorder(client HTTPClient) *Recorder {
+	return &Recorder{
+		client: client,
+	}
+}
+
+// Scrub registers values to scrub from the interactions, besides the
+// X-Auth-Token of the requests
+func (r *Recorder) Scrub(values ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.secrets = append(r.secrets, values...)
+}
+
+// Do sends req and records the interaction
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		content, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = content
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return resp, err
+	}
+	content, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(content))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	secrets := append([]string{req.Header.Get("X-Auth-Token")}, r.secrets...)
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: Request{
+			Method:  req.Method,
+			URL:     scrub(req.URL.String(), secrets),
+			Headers: scrubHeader(req.Header, secrets),
+			Body:    scrub(string(body), secrets),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Headers:    scrubHeader(resp.Header, secrets),
+			Body:       scrub(string(content), secrets),
+		},
+	})
+	return resp, nil
+}
+
+// Cassette returns a copy of the recorded interactions
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Cassette{
+		Interactions: append([]Interaction{}, r.cassette.Interactions...),
+	}
+}
+
+// Save writes the recorded interactions to path
+func (r *Recorder) Save(path string) error {
+	return r.Cassette().Save(path)
+}
+
+// Replayer is an HTTPClient answering with the interactions of a cassette.
+// The requests are matched by method, path, query and body; each
+// interaction is replayed once, in the recorded order.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayer returns a Replayer of the interactions of c
+func NewReplayer(c *Cassette) *Replayer {
+	return &Replayer{
+		interactions: c.Interactions,
+		used:         make([]bool, len(c.Interactions)),
+	}
+}
+
+// Do answers req with the first unused matching interaction
+func (p *Replayer) Do(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		content, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = content
+	}
+	secrets := []string{req.Header.Get("X-Auth-Token")}
+	uri, err := url.Parse(scrub(req.URL.String(), secrets))
+	if err != nil {
+		return nil, err
+	}
+	body = []byte(scrub(string(body), secrets))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, interaction := range p.interactions {
+		if p.used[i] || !interaction.Request.matches(req.Method, uri, body) {
+			continue
+		}
+		p.used[i] = true
+		recorded := interaction.Response
+		header := recorded.Headers.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		header.Set("Content-Length", strconv.Itoa(len(recorded.Body)))
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+			StatusCode:    recorded.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(recorded.Body)),
+			ContentLength: int64(len(recorded.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w for %s %s", ErrNoInteraction, req.Method, uri)
+}
+
+// Unused returns the interactions which weren't replayed
+func (p *Replayer) Unused() []Interaction {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var ret []Interaction
+	for i, interaction := range p.interactions {
+		if !p.used[i] {
+			ret = append(ret, interaction)
+		}
+	}
+	return ret
+}
+
+// matches returns true if the recorded request has the same method, path,
+// query and body, the JSON bodies are compared regardless of formatting
+func (r Request) matches(method string, uri *url.URL, body []byte) bool {
+	if r.Method != method {
+		return false
+	}
+	recorded, err := url.Parse(r.URL)
+	if err != nil || recorded.Path != uri.Path || !reflect.DeepEqual(recorded.Query(), uri.Query()) {
+		return false
+	}
+	if r.Body == string(body) {
+		return true
+	}
+	var a, b interface{}
+	if json.Unmarshal([]byte(r.Body), &a) != nil || json.Unmarshal(body, &b) != nil {
+		return false
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// scrub replaces the secrets in value
+func scrub(value string, secrets []string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			value = strings.Replace(value, secret, Scrubbed, -1)
+		}
+	}
+	return value
+}
+
+// scrubHeader returns a copy of header without the secrets
+func scrubHeader(header http.Header, secrets []string) http.Header {
+	ret := make(http.Header, len(header))
+	for key, values := range header {
+		if key == "X-Auth-Token" {
+			ret[key] = []string{Scrubbed}
+			continue
+		}
+		for _, value := range values {
+			ret.Add(key, scrub(value, secrets))
+		}
+	}
+	return ret
+}
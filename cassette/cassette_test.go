@@ -0,0 +1,109 @@
+package cassette
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const token = "0123-secret-token"
+
+func send(t *testing.T, client HTTPClient, method, uri, body string) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, uri, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Auth-Token", token)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, string(content)
+}
+
+func record(t *testing.T) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("X-Request-Id", r.Method)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"path": "` + r.URL.Path + `", "body": ` + string(body) + `}`))
+	}))
+	defer srv.Close()
+
+	recorder := NewRecorder(http.DefaultClient)
+	resp, content := send(t, recorder, "POST", srv.URL+"/tokens/"+token+"?b=2&a=1", `{"name": "web", "size": 1}`)
+	if resp.StatusCode != http.StatusCreated || !strings.Contains(content, token) {
+		t.Errorf("expected the recorder to pass the response through, got %d %s", resp.StatusCode, content)
+	}
+	send(t, recorder, "GET", srv.URL+"/servers", "{}")
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := recorder.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRecorder_scrub(t *testing.T) {
+	content, err := ioutil.ReadFile(record(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), token) {
+		t.Errorf("expected the token to be scrubbed:\n%s", content)
+	}
+	if !strings.Contains(string(content), "/tokens/"+Scrubbed) {
+		t.Errorf("expected the token to be replaced with %s:\n%s", Scrubbed, content)
+	}
+}
+
+func TestReplayer(t *testing.T) {
+	c, err := Load(record(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewReplayer(c)
+
+	// the host, the order of the query and the formatting of the body don't matter
+	resp, content := send(t, p, "POST", "https://api.example.com/tokens/"+token+"?a=1&b=2", `{"size":1,"name":"web"}`)
+	if resp.StatusCode != http.StatusCreated || resp.Header.Get("X-Request-Id") != "POST" {
+		t.Errorf("unexpected response: %d %v", resp.StatusCode, resp.Header)
+	}
+	if !strings.Contains(content, "/tokens/"+Scrubbed) {
+		t.Errorf("unexpected body: %s", content)
+	}
+	if len(p.Unused()) != 1 {
+		t.Errorf("expected 1 unused interaction, got %d", len(p.Unused()))
+	}
+
+	for _, tt := range []struct {
+		method, uri, body string
+	}{
+		{"POST", "https://api.example.com/tokens/" + token + "?a=1&b=2", `{"size":1,"name":"web"}`},
+		{"PUT", "https://api.example.com/servers", "{}"},
+		{"GET", "https://api.example.com/servers?page=2", "{}"},
+		{"GET", "https://api.example.com/servers", `{"name": "web"}`},
+	} {
+		req, _ := http.NewRequest(tt.method, tt.uri, strings.NewReader(tt.body))
+		req.Header.Set("X-Auth-Token", token)
+		if _, err := p.Do(req); !errors.Is(err, ErrNoInteraction) {
+			t.Errorf("%s %s %s: expected ErrNoInteraction, got %v", tt.method, tt.uri, tt.body, err)
+		}
+	}
+
+	send(t, p, "GET", "https://api.example.com/servers", "{}")
+	if len(p.Unused()) != 0 {
+		t.Errorf("expected every interaction to be replayed, got %d unused", len(p.Unused()))
+	}
+}
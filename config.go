@@ -0,0 +1,180 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Endpoints represents the URLs of the APIs, empty ones keep their default value
+type Endpoints struct {
+	Compute      string `json:"compute,omitempty" yaml:"compute,omitempty"`
+	Availability string `json:"availability,omitempty" yaml:"availability,omitempty"`
+	Account      string `json:"account,omitempty" yaml:"account,omitempty"`
+	Marketplace  string `json:"marketplace,omitempty" yaml:"marketplace,omitempty"`
+	Metadata     string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+}
+
+// Profile represents the settings of a client
+type Profile struct {
+	Organization string    `json:"organization,omitempty" yaml:"organization,omitempty"`
+	Token        string    `json:"token,omitempty" yaml:"token,omitempty"`
+	Region       string    `json:"region,omitempty" yaml:"region,omitempty"`
+	UserAgent    string    `json:"user_agent,omitempty" yaml:"user_agent,omitempty"`
+	Endpoints    Endpoints `json:"endpoints,omitempty" yaml:"endpoints,omitempty"`
+}
+
+// Config represents a configuration file, in JSON or YAML, i.e:
+//
+//	{
+//	  "organization": "...",
+//	  "token": "...",
+//	  "default_profile": "prod",
+//	  "profiles": {
+//	    "prod": {"organization": "...", "token": "...", "region": "ams1"},
+//	    "dev": {"token": "...", "endpoints": {"compute": "http://localhost:8080"}}
+//	  }
+//	}
+//
+// The top-level settings, as written in ~/.scwrc, are shared by all the profiles.
+type Config struct {
+	Profile `yaml:",inline"`
+
+	// DefaultProfile is the profile used when none is requested
+	DefaultProfile string `json:"default_profile,omitempty" yaml:"default_profile,omitempty"`
+
+	// Profiles holds the named profiles
+	Profiles map[string]Profile `json:"profiles,omitempty" yaml:"profiles,omitempty"`
+}
+
+// DefaultConfigPath returns the path of the configuration file, SCW_CONFIG_PATH or ~/.scwrc
+func DefaultConfigPath() (string, error) {
+	if path := os.Getenv("SCW_CONFIG_PATH"); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".scwrc"), nil
+}
+
+// LoadConfig reads a configuration file, JSON is detected by its opening brace
+func LoadConfig(path string) (*Config, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+
+	if bytes.HasPrefix(bytes.TrimSpace(content), []byte("{")) {
+		err = json.Unmarshal(content, &config)
+	} else {
+		err = yaml.Unmarshal(content, &config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %v", path, err)
+	}
+	return &config, nil
+}
+
+// Resolve returns the settings of a profile, merged in this order, each
+// step overriding the previous ones:
+//
+//  1. the top-level settings
+//  2. the named profile: name, SCW_PROFILE or DefaultProfile
+//  3. the environment: SCW_ORGANIZATION, SCW_TOKEN, SCW_REGION, SCW_USER_AGENT,
+//     SCW_COMPUTE_API, SCW_AVAILABILITY_API, SCW_ACCOUNT_API, SCW_MARKETPLACE_API
+//     and SCW_METADATA_API
+func (c *Config) Resolve(name string) (Profile, error) {
+	profile := c.Profile
+
+	if name == "" {
+		name = os.Getenv("SCW_PROFILE")
+	}
+	if name == "" {
+		name = c.DefaultProfile
+	}
+	if name != "" {
+		named, ok := c.Profiles[name]
+		if !ok {
+			return Profile{}, fmt.Errorf("no such profile %q", name)
+		}
+		profile.merge(named)
+	}
+	profile.merge(Profile{
+		Organization: os.Getenv("SCW_ORGANIZATION"),
+		Token:        os.Getenv("SCW_TOKEN"),
+		Region:       os.Getenv("SCW_REGION"),
+		UserAgent:    os.Getenv("SCW_USER_AGENT"),
+		Endpoints: Endpoints{
+			Compute:      os.Getenv("SCW_COMPUTE_API"),
+			Availability: os.Getenv("SCW_AVAILABILITY_API"),
+			Account:      os.Getenv("SCW_ACCOUNT_API"),
+			Marketplace:  os.Getenv("SCW_MARKETPLACE_API"),
+			Metadata:     os.Getenv("SCW_METADATA_API"),
+		},
+	})
+	return profile, nil
+}
+
+// merge overrides the settings of p with the non-empty ones of other
+func (p *Profile) merge(other Profile) {
+	override := func(dst *string, src string) {
+		if src != "" {
+			*dst = src
+		}
+	}
+	override(&p.Organization, other.Organization)
+	override(&p.Token, other.Token)
+	override(&p.Region, other.Region)
+	override(&p.UserAgent, other.UserAgent)
+	override(&p.Endpoints.Compute, other.Endpoints.Compute)
+	override(&p.Endpoints.Availability, other.Endpoints.Availability)
+	override(&p.Endpoints.Account, other.Endpoints.Account)
+	override(&p.Endpoints.Marketplace, other.Endpoints.Marketplace)
+	override(&p.Endpoints.Metadata, other.Endpoints.Metadata)
+}
+
+// NewFromConfig creates a client from a profile of a configuration file,
+// see Config.Resolve; options override the profile.
+// If path is empty, DefaultConfigPath is read if it exists.
+func NewFromConfig(path, profile string, options ...func(*API)) (*API, error) {
+	config := &Config{}
+
+	if path != "" {
+		loaded, err := LoadConfig(path)
+		if err != nil {
+			return nil, err
+		}
+		config = loaded
+	} else if path, err := DefaultConfigPath(); err == nil {
+		loaded, err := LoadConfig(path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if err == nil {
+			config = loaded
+		}
+	}
+
+	settings, err := config.Resolve(profile)
+	if err != nil {
+		return nil, err
+	}
+	if settings.Organization == "" || settings.Token == "" {
+		return nil, fmt.Errorf("missing organization or token in profile %q", profile)
+	}
+	defaults := []func(*API){
+		WithEndpoints(settings.Endpoints),
+	}
+	if settings.UserAgent != "" {
+		defaults = append(defaults, WithUserAgent(settings.UserAgent))
+	}
+	return New(settings.Organization, settings.Token, settings.Region, append(defaults, options...)...)
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func clearConfigEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{
+		"SCW_PROFILE", "SCW_ORGANIZATION", "SCW_TOKEN", "SCW_REGION", "SCW_USER_AGENT",
+		"SCW_COMPUTE_API", "SCW_AVAILABILITY_API", "SCW_ACCOUNT_API", "SCW_MARKETPLACE_API", "SCW_METADATA_API",
+	} {
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+}
+
+func TestNewFromConfig_legacy(t *testing.T) {
+	clearConfigEnv(t)
+	path := writeConfig(t, ".scwrc", `{"organization": "org", "token": "token"}`)
+
+	s, err := NewFromConfig(path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Organization != "org" || s.Token != "token" || s.computeAPI != "https://cp-par1.scaleway.com/" {
+		t.Errorf("unexpected client: %q %q %q", s.Organization, s.Token, s.computeAPI)
+	}
+}
+
+const yamlConfig = `
+organization: org
+token: token
+default_profile: dev
+profiles:
+  dev:
+    endpoints:
+      compute: http://localhost:8080
+  prod:
+    token: prod-token
+    region: ams1
+`
+
+func TestNewFromConfig_profiles(t *testing.T) {
+	clearConfigEnv(t)
+	path := writeConfig(t, "config.yaml", yamlConfig)
+
+	s, err := NewFromConfig(path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Token != "token" || s.computeAPI != "http://localhost:8080" {
+		t.Errorf("default profile not used: %q %q", s.Token, s.computeAPI)
+	}
+
+	s, err = NewFromConfig(path, "prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Organization != "org" || s.Token != "prod-token" || s.Region != "ams1" || s.computeAPI != "https://cp-ams1.scaleway.com/" {
+		t.Errorf("prod profile not used: %q %q %q %q", s.Organization, s.Token, s.Region, s.computeAPI)
+	}
+
+	if _, err = NewFromConfig(path, "staging"); err == nil {
+		t.Error("expected an error for an unknown profile")
+	}
+}
+
+func TestNewFromConfig_precedence(t *testing.T) {
+	clearConfigEnv(t)
+	path := writeConfig(t, "config.yaml", yamlConfig)
+	t.Setenv("SCW_PROFILE", "prod")
+	t.Setenv("SCW_TOKEN", "env-token")
+	t.Setenv("SCW_COMPUTE_API", "http://env")
+
+	s, err := NewFromConfig(path, "", WithEndpoints(Endpoints{Compute: "http://option"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Region != "ams1" {
+		t.Errorf("SCW_PROFILE not used: %q", s.Region)
+	}
+	if s.Token != "env-token" {
+		t.Errorf("environment should override the profile: %q", s.Token)
+	}
+	if s.computeAPI != "http://option" {
+		t.Errorf("options should override the environment: %q", s.computeAPI)
+	}
+}
+
+func TestNewFromConfig_missing(t *testing.T) {
+	clearConfigEnv(t)
+	if _, err := NewFromConfig(filepath.Join(t.TempDir(), "missing"), ""); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+
+	t.Setenv("SCW_CONFIG_PATH", filepath.Join(t.TempDir(), "missing"))
+	t.Setenv("SCW_ORGANIZATION", "org")
+	t.Setenv("SCW_TOKEN", "token")
+	s, err := NewFromConfig("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Organization != "org" || s.Token != "token" {
+		t.Errorf("environment not used: %q %q", s.Organization, s.Token)
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -33,7 +34,15 @@ type GetContainers struct {
 
 // GetContainers returns a GetContainers
 func (s *API) GetContainers() (*GetContainers, error) {
-	resp, err := s.GetResponsePaginate(s.computeAPI, "containers", url.Values{})
+	return s.GetContainersContext(context.Background())
+}
+
+// GetContainersContext is like GetContainers but uses ctx for the underlying requests
+func (s *API) GetContainersContext(ctx context.Context) (_ *GetContainers, err error) {
+	ctx, op := s.startOperation(ctx, "GetContainers", "container", "")
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.computeAPI, "containers", url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +62,15 @@ func (s *API) GetContainers() (*GetContainers, error) {
 
 // GetContainerDatas returns a GetContainerDatas
 func (s *API) GetContainerDatas(container string) (*GetContainerDatas, error) {
-	resp, err := s.GetResponsePaginate(s.computeAPI, fmt.Sprintf("containers/%s", container), url.Values{})
+	return s.GetContainerDatasContext(context.Background(), container)
+}
+
+// GetContainerDatasContext is like GetContainerDatas but uses ctx for the underlying requests
+func (s *API) GetContainerDatasContext(ctx context.Context, container string) (_ *GetContainerDatas, err error) {
+	ctx, op := s.startOperation(ctx, "GetContainerDatas", "container", container)
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.computeAPI, fmt.Sprintf("containers/%s", container), url.Values{})
 	if err != nil {
 		return nil, err
 	}
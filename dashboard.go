@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/url"
@@ -23,7 +24,15 @@ type Dashboard struct {
 
 // GetDashboard returns the dashboard
 func (s *API) GetDashboard() (*Dashboard, error) {
-	resp, err := s.GetResponsePaginate(s.computeAPI, "dashboard", url.Values{})
+	return s.GetDashboardContext(context.Background())
+}
+
+// GetDashboardContext is like GetDashboard but uses ctx for the underlying requests
+func (s *API) GetDashboardContext(ctx context.Context) (_ *Dashboard, err error) {
+	ctx, op := s.startOperation(ctx, "GetDashboard", "dashboard", "")
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.computeAPI, "dashboard", url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Errors matched by APIError, i.e: errors.Is(err, api.ErrNotFound)
+var (
+	ErrInvalidRequest = errors.New("invalid request")
+	ErrUnauthorized   = errors.New("unauthorized")
+	ErrForbidden      = errors.New("forbidden")
+	ErrNotFound       = errors.New("not found")
+	ErrConflict       = errors.New("conflict")
+	ErrQuotaExceeded  = errors.New("quota exceeded")
+	ErrRateLimited    = errors.New("rate limited")
+	ErrServerError    = errors.New("server error")
+)
+
+// APIError represents a  API Error
+type APIError struct {
+	// Message is a human-friendly error message
+	APIMessage string `json:"message,omitempty"`
+
+	// Type is a string code that defines the kind of error
+	Type string `json:"type,omitempty"`
+
+	// Fields contains detail about validation error
+	Fields map[string][]string `json:"fields,omitempty"`
+
+	// StatusCode is the HTTP status code received
+	StatusCode int `json:"-"`
+
+	// Message
+	Message string `json:"-"`
+
+	// Method and URL identify the failed request, the token is redacted from URL
+	Method string `json:"-"`
+	URL    string `json:"-"`
+
+	// RequestID is the identifier given to the request by the API, if any
+	RequestID string `json:"-"`
+}
+
+// FieldError represents the validation errors of a field of a request
+type FieldError struct {
+	Field    string
+	Messages []string
+}
+
+// Error returns a string representing the error
+func (e APIError) Error() string {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "StatusCode: %v, ", e.StatusCode)
+	fmt.Fprintf(&b, "Type: %v, ", e.Type)
+	fmt.Fprintf(&b, "APIMessage: %v", e.APIMessage)
+	if len(e.Fields) > 0 {
+		fmt.Fprintf(&b, ", Details: %v", e.Fields)
+	}
+	if e.Method != "" {
+		fmt.Fprintf(&b, ", Request: %v %v", e.Method, e.URL)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, ", RequestID: %v", e.RequestID)
+	}
+	return b.String()
+}
+
+// Is matches the error with the Err* sentinels, according to its type and status code
+func (e APIError) Is(target error) bool {
+	switch target {
+	case ErrInvalidRequest:
+		return e.StatusCode == http.StatusBadRequest || e.Type == "invalid_request_error"
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.Type == "authorization_required" || e.Type == "denied_authentication"
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || e.Type == "unknown_resource"
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict || e.Type == "conflict"
+	case ErrQuotaExceeded:
+		return e.Type == "quotas_exceeded" || e.Type == "quota_exceeded"
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// FieldErrors returns the validation errors of Fields, sorted by field
+func (e APIError) FieldErrors() []FieldError {
+	ret := make([]FieldError, 0, len(e.Fields))
+	for field, messages := range e.Fields {
+		ret = append(ret, FieldError{
+			Field:    field,
+			Messages: messages,
+		})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Field < ret[j].Field
+	})
+	return ret
+}
+
+// newAPIError returns the error of a response with an unexpected status code
+func (s *API) newAPIError(resp *http.Response, body []byte) APIError {
+	var scwError APIError
+
+	if err := json.Unmarshal(body, &scwError); err != nil || (scwError.Type == "" && scwError.APIMessage == "") {
+		// i.e: 5xx answered by a proxy
+		scwError = APIError{
+			APIMessage: strings.TrimSpace(string(body)),
+		}
+	}
+	scwError.StatusCode = resp.StatusCode
+	scwError.RequestID = resp.Header.Get("X-Request-Id")
+	if resp.Request != nil {
+		scwError.Method = resp.Request.Method
+		scwError.URL = s.redact(resp.Request.URL.String())
+	}
+	return scwError
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	for _, test := range []struct {
+		err      APIError
+		sentinel error
+	}{
+		{APIError{StatusCode: 400, Type: "invalid_request_error"}, ErrInvalidRequest},
+		{APIError{StatusCode: 401, Type: "authorization_required"}, ErrUnauthorized},
+		{APIError{StatusCode: 403}, ErrForbidden},
+		{APIError{StatusCode: 404, Type: "unknown_resource"}, ErrNotFound},
+		{APIError{StatusCode: 409}, ErrConflict},
+		{APIError{StatusCode: 403, Type: "quotas_exceeded"}, ErrQuotaExceeded},
+		{APIError{StatusCode: 429}, ErrRateLimited},
+		{APIError{StatusCode: 503}, ErrServerError},
+	} {
+		if !errors.Is(test.err, test.sentinel) {
+			t.Errorf("expected %v to be %v", test.err, test.sentinel)
+		}
+		if errors.Is(test.err, ErrConflict) != (test.sentinel == ErrConflict) {
+			t.Errorf("expected %v not to be %v", test.err, ErrConflict)
+		}
+	}
+}
+
+func TestAPIError_FieldErrors(t *testing.T) {
+	err := APIError{
+		Fields: map[string][]string{
+			"name":            {"required"},
+			"commercial_type": {"invalid", "unavailable"},
+		},
+	}
+
+	expected := []FieldError{
+		{Field: "commercial_type", Messages: []string{"invalid", "unavailable"}},
+		{Field: "name", Messages: []string{"required"}},
+	}
+	if got := err.FieldErrors(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestAPIError_Response(t *testing.T) {
+	s := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "42")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("<html>Internal Server Error</html>"))
+	}))
+	s.SetRetryPolicy(NoRetry)
+
+	err := s.DeleteServer("1")
+	if !errors.Is(err, ErrServerError) {
+		t.Fatalf("expected a server error, got %v", err)
+	}
+	var apiErr APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an APIError, got %T", err)
+	}
+	if apiErr.Method != "DELETE" || apiErr.URL != s.computeAPI+"/servers/1" || apiErr.RequestID != "42" {
+		t.Errorf("expected the request to be identified, got %+v", apiErr)
+	}
+	if apiErr.APIMessage != "<html>Internal Server Error</html>" {
+		t.Errorf("expected the body as message, got %q", apiErr.APIMessage)
+	}
+	if strings.Contains(err.Error(), "\x1b[") {
+		t.Errorf("expected no escape sequence in %q", err.Error())
+	}
+}
+
+func TestAPIError_Userdata(t *testing.T) {
+	s := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"type": "unknown_resource", "message": "no such user_data"}`))
+	}))
+
+	if _, err := s.GetUserdata("1", "key", false); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected GetUserdata to fail with %v, got %v", ErrNotFound, err)
+	}
+	if err := s.PatchUserdata("1", "key", []byte("value"), false); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected PatchUserdata to fail with %v, got %v", ErrNotFound, err)
+	}
+}
@@ -0,0 +1,351 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/smola/scaleway-sdk/scwtest"
+)
+
+// newFakeAPI returns a client of a fake API
+func newFakeAPI(t *testing.T) (*API, *scwtest.Server) {
+	t.Helper()
+	return newFakeRegionAPI(t, "par1")
+}
+
+// newFakeRegionAPI returns a client of a fake API of region
+func newFakeRegionAPI(t *testing.T, region string) (*API, *scwtest.Server) {
+	t.Helper()
+	srv := scwtest.NewServer()
+	srv.Zone = region
+	t.Cleanup(srv.Close)
+
+	s, err := New(srv.Organization, srv.Token, region, WithRetryPolicy(NoRetry), WithEndpoints(Endpoints{
+		Compute:      srv.ComputeURL(),
+		Availability: srv.AvailabilityURL(),
+		Account:      srv.AccountURL(),
+		Marketplace:  srv.MarketplaceURL(),
+		Metadata:     srv.MetadataURL(),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, srv
+}
+
+func TestFake_serverLifecycle(t *testing.T) {
+	s, srv := newFakeAPI(t)
+
+	image := srv.DefaultImage
+	dynamicIP := true
+	id, err := s.PostServer(ServerDefinition{
+		Name:              "web",
+		Image:             &image,
+		CommercialType:    "VC1S",
+		DynamicIPRequired: &dynamicIP,
+		Tags:              []string{"prod"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := s.GetServer(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server.State != "stopped" || server.Image.Identifier != image || server.Volumes["0"].Size == 0 {
+		t.Errorf("unexpected server: %+v", server)
+	}
+
+	if err = s.PostServerAction(id, "poweron"); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.PostServerAction(id, "poweron"); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("expected powering on a running server to fail, got %v", err)
+	}
+	if server, err = s.GetServer(id); err != nil {
+		t.Fatal(err)
+	}
+	if server.State != "running" || server.PublicAddress.IP == "" || server.DNSPublic != id+".pub.cloud.scaleway.com" {
+		t.Errorf("unexpected running server: %+v", server)
+	}
+	if err = s.DeleteServer(id); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("expected deleting a running server to fail, got %v", err)
+	}
+
+	if err = s.PostServerAction(id, "terminate"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.GetServer(id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected the server to be terminated, got %v", err)
+	}
+	volumes, err := s.GetVolumes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*volumes) != 0 {
+		t.Errorf("expected the volumes to be terminated, got %d", len(*volumes))
+	}
+	tasks, err := s.GetTasks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*tasks) != 2 || (*tasks)[1].Status != "success" {
+		t.Errorf("unexpected tasks: %+v", tasks)
+	}
+}
+
+func TestFake_transitions(t *testing.T) {
+	s, srv := newFakeAPI(t)
+	srv.TransitionReads = 2
+
+	image := srv.DefaultImage
+	id, err := s.PostServer(ServerDefinition{Name: "web", Image: &image, CommercialType: "VC1S"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.PostServerAction(id, "poweron"); err != nil {
+		t.Fatal(err)
+	}
+	var states []string
+	for i := 0; i < 4; i++ {
+		server, err := s.GetServer(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		states = append(states, server.State)
+	}
+	// GetServer sends HEAD then GET, each one is a read
+	if states[0] != "starting" || states[1] != "running" {
+		t.Errorf("unexpected states: %v", states)
+	}
+}
+
+func TestFake_pagination(t *testing.T) {
+	s, srv := newFakeAPI(t)
+	srv.SetQuota("volumes", 200)
+
+	for i := 0; i < 120; i++ {
+		if _, err := s.PostVolume(VolumeDefinition{Name: "data", Size: 1000000000, Type: "l_ssd"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	volumes, err := s.GetVolumes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*volumes) != 120 {
+		t.Errorf("expected 120 volumes, got %d", len(*volumes))
+	}
+
+	it := s.ListVolumesIter(context.Background())
+	defer it.Close()
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if err = it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if count != 120 {
+		t.Errorf("expected 120 volumes, got %d", count)
+	}
+
+	if _, err = s.PostVolume(VolumeDefinition{Name: "data", Size: 1000000000, Type: "l_ssd"}); err != nil {
+		t.Fatal(err)
+	}
+	srv.SetQuota("volumes", 121)
+	if _, err = s.PostVolume(VolumeDefinition{Name: "data", Size: 1000000000, Type: "l_ssd"}); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("expected the quota to be exceeded, got %v", err)
+	}
+}
+
+func TestFake_storage(t *testing.T) {
+	s, _ := newFakeAPI(t)
+
+	volumeID, err := s.PostVolume(VolumeDefinition{Name: "root", Size: 20000000000, Type: "l_ssd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshotID, err := s.PostSnapshot(volumeID, "root-snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot, err := s.GetSnapshot(snapshotID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Size != 20000000000 || snapshot.BaseVolume.Identifier != volumeID {
+		t.Errorf("unexpected snapshot: %+v", snapshot)
+	}
+	imageID, err := s.PostImage(snapshotID, "my-image", "", "x86_64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	images, err := s.GetImages()
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, image := range *images {
+		found = found || image.CurrentPublicVersion == imageID
+	}
+	if !found || len(*images) != 2 {
+		t.Errorf("expected the marketplace image and my-image, got %+v", images)
+	}
+	if err = s.DeleteSnapshot(snapshotID); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("expected deleting a snapshot used by an image to fail, got %v", err)
+	}
+	if err = s.DeleteImage(imageID); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.DeleteSnapshot(snapshotID); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.DeleteVolume(volumeID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFake_network(t *testing.T) {
+	s, srv := newFakeAPI(t)
+
+	image := srv.DefaultImage
+	serverID, err := s.PostServer(ServerDefinition{Name: "web", Image: &image, CommercialType: "VC1S"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ip, err := s.NewIP()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.AttachIP(ip.IP.ID, serverID); err != nil {
+		t.Fatal(err)
+	}
+	server, err := s.GetServer(serverID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server.PublicAddress.Identifier != ip.IP.ID {
+		t.Errorf("expected IP %s to be attached, got %+v", ip.IP.ID, server.PublicAddress)
+	}
+	if err = s.DetachIP(ip.IP.ID); err != nil {
+		t.Fatal(err)
+	}
+	if ip, err = s.GetIP(ip.IP.ID); err != nil || ip.IP.Server != nil {
+		t.Errorf("expected IP to be detached: %+v, %v", ip, err)
+	}
+
+	if err = s.PostSecurityGroup(NewSecurityGroup{Organization: s.Organization, Name: "web"}); err != nil {
+		t.Fatal(err)
+	}
+	groups, err := s.GetSecurityGroups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups.SecurityGroups) != 2 {
+		t.Fatalf("expected 2 security groups, got %d", len(groups.SecurityGroups))
+	}
+	groupID := groups.SecurityGroups[1].ID
+	rule, err := s.PostGroupRule(groupID, NewGroupRule{Action: "accept", Direction: "inbound", IPRange: "0.0.0.0/0", Protocol: "TCP", DestPortFrom: 443})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules, err := s.GetGroupRules(groupID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules.Rules) != 1 || rules.Rules[0].DestPortFrom != 443 {
+		t.Errorf("unexpected rules: %+v", rules)
+	}
+	if err = s.DeleteGroupRule(groupID, rule.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.DeleteSecurityGroup(srv.DefaultSecurityGroup); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("expected deleting the default security group to fail, got %v", err)
+	}
+	if err = s.DeleteSecurityGroup(groupID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFake_userdata(t *testing.T) {
+	s, srv := newFakeAPI(t)
+
+	image := srv.DefaultImage
+	serverID, err := s.PostServer(ServerDefinition{Name: "web", Image: &image, CommercialType: "VC1S"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.PatchUserdata(serverID, "cloud-init", []byte("#cloud-config\n"), false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.GetUserdatas(serverID, true); !errors.Is(err, ErrForbidden) {
+		t.Errorf("expected the metadata API to be unreachable, got %v", err)
+	}
+	srv.SetMetadataServer(serverID)
+	keys, err := s.GetUserdatas(serverID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys.UserData) != 1 || keys.UserData[0] != "cloud-init" {
+		t.Errorf("unexpected user data: %v", keys.UserData)
+	}
+	value, err := s.GetUserdata(serverID, "cloud-init", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value.String() != "#cloud-config\n" {
+		t.Errorf("unexpected value: %q", value.String())
+	}
+	if err = s.DeleteUserdata(serverID, "cloud-init", false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.GetUserdata(serverID, "cloud-init", false); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected the user data to be deleted, got %v", err)
+	}
+}
+
+func TestFake_account(t *testing.T) {
+	s, srv := newFakeAPI(t)
+
+	user, err := s.GetUser()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.ID != srv.UserID || len(user.Organizations) != 1 {
+		t.Errorf("unexpected user: %+v", user)
+	}
+	err = s.PatchUserSSHKey(user.ID, UserPatchSSHKeyDefinition{SSHPublicKeys: []KeyDefinition{{Key: "ssh-rsa AAAA jane"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user, err = s.GetUser(); err != nil || len(user.SSHPublicKeys) != 1 || user.SSHPublicKeys[0].Fingerprint == "" {
+		t.Errorf("expected the key to be added: %+v, %v", user, err)
+	}
+	quotas, err := s.GetQuotas()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quotas.Quotas["servers"] == 0 {
+		t.Errorf("unexpected quotas: %v", quotas.Quotas)
+	}
+	if _, err = s.GetPermissions(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.GetOrganization(); err != nil {
+		t.Fatal(err)
+	}
+	availabilities, err := s.GetServerAvailabilities()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(availabilities.CommercialTypes()) == 0 {
+		t.Error("expected commercial types")
+	}
+
+	s.Token = "invalid"
+	if _, err = s.GetUser(); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected an invalid token to be denied, got %v", err)
+	}
+}
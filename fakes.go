@@ -0,0 +1,90 @@
+package api
+
+import (
+	"sync"
+)
+
+// FakeCall is a call recorded by a fake service, the context isn't recorded
+type FakeCall struct {
+	Method string
+	Args   []interface{}
+}
+
+// fakeRecorder records the calls of a fake service, its mutex also guards
+// the scripted results
+type fakeRecorder struct {
+	mu    sync.Mutex
+	calls []FakeCall
+}
+
+// record appends a call and returns its index among the calls of method
+func (r *fakeRecorder) record(method string, args ...interface{}) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	index := r.count(method)
+	r.calls = append(r.calls, FakeCall{Method: method, Args: args})
+	return index
+}
+
+// args returns the arguments of the ith call of method
+func (r *fakeRecorder) args(method string, i int) []interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, call := range r.calls {
+		if call.Method != method {
+			continue
+		}
+		if i == 0 {
+			return call.Args
+		}
+		i--
+	}
+	panic("fake: no such call of " + method)
+}
+
+func (r *fakeRecorder) count(method string) int {
+	count := 0
+	for _, call := range r.calls {
+		if call.Method == method {
+			count++
+		}
+	}
+	return count
+}
+
+// Calls returns the recorded calls, in order
+func (r *fakeRecorder) Calls() []FakeCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]FakeCall{}, r.calls...)
+}
+
+// CallCount returns the number of calls of method, i.e: "GetServer" for
+// both GetServer and GetServerContext
+func (r *fakeRecorder) CallCount(method string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.count(method)
+}
+
+// fakeScript holds the results of a method of a fake service: the results
+// of a given call, else the default results, else the zero values
+type fakeScript[R any] struct {
+	results R
+	onCall  map[int]R
+}
+
+func (s *fakeScript[R]) returnsOnCall(i int, results R) {
+	if s.onCall == nil {
+		s.onCall = make(map[int]R)
+	}
+	s.onCall[i] = results
+}
+
+// next returns the results of the ith call, r.mu must be held
+func (s *fakeScript[R]) next(i int) R {
+	if results, ok := s.onCall[i]; ok {
+		return results
+	}
+	return s.results
+}
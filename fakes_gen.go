@@ -0,0 +1,3933 @@
+// Code generated by genfakes from services.go. DO NOT EDIT.
+
+package api
+
+import "context"
+
+// FakeServerService is a ServerService recording its calls and returning scripted results,
+// the calls which aren't scripted return the zero values
+type FakeServerService struct {
+	fakeRecorder
+
+	// GetServersFunc, if set, answers the calls of GetServers
+	GetServersFunc func(context.Context, bool, int) (*[]Server, error)
+
+	// ListServersFunc, if set, answers the calls of ListServers
+	ListServersFunc func(context.Context, ListServersOptions) ([]Server, error)
+
+	// GetServerFunc, if set, answers the calls of GetServer
+	GetServerFunc func(context.Context, string) (*Server, error)
+
+	// PostServerFunc, if set, answers the calls of PostServer
+	PostServerFunc func(context.Context, ServerDefinition) (string, error)
+
+	// PatchServerFunc, if set, answers the calls of PatchServer
+	PatchServerFunc func(context.Context, string, ServerPatchDefinition) error
+
+	// PostServerActionFunc, if set, answers the calls of PostServerAction
+	PostServerActionFunc func(context.Context, string, string) error
+
+	// DeleteServerFunc, if set, answers the calls of DeleteServer
+	DeleteServerFunc func(context.Context, string) error
+
+	// GetServerActionsFunc, if set, answers the calls of GetServerActions
+	GetServerActionsFunc func(context.Context, string) ([]string, error)
+
+	// PowerOnFunc, if set, answers the calls of PowerOn
+	PowerOnFunc func(context.Context, string, ActionOptions) (*Task, error)
+
+	// PowerOffFunc, if set, answers the calls of PowerOff
+	PowerOffFunc func(context.Context, string, ActionOptions) (*Task, error)
+
+	// RebootFunc, if set, answers the calls of Reboot
+	RebootFunc func(context.Context, string, ActionOptions) (*Task, error)
+
+	// TerminateFunc, if set, answers the calls of Terminate
+	TerminateFunc func(context.Context, string, ActionOptions) (*Task, error)
+
+	// BackupFunc, if set, answers the calls of Backup
+	BackupFunc func(context.Context, string, ActionOptions) (*Task, error)
+
+	// ListServersIterFunc, if set, answers the calls of ListServersIter
+	ListServersIterFunc func(context.Context, bool) *Iterator[Server]
+
+	// GetServerAvailabilitiesFunc, if set, answers the calls of GetServerAvailabilities
+	GetServerAvailabilitiesFunc func(context.Context) (ServerAvailabilities, error)
+
+	// GetTasksFunc, if set, answers the calls of GetTasks
+	GetTasksFunc func(context.Context) (*[]Task, error)
+
+	// ListTasksIterFunc, if set, answers the calls of ListTasksIter
+	ListTasksIterFunc func(context.Context) *Iterator[Task]
+
+	// GetTaskFunc, if set, answers the calls of GetTask
+	GetTaskFunc func(context.Context, string) (*Task, error)
+
+	// FilterTasksFunc, if set, answers the calls of FilterTasks
+	FilterTasksFunc func(context.Context, TaskFilter) ([]Task, error)
+
+	// WaitForTaskFunc, if set, answers the calls of WaitForTask
+	WaitForTaskFunc func(context.Context, string, TaskWaitOptions) (*Task, error)
+
+	// CloneServerFunc, if set, answers the calls of CloneServer
+	CloneServerFunc func(context.Context, string, CloneOptions) (*Server, error)
+
+	// DestroyServerFunc, if set, answers the calls of DestroyServer
+	DestroyServerFunc func(context.Context, string, DestroyOptions) (*DestroyReport, error)
+
+	// AddServerTagsFunc, if set, answers the calls of AddServerTags
+	AddServerTagsFunc func(context.Context, string, []string) error
+
+	// RemoveServerTagsFunc, if set, answers the calls of RemoveServerTags
+	RemoveServerTagsFunc func(context.Context, string, []string) error
+
+	// SetServerLabelFunc, if set, answers the calls of SetServerLabel
+	SetServerLabelFunc func(context.Context, string, string, string) error
+
+	getServers              fakeScript[fakeGetServersResults]
+	listServers             fakeScript[fakeListServersResults]
+	getServer               fakeScript[fakeGetServerResults]
+	postServer              fakeScript[fakePostServerResults]
+	patchServer             fakeScript[fakePatchServerResults]
+	postServerAction        fakeScript[fakePostServerActionResults]
+	deleteServer            fakeScript[fakeDeleteServerResults]
+	getServerActions        fakeScript[fakeGetServerActionsResults]
+	powerOn                 fakeScript[fakePowerOnResults]
+	powerOff                fakeScript[fakePowerOffResults]
+	reboot                  fakeScript[fakeRebootResults]
+	terminate               fakeScript[fakeTerminateResults]
+	backup                  fakeScript[fakeBackupResults]
+	listServersIter         fakeScript[fakeListServersIterResults]
+	getServerAvailabilities fakeScript[fakeGetServerAvailabilitiesResults]
+	getTasks                fakeScript[fakeGetTasksResults]
+	listTasksIter           fakeScript[fakeListTasksIterResults]
+	getTask                 fakeScript[fakeGetTaskResults]
+	filterTasks             fakeScript[fakeFilterTasksResults]
+	waitForTask             fakeScript[fakeWaitForTaskResults]
+	cloneServer             fakeScript[fakeCloneServerResults]
+	destroyServer           fakeScript[fakeDestroyServerResults]
+	addServerTags           fakeScript[fakeAddServerTagsResults]
+	removeServerTags        fakeScript[fakeRemoveServerTagsResults]
+	setServerLabel          fakeScript[fakeSetServerLabelResults]
+}
+
+var _ ServerService = (*FakeServerService)(nil)
+
+type fakeGetServersResults struct {
+	r0 *[]Server
+	r1 error
+}
+
+// GetServers records the call and returns the scripted results
+func (f *FakeServerService) GetServers(all bool, limit int) (*[]Server, error) {
+	return f.GetServersContext(context.Background(), all, limit)
+}
+
+// GetServersContext records the call and returns the scripted results
+func (f *FakeServerService) GetServersContext(ctx context.Context, all bool, limit int) (*[]Server, error) {
+	i := f.record("GetServers", all, limit)
+	if f.GetServersFunc != nil {
+		return f.GetServersFunc(ctx, all, limit)
+	}
+	f.mu.Lock()
+	r := f.getServers.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetServersReturns sets the results of the calls of GetServers
+func (f *FakeServerService) GetServersReturns(r0 *[]Server, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getServers.results = fakeGetServersResults{r0, r1}
+}
+
+// GetServersReturnsOnCall sets the results of the ith call of GetServers, from 0
+func (f *FakeServerService) GetServersReturnsOnCall(i int, r0 *[]Server, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getServers.returnsOnCall(i, fakeGetServersResults{r0, r1})
+}
+
+// GetServersArgsForCall returns the arguments of the ith call of GetServers, from 0
+func (f *FakeServerService) GetServersArgsForCall(i int) (bool, int) {
+	args := f.args("GetServers", i)
+	return args[0].(bool), args[1].(int)
+}
+
+type fakeListServersResults struct {
+	r0 []Server
+	r1 error
+}
+
+// ListServers records the call and returns the scripted results
+func (f *FakeServerService) ListServers(opts ListServersOptions) ([]Server, error) {
+	return f.ListServersContext(context.Background(), opts)
+}
+
+// ListServersContext records the call and returns the scripted results
+func (f *FakeServerService) ListServersContext(ctx context.Context, opts ListServersOptions) ([]Server, error) {
+	i := f.record("ListServers", opts)
+	if f.ListServersFunc != nil {
+		return f.ListServersFunc(ctx, opts)
+	}
+	f.mu.Lock()
+	r := f.listServers.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// ListServersReturns sets the results of the calls of ListServers
+func (f *FakeServerService) ListServersReturns(r0 []Server, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listServers.results = fakeListServersResults{r0, r1}
+}
+
+// ListServersReturnsOnCall sets the results of the ith call of ListServers, from 0
+func (f *FakeServerService) ListServersReturnsOnCall(i int, r0 []Server, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listServers.returnsOnCall(i, fakeListServersResults{r0, r1})
+}
+
+// ListServersArgsForCall returns the arguments of the ith call of ListServers, from 0
+func (f *FakeServerService) ListServersArgsForCall(i int) ListServersOptions {
+	args := f.args("ListServers", i)
+	return args[0].(ListServersOptions)
+}
+
+type fakeGetServerResults struct {
+	r0 *Server
+	r1 error
+}
+
+// GetServer records the call and returns the scripted results
+func (f *FakeServerService) GetServer(serverID string) (*Server, error) {
+	return f.GetServerContext(context.Background(), serverID)
+}
+
+// GetServerContext records the call and returns the scripted results
+func (f *FakeServerService) GetServerContext(ctx context.Context, serverID string) (*Server, error) {
+	i := f.record("GetServer", serverID)
+	if f.GetServerFunc != nil {
+		return f.GetServerFunc(ctx, serverID)
+	}
+	f.mu.Lock()
+	r := f.getServer.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetServerReturns sets the results of the calls of GetServer
+func (f *FakeServerService) GetServerReturns(r0 *Server, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getServer.results = fakeGetServerResults{r0, r1}
+}
+
+// GetServerReturnsOnCall sets the results of the ith call of GetServer, from 0
+func (f *FakeServerService) GetServerReturnsOnCall(i int, r0 *Server, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getServer.returnsOnCall(i, fakeGetServerResults{r0, r1})
+}
+
+// GetServerArgsForCall returns the arguments of the ith call of GetServer, from 0
+func (f *FakeServerService) GetServerArgsForCall(i int) string {
+	args := f.args("GetServer", i)
+	return args[0].(string)
+}
+
+type fakePostServerResults struct {
+	r0 string
+	r1 error
+}
+
+// PostServer records the call and returns the scripted results
+func (f *FakeServerService) PostServer(definition ServerDefinition) (string, error) {
+	return f.PostServerContext(context.Background(), definition)
+}
+
+// PostServerContext records the call and returns the scripted results
+func (f *FakeServerService) PostServerContext(ctx context.Context, definition ServerDefinition) (string, error) {
+	i := f.record("PostServer", definition)
+	if f.PostServerFunc != nil {
+		return f.PostServerFunc(ctx, definition)
+	}
+	f.mu.Lock()
+	r := f.postServer.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// PostServerReturns sets the results of the calls of PostServer
+func (f *FakeServerService) PostServerReturns(r0 string, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.postServer.results = fakePostServerResults{r0, r1}
+}
+
+// PostServerReturnsOnCall sets the results of the ith call of PostServer, from 0
+func (f *FakeServerService) PostServerReturnsOnCall(i int, r0 string, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.postServer.returnsOnCall(i, fakePostServerResults{r0, r1})
+}
+
+// PostServerArgsForCall returns the arguments of the ith call of PostServer, from 0
+func (f *FakeServerService) PostServerArgsForCall(i int) ServerDefinition {
+	args := f.args("PostServer", i)
+	return args[0].(ServerDefinition)
+}
+
+type fakePatchServerResults struct {
+	r0 error
+}
+
+// PatchServer records the call and returns the scripted results
+func (f *FakeServerService) PatchServer(serverID string, definition ServerPatchDefinition) error {
+	return f.PatchServerContext(context.Background(), serverID, definition)
+}
+
+// PatchServerContext records the call and returns the scripted results
+func (f *FakeServerService) PatchServerContext(ctx context.Context, serverID string, definition ServerPatchDefinition) error {
+	i := f.record("PatchServer", serverID, definition)
+	if f.PatchServerFunc != nil {
+		return f.PatchServerFunc(ctx, serverID, definition)
+	}
+	f.mu.Lock()
+	r := f.patchServer.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// PatchServerReturns sets the results of the calls of PatchServer
+func (f *FakeServerService) PatchServerReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.patchServer.results = fakePatchServerResults{r0}
+}
+
+// PatchServerReturnsOnCall sets the results of the ith call of PatchServer, from 0
+func (f *FakeServerService) PatchServerReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.patchServer.returnsOnCall(i, fakePatchServerResults{r0})
+}
+
+// PatchServerArgsForCall returns the arguments of the ith call of PatchServer, from 0
+func (f *FakeServerService) PatchServerArgsForCall(i int) (string, ServerPatchDefinition) {
+	args := f.args("PatchServer", i)
+	return args[0].(string), args[1].(ServerPatchDefinition)
+}
+
+type fakePostServerActionResults struct {
+	r0 error
+}
+
+// PostServerAction records the call and returns the scripted results
+func (f *FakeServerService) PostServerAction(serverID string, action string) error {
+	return f.PostServerActionContext(context.Background(), serverID, action)
+}
+
+// PostServerActionContext records the call and returns the scripted results
+func (f *FakeServerService) PostServerActionContext(ctx context.Context, serverID string, action string) error {
+	i := f.record("PostServerAction", serverID, action)
+	if f.PostServerActionFunc != nil {
+		return f.PostServerActionFunc(ctx, serverID, action)
+	}
+	f.mu.Lock()
+	r := f.postServerAction.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// PostServerActionReturns sets the results of the calls of PostServerAction
+func (f *FakeServerService) PostServerActionReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.postServerAction.results = fakePostServerActionResults{r0}
+}
+
+// PostServerActionReturnsOnCall sets the results of the ith call of PostServerAction, from 0
+func (f *FakeServerService) PostServerActionReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.postServerAction.returnsOnCall(i, fakePostServerActionResults{r0})
+}
+
+// PostServerActionArgsForCall returns the arguments of the ith call of PostServerAction, from 0
+func (f *FakeServerService) PostServerActionArgsForCall(i int) (string, string) {
+	args := f.args("PostServerAction", i)
+	return args[0].(string), args[1].(string)
+}
+
+type fakeDeleteServerResults struct {
+	r0 error
+}
+
+// DeleteServer records the call and returns the scripted results
+func (f *FakeServerService) DeleteServer(serverID string) error {
+	return f.DeleteServerContext(context.Background(), serverID)
+}
+
+// DeleteServerContext records the call and returns the scripted results
+func (f *FakeServerService) DeleteServerContext(ctx context.Context, serverID string) error {
+	i := f.record("DeleteServer", serverID)
+	if f.DeleteServerFunc != nil {
+		return f.DeleteServerFunc(ctx, serverID)
+	}
+	f.mu.Lock()
+	r := f.deleteServer.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// DeleteServerReturns sets the results of the calls of DeleteServer
+func (f *FakeServerService) DeleteServerReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteServer.results = fakeDeleteServerResults{r0}
+}
+
+// DeleteServerReturnsOnCall sets the results of the ith call of DeleteServer, from 0
+func (f *FakeServerService) DeleteServerReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteServer.returnsOnCall(i, fakeDeleteServerResults{r0})
+}
+
+// DeleteServerArgsForCall returns the arguments of the ith call of DeleteServer, from 0
+func (f *FakeServerService) DeleteServerArgsForCall(i int) string {
+	args := f.args("DeleteServer", i)
+	return args[0].(string)
+}
+
+type fakeGetServerActionsResults struct {
+	r0 []string
+	r1 error
+}
+
+// GetServerActions records the call and returns the scripted results
+func (f *FakeServerService) GetServerActions(serverID string) ([]string, error) {
+	return f.GetServerActionsContext(context.Background(), serverID)
+}
+
+// GetServerActionsContext records the call and returns the scripted results
+func (f *FakeServerService) GetServerActionsContext(ctx context.Context, serverID string) ([]string, error) {
+	i := f.record("GetServerActions", serverID)
+	if f.GetServerActionsFunc != nil {
+		return f.GetServerActionsFunc(ctx, serverID)
+	}
+	f.mu.Lock()
+	r := f.getServerActions.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetServerActionsReturns sets the results of the calls of GetServerActions
+func (f *FakeServerService) GetServerActionsReturns(r0 []string, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getServerActions.results = fakeGetServerActionsResults{r0, r1}
+}
+
+// GetServerActionsReturnsOnCall sets the results of the ith call of GetServerActions, from 0
+func (f *FakeServerService) GetServerActionsReturnsOnCall(i int, r0 []string, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getServerActions.returnsOnCall(i, fakeGetServerActionsResults{r0, r1})
+}
+
+// GetServerActionsArgsForCall returns the arguments of the ith call of GetServerActions, from 0
+func (f *FakeServerService) GetServerActionsArgsForCall(i int) string {
+	args := f.args("GetServerActions", i)
+	return args[0].(string)
+}
+
+type fakePowerOnResults struct {
+	r0 *Task
+	r1 error
+}
+
+// PowerOn records the call and returns the scripted results
+func (f *FakeServerService) PowerOn(serverID string, opts ActionOptions) (*Task, error) {
+	return f.PowerOnContext(context.Background(), serverID, opts)
+}
+
+// PowerOnContext records the call and returns the scripted results
+func (f *FakeServerService) PowerOnContext(ctx context.Context, serverID string, opts ActionOptions) (*Task, error) {
+	i := f.record("PowerOn", serverID, opts)
+	if f.PowerOnFunc != nil {
+		return f.PowerOnFunc(ctx, serverID, opts)
+	}
+	f.mu.Lock()
+	r := f.powerOn.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// PowerOnReturns sets the results of the calls of PowerOn
+func (f *FakeServerService) PowerOnReturns(r0 *Task, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.powerOn.results = fakePowerOnResults{r0, r1}
+}
+
+// PowerOnReturnsOnCall sets the results of the ith call of PowerOn, from 0
+func (f *FakeServerService) PowerOnReturnsOnCall(i int, r0 *Task, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.powerOn.returnsOnCall(i, fakePowerOnResults{r0, r1})
+}
+
+// PowerOnArgsForCall returns the arguments of the ith call of PowerOn, from 0
+func (f *FakeServerService) PowerOnArgsForCall(i int) (string, ActionOptions) {
+	args := f.args("PowerOn", i)
+	return args[0].(string), args[1].(ActionOptions)
+}
+
+type fakePowerOffResults struct {
+	r0 *Task
+	r1 error
+}
+
+// PowerOff records the call and returns the scripted results
+func (f *FakeServerService) PowerOff(serverID string, opts ActionOptions) (*Task, error) {
+	return f.PowerOffContext(context.Background(), serverID, opts)
+}
+
+// PowerOffContext records the call and returns the scripted results
+func (f *FakeServerService) PowerOffContext(ctx context.Context, serverID string, opts ActionOptions) (*Task, error) {
+	i := f.record("PowerOff", serverID, opts)
+	if f.PowerOffFunc != nil {
+		return f.PowerOffFunc(ctx, serverID, opts)
+	}
+	f.mu.Lock()
+	r := f.powerOff.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// PowerOffReturns sets the results of the calls of PowerOff
+func (f *FakeServerService) PowerOffReturns(r0 *Task, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.powerOff.results = fakePowerOffResults{r0, r1}
+}
+
+// PowerOffReturnsOnCall sets the results of the ith call of PowerOff, from 0
+func (f *FakeServerService) PowerOffReturnsOnCall(i int, r0 *Task, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.powerOff.returnsOnCall(i, fakePowerOffResults{r0, r1})
+}
+
+// PowerOffArgsForCall returns the arguments of the ith call of PowerOff, from 0
+func (f *FakeServerService) PowerOffArgsForCall(i int) (string, ActionOptions) {
+	args := f.args("PowerOff", i)
+	return args[0].(string), args[1].(ActionOptions)
+}
+
+type fakeRebootResults struct {
+	r0 *Task
+	r1 error
+}
+
+// Reboot records the call and returns the scripted results
+func (f *FakeServerService) Reboot(serverID string, opts ActionOptions) (*Task, error) {
+	return f.RebootContext(context.Background(), serverID, opts)
+}
+
+// RebootContext records the call and returns the scripted results
+func (f *FakeServerService) RebootContext(ctx context.Context, serverID string, opts ActionOptions) (*Task, error) {
+	i := f.record("Reboot", serverID, opts)
+	if f.RebootFunc != nil {
+		return f.RebootFunc(ctx, serverID, opts)
+	}
+	f.mu.Lock()
+	r := f.reboot.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// RebootReturns sets the results of the calls of Reboot
+func (f *FakeServerService) RebootReturns(r0 *Task, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reboot.results = fakeRebootResults{r0, r1}
+}
+
+// RebootReturnsOnCall sets the results of the ith call of Reboot, from 0
+func (f *FakeServerService) RebootReturnsOnCall(i int, r0 *Task, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reboot.returnsOnCall(i, fakeRebootResults{r0, r1})
+}
+
+// RebootArgsForCall returns the arguments of the ith call of Reboot, from 0
+func (f *FakeServerService) RebootArgsForCall(i int) (string, ActionOptions) {
+	args := f.args("Reboot", i)
+	return args[0].(string), args[1].(ActionOptions)
+}
+
+type fakeTerminateResults struct {
+	r0 *Task
+	r1 error
+}
+
+// Terminate records the call and returns the scripted results
+func (f *FakeServerService) Terminate(serverID string, opts ActionOptions) (*Task, error) {
+	return f.TerminateContext(context.Background(), serverID, opts)
+}
+
+// TerminateContext records the call and returns the scripted results
+func (f *FakeServerService) TerminateContext(ctx context.Context, serverID string, opts ActionOptions) (*Task, error) {
+	i := f.record("Terminate", serverID, opts)
+	if f.TerminateFunc != nil {
+		return f.TerminateFunc(ctx, serverID, opts)
+	}
+	f.mu.Lock()
+	r := f.terminate.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// TerminateReturns sets the results of the calls of Terminate
+func (f *FakeServerService) TerminateReturns(r0 *Task, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.terminate.results = fakeTerminateResults{r0, r1}
+}
+
+// TerminateReturnsOnCall sets the results of the ith call of Terminate, from 0
+func (f *FakeServerService) TerminateReturnsOnCall(i int, r0 *Task, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.terminate.returnsOnCall(i, fakeTerminateResults{r0, r1})
+}
+
+// TerminateArgsForCall returns the arguments of the ith call of Terminate, from 0
+func (f *FakeServerService) TerminateArgsForCall(i int) (string, ActionOptions) {
+	args := f.args("Terminate", i)
+	return args[0].(string), args[1].(ActionOptions)
+}
+
+type fakeBackupResults struct {
+	r0 *Task
+	r1 error
+}
+
+// Backup records the call and returns the scripted results
+func (f *FakeServerService) Backup(serverID string, opts ActionOptions) (*Task, error) {
+	return f.BackupContext(context.Background(), serverID, opts)
+}
+
+// BackupContext records the call and returns the scripted results
+func (f *FakeServerService) BackupContext(ctx context.Context, serverID string, opts ActionOptions) (*Task, error) {
+	i := f.record("Backup", serverID, opts)
+	if f.BackupFunc != nil {
+		return f.BackupFunc(ctx, serverID, opts)
+	}
+	f.mu.Lock()
+	r := f.backup.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// BackupReturns sets the results of the calls of Backup
+func (f *FakeServerService) BackupReturns(r0 *Task, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.backup.results = fakeBackupResults{r0, r1}
+}
+
+// BackupReturnsOnCall sets the results of the ith call of Backup, from 0
+func (f *FakeServerService) BackupReturnsOnCall(i int, r0 *Task, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.backup.returnsOnCall(i, fakeBackupResults{r0, r1})
+}
+
+// BackupArgsForCall returns the arguments of the ith call of Backup, from 0
+func (f *FakeServerService) BackupArgsForCall(i int) (string, ActionOptions) {
+	args := f.args("Backup", i)
+	return args[0].(string), args[1].(ActionOptions)
+}
+
+type fakeListServersIterResults struct {
+	r0 *Iterator[Server]
+}
+
+// ListServersIter records the call and returns the scripted results
+func (f *FakeServerService) ListServersIter(ctx context.Context, all bool) *Iterator[Server] {
+	i := f.record("ListServersIter", all)
+	if f.ListServersIterFunc != nil {
+		return f.ListServersIterFunc(ctx, all)
+	}
+	f.mu.Lock()
+	r := f.listServersIter.next(i)
+	f.mu.Unlock()
+	if r.r0 == nil {
+		r.r0 = SliceIterator[Server](nil, nil)
+	}
+	return r.r0
+}
+
+// ListServersIterReturns sets the results of the calls of ListServersIter
+func (f *FakeServerService) ListServersIterReturns(r0 *Iterator[Server]) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listServersIter.results = fakeListServersIterResults{r0}
+}
+
+// ListServersIterReturnsOnCall sets the results of the ith call of ListServersIter, from 0
+func (f *FakeServerService) ListServersIterReturnsOnCall(i int, r0 *Iterator[Server]) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listServersIter.returnsOnCall(i, fakeListServersIterResults{r0})
+}
+
+// ListServersIterArgsForCall returns the arguments of the ith call of ListServersIter, from 0
+func (f *FakeServerService) ListServersIterArgsForCall(i int) bool {
+	args := f.args("ListServersIter", i)
+	return args[0].(bool)
+}
+
+type fakeGetServerAvailabilitiesResults struct {
+	r0 ServerAvailabilities
+	r1 error
+}
+
+// GetServerAvailabilities records the call and returns the scripted results
+func (f *FakeServerService) GetServerAvailabilities() (ServerAvailabilities, error) {
+	return f.GetServerAvailabilitiesContext(context.Background())
+}
+
+// GetServerAvailabilitiesContext records the call and returns the scripted results
+func (f *FakeServerService) GetServerAvailabilitiesContext(ctx context.Context) (ServerAvailabilities, error) {
+	i := f.record("GetServerAvailabilities")
+	if f.GetServerAvailabilitiesFunc != nil {
+		return f.GetServerAvailabilitiesFunc(ctx)
+	}
+	f.mu.Lock()
+	r := f.getServerAvailabilities.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetServerAvailabilitiesReturns sets the results of the calls of GetServerAvailabilities
+func (f *FakeServerService) GetServerAvailabilitiesReturns(r0 ServerAvailabilities, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getServerAvailabilities.results = fakeGetServerAvailabilitiesResults{r0, r1}
+}
+
+// GetServerAvailabilitiesReturnsOnCall sets the results of the ith call of GetServerAvailabilities, from 0
+func (f *FakeServerService) GetServerAvailabilitiesReturnsOnCall(i int, r0 ServerAvailabilities, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getServerAvailabilities.returnsOnCall(i, fakeGetServerAvailabilitiesResults{r0, r1})
+}
+
+type fakeGetTasksResults struct {
+	r0 *[]Task
+	r1 error
+}
+
+// GetTasks records the call and returns the scripted results
+func (f *FakeServerService) GetTasks() (*[]Task, error) {
+	return f.GetTasksContext(context.Background())
+}
+
+// GetTasksContext records the call and returns the scripted results
+func (f *FakeServerService) GetTasksContext(ctx context.Context) (*[]Task, error) {
+	i := f.record("GetTasks")
+	if f.GetTasksFunc != nil {
+		return f.GetTasksFunc(ctx)
+	}
+	f.mu.Lock()
+	r := f.getTasks.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetTasksReturns sets the results of the calls of GetTasks
+func (f *FakeServerService) GetTasksReturns(r0 *[]Task, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getTasks.results = fakeGetTasksResults{r0, r1}
+}
+
+// GetTasksReturnsOnCall sets the results of the ith call of GetTasks, from 0
+func (f *FakeServerService) GetTasksReturnsOnCall(i int, r0 *[]Task, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getTasks.returnsOnCall(i, fakeGetTasksResults{r0, r1})
+}
+
+type fakeListTasksIterResults struct {
+	r0 *Iterator[Task]
+}
+
+// ListTasksIter records the call and returns the scripted results
+func (f *FakeServerService) ListTasksIter(ctx context.Context) *Iterator[Task] {
+	i := f.record("ListTasksIter")
+	if f.ListTasksIterFunc != nil {
+		return f.ListTasksIterFunc(ctx)
+	}
+	f.mu.Lock()
+	r := f.listTasksIter.next(i)
+	f.mu.Unlock()
+	if r.r0 == nil {
+		r.r0 = SliceIterator[Task](nil, nil)
+	}
+	return r.r0
+}
+
+// ListTasksIterReturns sets the results of the calls of ListTasksIter
+func (f *FakeServerService) ListTasksIterReturns(r0 *Iterator[Task]) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listTasksIter.results = fakeListTasksIterResults{r0}
+}
+
+// ListTasksIterReturnsOnCall sets the results of the ith call of ListTasksIter, from 0
+func (f *FakeServerService) ListTasksIterReturnsOnCall(i int, r0 *Iterator[Task]) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listTasksIter.returnsOnCall(i, fakeListTasksIterResults{r0})
+}
+
+type fakeGetTaskResults struct {
+	r0 *Task
+	r1 error
+}
+
+// GetTask records the call and returns the scripted results
+func (f *FakeServerService) GetTask(taskID string) (*Task, error) {
+	return f.GetTaskContext(context.Background(), taskID)
+}
+
+// GetTaskContext records the call and returns the scripted results
+func (f *FakeServerService) GetTaskContext(ctx context.Context, taskID string) (*Task, error) {
+	i := f.record("GetTask", taskID)
+	if f.GetTaskFunc != nil {
+		return f.GetTaskFunc(ctx, taskID)
+	}
+	f.mu.Lock()
+	r := f.getTask.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetTaskReturns sets the results of the calls of GetTask
+func (f *FakeServerService) GetTaskReturns(r0 *Task, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getTask.results = fakeGetTaskResults{r0, r1}
+}
+
+// GetTaskReturnsOnCall sets the results of the ith call of GetTask, from 0
+func (f *FakeServerService) GetTaskReturnsOnCall(i int, r0 *Task, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getTask.returnsOnCall(i, fakeGetTaskResults{r0, r1})
+}
+
+// GetTaskArgsForCall returns the arguments of the ith call of GetTask, from 0
+func (f *FakeServerService) GetTaskArgsForCall(i int) string {
+	args := f.args("GetTask", i)
+	return args[0].(string)
+}
+
+type fakeFilterTasksResults struct {
+	r0 []Task
+	r1 error
+}
+
+// FilterTasks records the call and returns the scripted results
+func (f *FakeServerService) FilterTasks(filter TaskFilter) ([]Task, error) {
+	return f.FilterTasksContext(context.Background(), filter)
+}
+
+// FilterTasksContext records the call and returns the scripted results
+func (f *FakeServerService) FilterTasksContext(ctx context.Context, filter TaskFilter) ([]Task, error) {
+	i := f.record("FilterTasks", filter)
+	if f.FilterTasksFunc != nil {
+		return f.FilterTasksFunc(ctx, filter)
+	}
+	f.mu.Lock()
+	r := f.filterTasks.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// FilterTasksReturns sets the results of the calls of FilterTasks
+func (f *FakeServerService) FilterTasksReturns(r0 []Task, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.filterTasks.results = fakeFilterTasksResults{r0, r1}
+}
+
+// FilterTasksReturnsOnCall sets the results of the ith call of FilterTasks, from 0
+func (f *FakeServerService) FilterTasksReturnsOnCall(i int, r0 []Task, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.filterTasks.returnsOnCall(i, fakeFilterTasksResults{r0, r1})
+}
+
+// FilterTasksArgsForCall returns the arguments of the ith call of FilterTasks, from 0
+func (f *FakeServerService) FilterTasksArgsForCall(i int) TaskFilter {
+	args := f.args("FilterTasks", i)
+	return args[0].(TaskFilter)
+}
+
+type fakeWaitForTaskResults struct {
+	r0 *Task
+	r1 error
+}
+
+// WaitForTask records the call and returns the scripted results
+func (f *FakeServerService) WaitForTask(ctx context.Context, taskID string, opts TaskWaitOptions) (*Task, error) {
+	i := f.record("WaitForTask", taskID, opts)
+	if f.WaitForTaskFunc != nil {
+		return f.WaitForTaskFunc(ctx, taskID, opts)
+	}
+	f.mu.Lock()
+	r := f.waitForTask.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// WaitForTaskReturns sets the results of the calls of WaitForTask
+func (f *FakeServerService) WaitForTaskReturns(r0 *Task, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.waitForTask.results = fakeWaitForTaskResults{r0, r1}
+}
+
+// WaitForTaskReturnsOnCall sets the results of the ith call of WaitForTask, from 0
+func (f *FakeServerService) WaitForTaskReturnsOnCall(i int, r0 *Task, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.waitForTask.returnsOnCall(i, fakeWaitForTaskResults{r0, r1})
+}
+
+// WaitForTaskArgsForCall returns the arguments of the ith call of WaitForTask, from 0
+func (f *FakeServerService) WaitForTaskArgsForCall(i int) (string, TaskWaitOptions) {
+	args := f.args("WaitForTask", i)
+	return args[0].(string), args[1].(TaskWaitOptions)
+}
+
+type fakeCloneServerResults struct {
+	r0 *Server
+	r1 error
+}
+
+// CloneServer records the call and returns the scripted results
+func (f *FakeServerService) CloneServer(ctx context.Context, serverID string, opts CloneOptions) (*Server, error) {
+	i := f.record("CloneServer", serverID, opts)
+	if f.CloneServerFunc != nil {
+		return f.CloneServerFunc(ctx, serverID, opts)
+	}
+	f.mu.Lock()
+	r := f.cloneServer.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// CloneServerReturns sets the results of the calls of CloneServer
+func (f *FakeServerService) CloneServerReturns(r0 *Server, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cloneServer.results = fakeCloneServerResults{r0, r1}
+}
+
+// CloneServerReturnsOnCall sets the results of the ith call of CloneServer, from 0
+func (f *FakeServerService) CloneServerReturnsOnCall(i int, r0 *Server, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cloneServer.returnsOnCall(i, fakeCloneServerResults{r0, r1})
+}
+
+// CloneServerArgsForCall returns the arguments of the ith call of CloneServer, from 0
+func (f *FakeServerService) CloneServerArgsForCall(i int) (string, CloneOptions) {
+	args := f.args("CloneServer", i)
+	return args[0].(string), args[1].(CloneOptions)
+}
+
+type fakeDestroyServerResults struct {
+	r0 *DestroyReport
+	r1 error
+}
+
+// DestroyServer records the call and returns the scripted results
+func (f *FakeServerService) DestroyServer(ctx context.Context, serverID string, opts DestroyOptions) (*DestroyReport, error) {
+	i := f.record("DestroyServer", serverID, opts)
+	if f.DestroyServerFunc != nil {
+		return f.DestroyServerFunc(ctx, serverID, opts)
+	}
+	f.mu.Lock()
+	r := f.destroyServer.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// DestroyServerReturns sets the results of the calls of DestroyServer
+func (f *FakeServerService) DestroyServerReturns(r0 *DestroyReport, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.destroyServer.results = fakeDestroyServerResults{r0, r1}
+}
+
+// DestroyServerReturnsOnCall sets the results of the ith call of DestroyServer, from 0
+func (f *FakeServerService) DestroyServerReturnsOnCall(i int, r0 *DestroyReport, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.destroyServer.returnsOnCall(i, fakeDestroyServerResults{r0, r1})
+}
+
+// DestroyServerArgsForCall returns the arguments of the ith call of DestroyServer, from 0
+func (f *FakeServerService) DestroyServerArgsForCall(i int) (string, DestroyOptions) {
+	args := f.args("DestroyServer", i)
+	return args[0].(string), args[1].(DestroyOptions)
+}
+
+type fakeAddServerTagsResults struct {
+	r0 error
+}
+
+// AddServerTags records the call and returns the scripted results
+func (f *FakeServerService) AddServerTags(serverID string, tags []string) error {
+	return f.AddServerTagsContext(context.Background(), serverID, tags)
+}
+
+// AddServerTagsContext records the call and returns the scripted results
+func (f *FakeServerService) AddServerTagsContext(ctx context.Context, serverID string, tags []string) error {
+	i := f.record("AddServerTags", serverID, tags)
+	if f.AddServerTagsFunc != nil {
+		return f.AddServerTagsFunc(ctx, serverID, tags)
+	}
+	f.mu.Lock()
+	r := f.addServerTags.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// AddServerTagsReturns sets the results of the calls of AddServerTags
+func (f *FakeServerService) AddServerTagsReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.addServerTags.results = fakeAddServerTagsResults{r0}
+}
+
+// AddServerTagsReturnsOnCall sets the results of the ith call of AddServerTags, from 0
+func (f *FakeServerService) AddServerTagsReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.addServerTags.returnsOnCall(i, fakeAddServerTagsResults{r0})
+}
+
+// AddServerTagsArgsForCall returns the arguments of the ith call of AddServerTags, from 0
+func (f *FakeServerService) AddServerTagsArgsForCall(i int) (string, []string) {
+	args := f.args("AddServerTags", i)
+	return args[0].(string), args[1].([]string)
+}
+
+type fakeRemoveServerTagsResults struct {
+	r0 error
+}
+
+// RemoveServerTags records the call and returns the scripted results
+func (f *FakeServerService) RemoveServerTags(serverID string, tags []string) error {
+	return f.RemoveServerTagsContext(context.Background(), serverID, tags)
+}
+
+// RemoveServerTagsContext records the call and returns the scripted results
+func (f *FakeServerService) RemoveServerTagsContext(ctx context.Context, serverID string, tags []string) error {
+	i := f.record("RemoveServerTags", serverID, tags)
+	if f.RemoveServerTagsFunc != nil {
+		return f.RemoveServerTagsFunc(ctx, serverID, tags)
+	}
+	f.mu.Lock()
+	r := f.removeServerTags.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// RemoveServerTagsReturns sets the results of the calls of RemoveServerTags
+func (f *FakeServerService) RemoveServerTagsReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.removeServerTags.results = fakeRemoveServerTagsResults{r0}
+}
+
+// RemoveServerTagsReturnsOnCall sets the results of the ith call of RemoveServerTags, from 0
+func (f *FakeServerService) RemoveServerTagsReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.removeServerTags.returnsOnCall(i, fakeRemoveServerTagsResults{r0})
+}
+
+// RemoveServerTagsArgsForCall returns the arguments of the ith call of RemoveServerTags, from 0
+func (f *FakeServerService) RemoveServerTagsArgsForCall(i int) (string, []string) {
+	args := f.args("RemoveServerTags", i)
+	return args[0].(string), args[1].([]string)
+}
+
+type fakeSetServerLabelResults struct {
+	r0 error
+}
+
+// SetServerLabel records the call and returns the scripted results
+func (f *FakeServerService) SetServerLabel(serverID string, key string, value string) error {
+	return f.SetServerLabelContext(context.Background(), serverID, key, value)
+}
+
+// SetServerLabelContext records the call and returns the scripted results
+func (f *FakeServerService) SetServerLabelContext(ctx context.Context, serverID string, key string, value string) error {
+	i := f.record("SetServerLabel", serverID, key, value)
+	if f.SetServerLabelFunc != nil {
+		return f.SetServerLabelFunc(ctx, serverID, key, value)
+	}
+	f.mu.Lock()
+	r := f.setServerLabel.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// SetServerLabelReturns sets the results of the calls of SetServerLabel
+func (f *FakeServerService) SetServerLabelReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.setServerLabel.results = fakeSetServerLabelResults{r0}
+}
+
+// SetServerLabelReturnsOnCall sets the results of the ith call of SetServerLabel, from 0
+func (f *FakeServerService) SetServerLabelReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.setServerLabel.returnsOnCall(i, fakeSetServerLabelResults{r0})
+}
+
+// SetServerLabelArgsForCall returns the arguments of the ith call of SetServerLabel, from 0
+func (f *FakeServerService) SetServerLabelArgsForCall(i int) (string, string, string) {
+	args := f.args("SetServerLabel", i)
+	return args[0].(string), args[1].(string), args[2].(string)
+}
+
+// FakeVolumeService is a VolumeService recording its calls and returning scripted results,
+// the calls which aren't scripted return the zero values
+type FakeVolumeService struct {
+	fakeRecorder
+
+	// GetVolumesFunc, if set, answers the calls of GetVolumes
+	GetVolumesFunc func(context.Context) (*[]Volume, error)
+
+	// GetVolumeFunc, if set, answers the calls of GetVolume
+	GetVolumeFunc func(context.Context, string) (*Volume, error)
+
+	// PostVolumeFunc, if set, answers the calls of PostVolume
+	PostVolumeFunc func(context.Context, VolumeDefinition) (string, error)
+
+	// PutVolumeFunc, if set, answers the calls of PutVolume
+	PutVolumeFunc func(context.Context, string, VolumePutDefinition) error
+
+	// DeleteVolumeFunc, if set, answers the calls of DeleteVolume
+	DeleteVolumeFunc func(context.Context, string) error
+
+	// ListVolumesIterFunc, if set, answers the calls of ListVolumesIter
+	ListVolumesIterFunc func(context.Context) *Iterator[Volume]
+
+	getVolumes      fakeScript[fakeGetVolumesResults]
+	getVolume       fakeScript[fakeGetVolumeResults]
+	postVolume      fakeScript[fakePostVolumeResults]
+	putVolume       fakeScript[fakePutVolumeResults]
+	deleteVolume    fakeScript[fakeDeleteVolumeResults]
+	listVolumesIter fakeScript[fakeListVolumesIterResults]
+}
+
+var _ VolumeService = (*FakeVolumeService)(nil)
+
+type fakeGetVolumesResults struct {
+	r0 *[]Volume
+	r1 error
+}
+
+// GetVolumes records the call and returns the scripted results
+func (f *FakeVolumeService) GetVolumes() (*[]Volume, error) {
+	return f.GetVolumesContext(context.Background())
+}
+
+// GetVolumesContext records the call and returns the scripted results
+func (f *FakeVolumeService) GetVolumesContext(ctx context.Context) (*[]Volume, error) {
+	i := f.record("GetVolumes")
+	if f.GetVolumesFunc != nil {
+		return f.GetVolumesFunc(ctx)
+	}
+	f.mu.Lock()
+	r := f.getVolumes.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetVolumesReturns sets the results of the calls of GetVolumes
+func (f *FakeVolumeService) GetVolumesReturns(r0 *[]Volume, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getVolumes.results = fakeGetVolumesResults{r0, r1}
+}
+
+// GetVolumesReturnsOnCall sets the results of the ith call of GetVolumes, from 0
+func (f *FakeVolumeService) GetVolumesReturnsOnCall(i int, r0 *[]Volume, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getVolumes.returnsOnCall(i, fakeGetVolumesResults{r0, r1})
+}
+
+type fakeGetVolumeResults struct {
+	r0 *Volume
+	r1 error
+}
+
+// GetVolume records the call and returns the scripted results
+func (f *FakeVolumeService) GetVolume(volumeID string) (*Volume, error) {
+	return f.GetVolumeContext(context.Background(), volumeID)
+}
+
+// GetVolumeContext records the call and returns the scripted results
+func (f *FakeVolumeService) GetVolumeContext(ctx context.Context, volumeID string) (*Volume, error) {
+	i := f.record("GetVolume", volumeID)
+	if f.GetVolumeFunc != nil {
+		return f.GetVolumeFunc(ctx, volumeID)
+	}
+	f.mu.Lock()
+	r := f.getVolume.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetVolumeReturns sets the results of the calls of GetVolume
+func (f *FakeVolumeService) GetVolumeReturns(r0 *Volume, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getVolume.results = fakeGetVolumeResults{r0, r1}
+}
+
+// GetVolumeReturnsOnCall sets the results of the ith call of GetVolume, from 0
+func (f *FakeVolumeService) GetVolumeReturnsOnCall(i int, r0 *Volume, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getVolume.returnsOnCall(i, fakeGetVolumeResults{r0, r1})
+}
+
+// GetVolumeArgsForCall returns the arguments of the ith call of GetVolume, from 0
+func (f *FakeVolumeService) GetVolumeArgsForCall(i int) string {
+	args := f.args("GetVolume", i)
+	return args[0].(string)
+}
+
+type fakePostVolumeResults struct {
+	r0 string
+	r1 error
+}
+
+// PostVolume records the call and returns the scripted results
+func (f *FakeVolumeService) PostVolume(definition VolumeDefinition) (string, error) {
+	return f.PostVolumeContext(context.Background(), definition)
+}
+
+// PostVolumeContext records the call and returns the scripted results
+func (f *FakeVolumeService) PostVolumeContext(ctx context.Context, definition VolumeDefinition) (string, error) {
+	i := f.record("PostVolume", definition)
+	if f.PostVolumeFunc != nil {
+		return f.PostVolumeFunc(ctx, definition)
+	}
+	f.mu.Lock()
+	r := f.postVolume.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// PostVolumeReturns sets the results of the calls of PostVolume
+func (f *FakeVolumeService) PostVolumeReturns(r0 string, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.postVolume.results = fakePostVolumeResults{r0, r1}
+}
+
+// PostVolumeReturnsOnCall sets the results of the ith call of PostVolume, from 0
+func (f *FakeVolumeService) PostVolumeReturnsOnCall(i int, r0 string, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.postVolume.returnsOnCall(i, fakePostVolumeResults{r0, r1})
+}
+
+// PostVolumeArgsForCall returns the arguments of the ith call of PostVolume, from 0
+func (f *FakeVolumeService) PostVolumeArgsForCall(i int) VolumeDefinition {
+	args := f.args("PostVolume", i)
+	return args[0].(VolumeDefinition)
+}
+
+type fakePutVolumeResults struct {
+	r0 error
+}
+
+// PutVolume records the call and returns the scripted results
+func (f *FakeVolumeService) PutVolume(volumeID string, definition VolumePutDefinition) error {
+	return f.PutVolumeContext(context.Background(), volumeID, definition)
+}
+
+// PutVolumeContext records the call and returns the scripted results
+func (f *FakeVolumeService) PutVolumeContext(ctx context.Context, volumeID string, definition VolumePutDefinition) error {
+	i := f.record("PutVolume", volumeID, definition)
+	if f.PutVolumeFunc != nil {
+		return f.PutVolumeFunc(ctx, volumeID, definition)
+	}
+	f.mu.Lock()
+	r := f.putVolume.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// PutVolumeReturns sets the results of the calls of PutVolume
+func (f *FakeVolumeService) PutVolumeReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.putVolume.results = fakePutVolumeResults{r0}
+}
+
+// PutVolumeReturnsOnCall sets the results of the ith call of PutVolume, from 0
+func (f *FakeVolumeService) PutVolumeReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.putVolume.returnsOnCall(i, fakePutVolumeResults{r0})
+}
+
+// PutVolumeArgsForCall returns the arguments of the ith call of PutVolume, from 0
+func (f *FakeVolumeService) PutVolumeArgsForCall(i int) (string, VolumePutDefinition) {
+	args := f.args("PutVolume", i)
+	return args[0].(string), args[1].(VolumePutDefinition)
+}
+
+type fakeDeleteVolumeResults struct {
+	r0 error
+}
+
+// DeleteVolume records the call and returns the scripted results
+func (f *FakeVolumeService) DeleteVolume(volumeID string) error {
+	return f.DeleteVolumeContext(context.Background(), volumeID)
+}
+
+// DeleteVolumeContext records the call and returns the scripted results
+func (f *FakeVolumeService) DeleteVolumeContext(ctx context.Context, volumeID string) error {
+	i := f.record("DeleteVolume", volumeID)
+	if f.DeleteVolumeFunc != nil {
+		return f.DeleteVolumeFunc(ctx, volumeID)
+	}
+	f.mu.Lock()
+	r := f.deleteVolume.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// DeleteVolumeReturns sets the results of the calls of DeleteVolume
+func (f *FakeVolumeService) DeleteVolumeReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteVolume.results = fakeDeleteVolumeResults{r0}
+}
+
+// DeleteVolumeReturnsOnCall sets the results of the ith call of DeleteVolume, from 0
+func (f *FakeVolumeService) DeleteVolumeReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteVolume.returnsOnCall(i, fakeDeleteVolumeResults{r0})
+}
+
+// DeleteVolumeArgsForCall returns the arguments of the ith call of DeleteVolume, from 0
+func (f *FakeVolumeService) DeleteVolumeArgsForCall(i int) string {
+	args := f.args("DeleteVolume", i)
+	return args[0].(string)
+}
+
+type fakeListVolumesIterResults struct {
+	r0 *Iterator[Volume]
+}
+
+// ListVolumesIter records the call and returns the scripted results
+func (f *FakeVolumeService) ListVolumesIter(ctx context.Context) *Iterator[Volume] {
+	i := f.record("ListVolumesIter")
+	if f.ListVolumesIterFunc != nil {
+		return f.ListVolumesIterFunc(ctx)
+	}
+	f.mu.Lock()
+	r := f.listVolumesIter.next(i)
+	f.mu.Unlock()
+	if r.r0 == nil {
+		r.r0 = SliceIterator[Volume](nil, nil)
+	}
+	return r.r0
+}
+
+// ListVolumesIterReturns sets the results of the calls of ListVolumesIter
+func (f *FakeVolumeService) ListVolumesIterReturns(r0 *Iterator[Volume]) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listVolumesIter.results = fakeListVolumesIterResults{r0}
+}
+
+// ListVolumesIterReturnsOnCall sets the results of the ith call of ListVolumesIter, from 0
+func (f *FakeVolumeService) ListVolumesIterReturnsOnCall(i int, r0 *Iterator[Volume]) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listVolumesIter.returnsOnCall(i, fakeListVolumesIterResults{r0})
+}
+
+// FakeSnapshotService is a SnapshotService recording its calls and returning scripted results,
+// the calls which aren't scripted return the zero values
+type FakeSnapshotService struct {
+	fakeRecorder
+
+	// GetSnapshotsFunc, if set, answers the calls of GetSnapshots
+	GetSnapshotsFunc func(context.Context) (*[]Snapshot, error)
+
+	// GetSnapshotFunc, if set, answers the calls of GetSnapshot
+	GetSnapshotFunc func(context.Context, string) (*Snapshot, error)
+
+	// PostSnapshotFunc, if set, answers the calls of PostSnapshot
+	PostSnapshotFunc func(context.Context, string, string) (string, error)
+
+	// ImportSnapshotFunc, if set, answers the calls of ImportSnapshot
+	ImportSnapshotFunc func(context.Context, string, string, string) (string, error)
+
+	// ExportSnapshotFunc, if set, answers the calls of ExportSnapshot
+	ExportSnapshotFunc func(context.Context, string, string, string) (*Task, error)
+
+	// DeleteSnapshotFunc, if set, answers the calls of DeleteSnapshot
+	DeleteSnapshotFunc func(context.Context, string) error
+
+	// ListSnapshotsIterFunc, if set, answers the calls of ListSnapshotsIter
+	ListSnapshotsIterFunc func(context.Context) *Iterator[Snapshot]
+
+	getSnapshots      fakeScript[fakeGetSnapshotsResults]
+	getSnapshot       fakeScript[fakeGetSnapshotResults]
+	postSnapshot      fakeScript[fakePostSnapshotResults]
+	importSnapshot    fakeScript[fakeImportSnapshotResults]
+	exportSnapshot    fakeScript[fakeExportSnapshotResults]
+	deleteSnapshot    fakeScript[fakeDeleteSnapshotResults]
+	listSnapshotsIter fakeScript[fakeListSnapshotsIterResults]
+}
+
+var _ SnapshotService = (*FakeSnapshotService)(nil)
+
+type fakeGetSnapshotsResults struct {
+	r0 *[]Snapshot
+	r1 error
+}
+
+// GetSnapshots records the call and returns the scripted results
+func (f *FakeSnapshotService) GetSnapshots() (*[]Snapshot, error) {
+	return f.GetSnapshotsContext(context.Background())
+}
+
+// GetSnapshotsContext records the call and returns the scripted results
+func (f *FakeSnapshotService) GetSnapshotsContext(ctx context.Context) (*[]Snapshot, error) {
+	i := f.record("GetSnapshots")
+	if f.GetSnapshotsFunc != nil {
+		return f.GetSnapshotsFunc(ctx)
+	}
+	f.mu.Lock()
+	r := f.getSnapshots.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetSnapshotsReturns sets the results of the calls of GetSnapshots
+func (f *FakeSnapshotService) GetSnapshotsReturns(r0 *[]Snapshot, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getSnapshots.results = fakeGetSnapshotsResults{r0, r1}
+}
+
+// GetSnapshotsReturnsOnCall sets the results of the ith call of GetSnapshots, from 0
+func (f *FakeSnapshotService) GetSnapshotsReturnsOnCall(i int, r0 *[]Snapshot, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getSnapshots.returnsOnCall(i, fakeGetSnapshotsResults{r0, r1})
+}
+
+type fakeGetSnapshotResults struct {
+	r0 *Snapshot
+	r1 error
+}
+
+// GetSnapshot records the call and returns the scripted results
+func (f *FakeSnapshotService) GetSnapshot(snapshotID string) (*Snapshot, error) {
+	return f.GetSnapshotContext(context.Background(), snapshotID)
+}
+
+// GetSnapshotContext records the call and returns the scripted results
+func (f *FakeSnapshotService) GetSnapshotContext(ctx context.Context, snapshotID string) (*Snapshot, error) {
+	i := f.record("GetSnapshot", snapshotID)
+	if f.GetSnapshotFunc != nil {
+		return f.GetSnapshotFunc(ctx, snapshotID)
+	}
+	f.mu.Lock()
+	r := f.getSnapshot.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetSnapshotReturns sets the results of the calls of GetSnapshot
+func (f *FakeSnapshotService) GetSnapshotReturns(r0 *Snapshot, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getSnapshot.results = fakeGetSnapshotResults{r0, r1}
+}
+
+// GetSnapshotReturnsOnCall sets the results of the ith call of GetSnapshot, from 0
+func (f *FakeSnapshotService) GetSnapshotReturnsOnCall(i int, r0 *Snapshot, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getSnapshot.returnsOnCall(i, fakeGetSnapshotResults{r0, r1})
+}
+
+// GetSnapshotArgsForCall returns the arguments of the ith call of GetSnapshot, from 0
+func (f *FakeSnapshotService) GetSnapshotArgsForCall(i int) string {
+	args := f.args("GetSnapshot", i)
+	return args[0].(string)
+}
+
+type fakePostSnapshotResults struct {
+	r0 string
+	r1 error
+}
+
+// PostSnapshot records the call and returns the scripted results
+func (f *FakeSnapshotService) PostSnapshot(volumeID string, name string) (string, error) {
+	return f.PostSnapshotContext(context.Background(), volumeID, name)
+}
+
+// PostSnapshotContext records the call and returns the scripted results
+func (f *FakeSnapshotService) PostSnapshotContext(ctx context.Context, volumeID string, name string) (string, error) {
+	i := f.record("PostSnapshot", volumeID, name)
+	if f.PostSnapshotFunc != nil {
+		return f.PostSnapshotFunc(ctx, volumeID, name)
+	}
+	f.mu.Lock()
+	r := f.postSnapshot.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// PostSnapshotReturns sets the results of the calls of PostSnapshot
+func (f *FakeSnapshotService) PostSnapshotReturns(r0 string, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.postSnapshot.results = fakePostSnapshotResults{r0, r1}
+}
+
+// PostSnapshotReturnsOnCall sets the results of the ith call of PostSnapshot, from 0
+func (f *FakeSnapshotService) PostSnapshotReturnsOnCall(i int, r0 string, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.postSnapshot.returnsOnCall(i, fakePostSnapshotResults{r0, r1})
+}
+
+// PostSnapshotArgsForCall returns the arguments of the ith call of PostSnapshot, from 0
+func (f *FakeSnapshotService) PostSnapshotArgsForCall(i int) (string, string) {
+	args := f.args("PostSnapshot", i)
+	return args[0].(string), args[1].(string)
+}
+
+type fakeImportSnapshotResults struct {
+	r0 string
+	r1 error
+}
+
+// ImportSnapshot records the call and returns the scripted results
+func (f *FakeSnapshotService) ImportSnapshot(bucket string, key string, name string) (string, error) {
+	return f.ImportSnapshotContext(context.Background(), bucket, key, name)
+}
+
+// ImportSnapshotContext records the call and returns the scripted results
+func (f *FakeSnapshotService) ImportSnapshotContext(ctx context.Context, bucket string, key string, name string) (string, error) {
+	i := f.record("ImportSnapshot", bucket, key, name)
+	if f.ImportSnapshotFunc != nil {
+		return f.ImportSnapshotFunc(ctx, bucket, key, name)
+	}
+	f.mu.Lock()
+	r := f.importSnapshot.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// ImportSnapshotReturns sets the results of the calls of ImportSnapshot
+func (f *FakeSnapshotService) ImportSnapshotReturns(r0 string, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.importSnapshot.results = fakeImportSnapshotResults{r0, r1}
+}
+
+// ImportSnapshotReturnsOnCall sets the results of the ith call of ImportSnapshot, from 0
+func (f *FakeSnapshotService) ImportSnapshotReturnsOnCall(i int, r0 string, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.importSnapshot.returnsOnCall(i, fakeImportSnapshotResults{r0, r1})
+}
+
+// ImportSnapshotArgsForCall returns the arguments of the ith call of ImportSnapshot, from 0
+func (f *FakeSnapshotService) ImportSnapshotArgsForCall(i int) (string, string, string) {
+	args := f.args("ImportSnapshot", i)
+	return args[0].(string), args[1].(string), args[2].(string)
+}
+
+type fakeExportSnapshotResults struct {
+	r0 *Task
+	r1 error
+}
+
+// ExportSnapshot records the call and returns the scripted results
+func (f *FakeSnapshotService) ExportSnapshot(snapshotID string, bucket string, key string) (*Task, error) {
+	return f.ExportSnapshotContext(context.Background(), snapshotID, bucket, key)
+}
+
+// ExportSnapshotContext records the call and returns the scripted results
+func (f *FakeSnapshotService) ExportSnapshotContext(ctx context.Context, snapshotID string, bucket string, key string) (*Task, error) {
+	i := f.record("ExportSnapshot", snapshotID, bucket, key)
+	if f.ExportSnapshotFunc != nil {
+		return f.ExportSnapshotFunc(ctx, snapshotID, bucket, key)
+	}
+	f.mu.Lock()
+	r := f.exportSnapshot.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// ExportSnapshotReturns sets the results of the calls of ExportSnapshot
+func (f *FakeSnapshotService) ExportSnapshotReturns(r0 *Task, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.exportSnapshot.results = fakeExportSnapshotResults{r0, r1}
+}
+
+// ExportSnapshotReturnsOnCall sets the results of the ith call of ExportSnapshot, from 0
+func (f *FakeSnapshotService) ExportSnapshotReturnsOnCall(i int, r0 *Task, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.exportSnapshot.returnsOnCall(i, fakeExportSnapshotResults{r0, r1})
+}
+
+// ExportSnapshotArgsForCall returns the arguments of the ith call of ExportSnapshot, from 0
+func (f *FakeSnapshotService) ExportSnapshotArgsForCall(i int) (string, string, string) {
+	args := f.args("ExportSnapshot", i)
+	return args[0].(string), args[1].(string), args[2].(string)
+}
+
+type fakeDeleteSnapshotResults struct {
+	r0 error
+}
+
+// DeleteSnapshot records the call and returns the scripted results
+func (f *FakeSnapshotService) DeleteSnapshot(snapshotID string) error {
+	return f.DeleteSnapshotContext(context.Background(), snapshotID)
+}
+
+// DeleteSnapshotContext records the call and returns the scripted results
+func (f *FakeSnapshotService) DeleteSnapshotContext(ctx context.Context, snapshotID string) error {
+	i := f.record("DeleteSnapshot", snapshotID)
+	if f.DeleteSnapshotFunc != nil {
+		return f.DeleteSnapshotFunc(ctx, snapshotID)
+	}
+	f.mu.Lock()
+	r := f.deleteSnapshot.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// DeleteSnapshotReturns sets the results of the calls of DeleteSnapshot
+func (f *FakeSnapshotService) DeleteSnapshotReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteSnapshot.results = fakeDeleteSnapshotResults{r0}
+}
+
+// DeleteSnapshotReturnsOnCall sets the results of the ith call of DeleteSnapshot, from 0
+func (f *FakeSnapshotService) DeleteSnapshotReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteSnapshot.returnsOnCall(i, fakeDeleteSnapshotResults{r0})
+}
+
+// DeleteSnapshotArgsForCall returns the arguments of the ith call of DeleteSnapshot, from 0
+func (f *FakeSnapshotService) DeleteSnapshotArgsForCall(i int) string {
+	args := f.args("DeleteSnapshot", i)
+	return args[0].(string)
+}
+
+type fakeListSnapshotsIterResults struct {
+	r0 *Iterator[Snapshot]
+}
+
+// ListSnapshotsIter records the call and returns the scripted results
+func (f *FakeSnapshotService) ListSnapshotsIter(ctx context.Context) *Iterator[Snapshot] {
+	i := f.record("ListSnapshotsIter")
+	if f.ListSnapshotsIterFunc != nil {
+		return f.ListSnapshotsIterFunc(ctx)
+	}
+	f.mu.Lock()
+	r := f.listSnapshotsIter.next(i)
+	f.mu.Unlock()
+	if r.r0 == nil {
+		r.r0 = SliceIterator[Snapshot](nil, nil)
+	}
+	return r.r0
+}
+
+// ListSnapshotsIterReturns sets the results of the calls of ListSnapshotsIter
+func (f *FakeSnapshotService) ListSnapshotsIterReturns(r0 *Iterator[Snapshot]) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listSnapshotsIter.results = fakeListSnapshotsIterResults{r0}
+}
+
+// ListSnapshotsIterReturnsOnCall sets the results of the ith call of ListSnapshotsIter, from 0
+func (f *FakeSnapshotService) ListSnapshotsIterReturnsOnCall(i int, r0 *Iterator[Snapshot]) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listSnapshotsIter.returnsOnCall(i, fakeListSnapshotsIterResults{r0})
+}
+
+// FakeImageService is a ImageService recording its calls and returning scripted results,
+// the calls which aren't scripted return the zero values
+type FakeImageService struct {
+	fakeRecorder
+
+	// GetImagesFunc, if set, answers the calls of GetImages
+	GetImagesFunc func(context.Context) (*[]MarketImage, error)
+
+	// GetImageFunc, if set, answers the calls of GetImage
+	GetImageFunc func(context.Context, string) (*Image, error)
+
+	// PostImageFunc, if set, answers the calls of PostImage
+	PostImageFunc func(context.Context, string, string, string, string) (string, error)
+
+	// DeleteImageFunc, if set, answers the calls of DeleteImage
+	DeleteImageFunc func(context.Context, string) error
+
+	// ListImagesIterFunc, if set, answers the calls of ListImagesIter
+	ListImagesIterFunc func(context.Context) *Iterator[Image]
+
+	// GetBootscriptsFunc, if set, answers the calls of GetBootscripts
+	GetBootscriptsFunc func(context.Context) ([]Bootscript, error)
+
+	// GetBootscriptFunc, if set, answers the calls of GetBootscript
+	GetBootscriptFunc func(context.Context, string) (*Bootscript, error)
+
+	// ListBootscriptsIterFunc, if set, answers the calls of ListBootscriptsIter
+	ListBootscriptsIterFunc func(context.Context) *Iterator[Bootscript]
+
+	getImages           fakeScript[fakeGetImagesResults]
+	getImage            fakeScript[fakeGetImageResults]
+	postImage           fakeScript[fakePostImageResults]
+	deleteImage         fakeScript[fakeDeleteImageResults]
+	listImagesIter      fakeScript[fakeListImagesIterResults]
+	getBootscripts      fakeScript[fakeGetBootscriptsResults]
+	getBootscript       fakeScript[fakeGetBootscriptResults]
+	listBootscriptsIter fakeScript[fakeListBootscriptsIterResults]
+}
+
+var _ ImageService = (*FakeImageService)(nil)
+
+type fakeGetImagesResults struct {
+	r0 *[]MarketImage
+	r1 error
+}
+
+// GetImages records the call and returns the scripted results
+func (f *FakeImageService) GetImages() (*[]MarketImage, error) {
+	return f.GetImagesContext(context.Background())
+}
+
+// GetImagesContext records the call and returns the scripted results
+func (f *FakeImageService) GetImagesContext(ctx context.Context) (*[]MarketImage, error) {
+	i := f.record("GetImages")
+	if f.GetImagesFunc != nil {
+		return f.GetImagesFunc(ctx)
+	}
+	f.mu.Lock()
+	r := f.getImages.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetImagesReturns sets the results of the calls of GetImages
+func (f *FakeImageService) GetImagesReturns(r0 *[]MarketImage, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getImages.results = fakeGetImagesResults{r0, r1}
+}
+
+// GetImagesReturnsOnCall sets the results of the ith call of GetImages, from 0
+func (f *FakeImageService) GetImagesReturnsOnCall(i int, r0 *[]MarketImage, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getImages.returnsOnCall(i, fakeGetImagesResults{r0, r1})
+}
+
+type fakeGetImageResults struct {
+	r0 *Image
+	r1 error
+}
+
+// GetImage records the call and returns the scripted results
+func (f *FakeImageService) GetImage(imageID string) (*Image, error) {
+	return f.GetImageContext(context.Background(), imageID)
+}
+
+// GetImageContext records the call and returns the scripted results
+func (f *FakeImageService) GetImageContext(ctx context.Context, imageID string) (*Image, error) {
+	i := f.record("GetImage", imageID)
+	if f.GetImageFunc != nil {
+		return f.GetImageFunc(ctx, imageID)
+	}
+	f.mu.Lock()
+	r := f.getImage.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetImageReturns sets the results of the calls of GetImage
+func (f *FakeImageService) GetImageReturns(r0 *Image, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getImage.results = fakeGetImageResults{r0, r1}
+}
+
+// GetImageReturnsOnCall sets the results of the ith call of GetImage, from 0
+func (f *FakeImageService) GetImageReturnsOnCall(i int, r0 *Image, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getImage.returnsOnCall(i, fakeGetImageResults{r0, r1})
+}
+
+// GetImageArgsForCall returns the arguments of the ith call of GetImage, from 0
+func (f *FakeImageService) GetImageArgsForCall(i int) string {
+	args := f.args("GetImage", i)
+	return args[0].(string)
+}
+
+type fakePostImageResults struct {
+	r0 string
+	r1 error
+}
+
+// PostImage records the call and returns the scripted results
+func (f *FakeImageService) PostImage(volumeID string, name string, bootscript string, arch string) (string, error) {
+	return f.PostImageContext(context.Background(), volumeID, name, bootscript, arch)
+}
+
+// PostImageContext records the call and returns the scripted results
+func (f *FakeImageService) PostImageContext(ctx context.Context, volumeID string, name string, bootscript string, arch string) (string, error) {
+	i := f.record("PostImage", volumeID, name, bootscript, arch)
+	if f.PostImageFunc != nil {
+		return f.PostImageFunc(ctx, volumeID, name, bootscript, arch)
+	}
+	f.mu.Lock()
+	r := f.postImage.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// PostImageReturns sets the results of the calls of PostImage
+func (f *FakeImageService) PostImageReturns(r0 string, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.postImage.results = fakePostImageResults{r0, r1}
+}
+
+// PostImageReturnsOnCall sets the results of the ith call of PostImage, from 0
+func (f *FakeImageService) PostImageReturnsOnCall(i int, r0 string, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.postImage.returnsOnCall(i, fakePostImageResults{r0, r1})
+}
+
+// PostImageArgsForCall returns the arguments of the ith call of PostImage, from 0
+func (f *FakeImageService) PostImageArgsForCall(i int) (string, string, string, string) {
+	args := f.args("PostImage", i)
+	return args[0].(string), args[1].(string), args[2].(string), args[3].(string)
+}
+
+type fakeDeleteImageResults struct {
+	r0 error
+}
+
+// DeleteImage records the call and returns the scripted results
+func (f *FakeImageService) DeleteImage(imageID string) error {
+	return f.DeleteImageContext(context.Background(), imageID)
+}
+
+// DeleteImageContext records the call and returns the scripted results
+func (f *FakeImageService) DeleteImageContext(ctx context.Context, imageID string) error {
+	i := f.record("DeleteImage", imageID)
+	if f.DeleteImageFunc != nil {
+		return f.DeleteImageFunc(ctx, imageID)
+	}
+	f.mu.Lock()
+	r := f.deleteImage.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// DeleteImageReturns sets the results of the calls of DeleteImage
+func (f *FakeImageService) DeleteImageReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteImage.results = fakeDeleteImageResults{r0}
+}
+
+// DeleteImageReturnsOnCall sets the results of the ith call of DeleteImage, from 0
+func (f *FakeImageService) DeleteImageReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteImage.returnsOnCall(i, fakeDeleteImageResults{r0})
+}
+
+// DeleteImageArgsForCall returns the arguments of the ith call of DeleteImage, from 0
+func (f *FakeImageService) DeleteImageArgsForCall(i int) string {
+	args := f.args("DeleteImage", i)
+	return args[0].(string)
+}
+
+type fakeListImagesIterResults struct {
+	r0 *Iterator[Image]
+}
+
+// ListImagesIter records the call and returns the scripted results
+func (f *FakeImageService) ListImagesIter(ctx context.Context) *Iterator[Image] {
+	i := f.record("ListImagesIter")
+	if f.ListImagesIterFunc != nil {
+		return f.ListImagesIterFunc(ctx)
+	}
+	f.mu.Lock()
+	r := f.listImagesIter.next(i)
+	f.mu.Unlock()
+	if r.r0 == nil {
+		r.r0 = SliceIterator[Image](nil, nil)
+	}
+	return r.r0
+}
+
+// ListImagesIterReturns sets the results of the calls of ListImagesIter
+func (f *FakeImageService) ListImagesIterReturns(r0 *Iterator[Image]) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listImagesIter.results = fakeListImagesIterResults{r0}
+}
+
+// ListImagesIterReturnsOnCall sets the results of the ith call of ListImagesIter, from 0
+func (f *FakeImageService) ListImagesIterReturnsOnCall(i int, r0 *Iterator[Image]) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listImagesIter.returnsOnCall(i, fakeListImagesIterResults{r0})
+}
+
+type fakeGetBootscriptsResults struct {
+	r0 []Bootscript
+	r1 error
+}
+
+// GetBootscripts records the call and returns the scripted results
+func (f *FakeImageService) GetBootscripts() ([]Bootscript, error) {
+	return f.GetBootscriptsContext(context.Background())
+}
+
+// GetBootscriptsContext records the call and returns the scripted results
+func (f *FakeImageService) GetBootscriptsContext(ctx context.Context) ([]Bootscript, error) {
+	i := f.record("GetBootscripts")
+	if f.GetBootscriptsFunc != nil {
+		return f.GetBootscriptsFunc(ctx)
+	}
+	f.mu.Lock()
+	r := f.getBootscripts.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetBootscriptsReturns sets the results of the calls of GetBootscripts
+func (f *FakeImageService) GetBootscriptsReturns(r0 []Bootscript, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getBootscripts.results = fakeGetBootscriptsResults{r0, r1}
+}
+
+// GetBootscriptsReturnsOnCall sets the results of the ith call of GetBootscripts, from 0
+func (f *FakeImageService) GetBootscriptsReturnsOnCall(i int, r0 []Bootscript, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getBootscripts.returnsOnCall(i, fakeGetBootscriptsResults{r0, r1})
+}
+
+type fakeGetBootscriptResults struct {
+	r0 *Bootscript
+	r1 error
+}
+
+// GetBootscript records the call and returns the scripted results
+func (f *FakeImageService) GetBootscript(bootscriptID string) (*Bootscript, error) {
+	return f.GetBootscriptContext(context.Background(), bootscriptID)
+}
+
+// GetBootscriptContext records the call and returns the scripted results
+func (f *FakeImageService) GetBootscriptContext(ctx context.Context, bootscriptID string) (*Bootscript, error) {
+	i := f.record("GetBootscript", bootscriptID)
+	if f.GetBootscriptFunc != nil {
+		return f.GetBootscriptFunc(ctx, bootscriptID)
+	}
+	f.mu.Lock()
+	r := f.getBootscript.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetBootscriptReturns sets the results of the calls of GetBootscript
+func (f *FakeImageService) GetBootscriptReturns(r0 *Bootscript, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getBootscript.results = fakeGetBootscriptResults{r0, r1}
+}
+
+// GetBootscriptReturnsOnCall sets the results of the ith call of GetBootscript, from 0
+func (f *FakeImageService) GetBootscriptReturnsOnCall(i int, r0 *Bootscript, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getBootscript.returnsOnCall(i, fakeGetBootscriptResults{r0, r1})
+}
+
+// GetBootscriptArgsForCall returns the arguments of the ith call of GetBootscript, from 0
+func (f *FakeImageService) GetBootscriptArgsForCall(i int) string {
+	args := f.args("GetBootscript", i)
+	return args[0].(string)
+}
+
+type fakeListBootscriptsIterResults struct {
+	r0 *Iterator[Bootscript]
+}
+
+// ListBootscriptsIter records the call and returns the scripted results
+func (f *FakeImageService) ListBootscriptsIter(ctx context.Context) *Iterator[Bootscript] {
+	i := f.record("ListBootscriptsIter")
+	if f.ListBootscriptsIterFunc != nil {
+		return f.ListBootscriptsIterFunc(ctx)
+	}
+	f.mu.Lock()
+	r := f.listBootscriptsIter.next(i)
+	f.mu.Unlock()
+	if r.r0 == nil {
+		r.r0 = SliceIterator[Bootscript](nil, nil)
+	}
+	return r.r0
+}
+
+// ListBootscriptsIterReturns sets the results of the calls of ListBootscriptsIter
+func (f *FakeImageService) ListBootscriptsIterReturns(r0 *Iterator[Bootscript]) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listBootscriptsIter.results = fakeListBootscriptsIterResults{r0}
+}
+
+// ListBootscriptsIterReturnsOnCall sets the results of the ith call of ListBootscriptsIter, from 0
+func (f *FakeImageService) ListBootscriptsIterReturnsOnCall(i int, r0 *Iterator[Bootscript]) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listBootscriptsIter.returnsOnCall(i, fakeListBootscriptsIterResults{r0})
+}
+
+// FakeIPService is a IPService recording its calls and returning scripted results,
+// the calls which aren't scripted return the zero values
+type FakeIPService struct {
+	fakeRecorder
+
+	// GetIPSFunc, if set, answers the calls of GetIPS
+	GetIPSFunc func(context.Context) (*GetIPS, error)
+
+	// GetIPFunc, if set, answers the calls of GetIP
+	GetIPFunc func(context.Context, string) (*GetIP, error)
+
+	// NewIPFunc, if set, answers the calls of NewIP
+	NewIPFunc func(context.Context) (*GetIP, error)
+
+	// AttachIPFunc, if set, answers the calls of AttachIP
+	AttachIPFunc func(context.Context, string, string) error
+
+	// DetachIPFunc, if set, answers the calls of DetachIP
+	DetachIPFunc func(context.Context, string) error
+
+	// DeleteIPFunc, if set, answers the calls of DeleteIP
+	DeleteIPFunc func(context.Context, string) error
+
+	// ListIPsIterFunc, if set, answers the calls of ListIPsIter
+	ListIPsIterFunc func(context.Context) *Iterator[IPV4]
+
+	getIPS      fakeScript[fakeGetIPSResults]
+	getIP       fakeScript[fakeGetIPResults]
+	newIP       fakeScript[fakeNewIPResults]
+	attachIP    fakeScript[fakeAttachIPResults]
+	detachIP    fakeScript[fakeDetachIPResults]
+	deleteIP    fakeScript[fakeDeleteIPResults]
+	listIPsIter fakeScript[fakeListIPsIterResults]
+}
+
+var _ IPService = (*FakeIPService)(nil)
+
+type fakeGetIPSResults struct {
+	r0 *GetIPS
+	r1 error
+}
+
+// GetIPS records the call and returns the scripted results
+func (f *FakeIPService) GetIPS() (*GetIPS, error) {
+	return f.GetIPSContext(context.Background())
+}
+
+// GetIPSContext records the call and returns the scripted results
+func (f *FakeIPService) GetIPSContext(ctx context.Context) (*GetIPS, error) {
+	i := f.record("GetIPS")
+	if f.GetIPSFunc != nil {
+		return f.GetIPSFunc(ctx)
+	}
+	f.mu.Lock()
+	r := f.getIPS.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetIPSReturns sets the results of the calls of GetIPS
+func (f *FakeIPService) GetIPSReturns(r0 *GetIPS, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getIPS.results = fakeGetIPSResults{r0, r1}
+}
+
+// GetIPSReturnsOnCall sets the results of the ith call of GetIPS, from 0
+func (f *FakeIPService) GetIPSReturnsOnCall(i int, r0 *GetIPS, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getIPS.returnsOnCall(i, fakeGetIPSResults{r0, r1})
+}
+
+type fakeGetIPResults struct {
+	r0 *GetIP
+	r1 error
+}
+
+// GetIP records the call and returns the scripted results
+func (f *FakeIPService) GetIP(ipID string) (*GetIP, error) {
+	return f.GetIPContext(context.Background(), ipID)
+}
+
+// GetIPContext records the call and returns the scripted results
+func (f *FakeIPService) GetIPContext(ctx context.Context, ipID string) (*GetIP, error) {
+	i := f.record("GetIP", ipID)
+	if f.GetIPFunc != nil {
+		return f.GetIPFunc(ctx, ipID)
+	}
+	f.mu.Lock()
+	r := f.getIP.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetIPReturns sets the results of the calls of GetIP
+func (f *FakeIPService) GetIPReturns(r0 *GetIP, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getIP.results = fakeGetIPResults{r0, r1}
+}
+
+// GetIPReturnsOnCall sets the results of the ith call of GetIP, from 0
+func (f *FakeIPService) GetIPReturnsOnCall(i int, r0 *GetIP, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getIP.returnsOnCall(i, fakeGetIPResults{r0, r1})
+}
+
+// GetIPArgsForCall returns the arguments of the ith call of GetIP, from 0
+func (f *FakeIPService) GetIPArgsForCall(i int) string {
+	args := f.args("GetIP", i)
+	return args[0].(string)
+}
+
+type fakeNewIPResults struct {
+	r0 *GetIP
+	r1 error
+}
+
+// NewIP records the call and returns the scripted results
+func (f *FakeIPService) NewIP() (*GetIP, error) {
+	return f.NewIPContext(context.Background())
+}
+
+// NewIPContext records the call and returns the scripted results
+func (f *FakeIPService) NewIPContext(ctx context.Context) (*GetIP, error) {
+	i := f.record("NewIP")
+	if f.NewIPFunc != nil {
+		return f.NewIPFunc(ctx)
+	}
+	f.mu.Lock()
+	r := f.newIP.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// NewIPReturns sets the results of the calls of NewIP
+func (f *FakeIPService) NewIPReturns(r0 *GetIP, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.newIP.results = fakeNewIPResults{r0, r1}
+}
+
+// NewIPReturnsOnCall sets the results of the ith call of NewIP, from 0
+func (f *FakeIPService) NewIPReturnsOnCall(i int, r0 *GetIP, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.newIP.returnsOnCall(i, fakeNewIPResults{r0, r1})
+}
+
+type fakeAttachIPResults struct {
+	r0 error
+}
+
+// AttachIP records the call and returns the scripted results
+func (f *FakeIPService) AttachIP(ipID string, serverID string) error {
+	return f.AttachIPContext(context.Background(), ipID, serverID)
+}
+
+// AttachIPContext records the call and returns the scripted results
+func (f *FakeIPService) AttachIPContext(ctx context.Context, ipID string, serverID string) error {
+	i := f.record("AttachIP", ipID, serverID)
+	if f.AttachIPFunc != nil {
+		return f.AttachIPFunc(ctx, ipID, serverID)
+	}
+	f.mu.Lock()
+	r := f.attachIP.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// AttachIPReturns sets the results of the calls of AttachIP
+func (f *FakeIPService) AttachIPReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.attachIP.results = fakeAttachIPResults{r0}
+}
+
+// AttachIPReturnsOnCall sets the results of the ith call of AttachIP, from 0
+func (f *FakeIPService) AttachIPReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.attachIP.returnsOnCall(i, fakeAttachIPResults{r0})
+}
+
+// AttachIPArgsForCall returns the arguments of the ith call of AttachIP, from 0
+func (f *FakeIPService) AttachIPArgsForCall(i int) (string, string) {
+	args := f.args("AttachIP", i)
+	return args[0].(string), args[1].(string)
+}
+
+type fakeDetachIPResults struct {
+	r0 error
+}
+
+// DetachIP records the call and returns the scripted results
+func (f *FakeIPService) DetachIP(ipID string) error {
+	return f.DetachIPContext(context.Background(), ipID)
+}
+
+// DetachIPContext records the call and returns the scripted results
+func (f *FakeIPService) DetachIPContext(ctx context.Context, ipID string) error {
+	i := f.record("DetachIP", ipID)
+	if f.DetachIPFunc != nil {
+		return f.DetachIPFunc(ctx, ipID)
+	}
+	f.mu.Lock()
+	r := f.detachIP.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// DetachIPReturns sets the results of the calls of DetachIP
+func (f *FakeIPService) DetachIPReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.detachIP.results = fakeDetachIPResults{r0}
+}
+
+// DetachIPReturnsOnCall sets the results of the ith call of DetachIP, from 0
+func (f *FakeIPService) DetachIPReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.detachIP.returnsOnCall(i, fakeDetachIPResults{r0})
+}
+
+// DetachIPArgsForCall returns the arguments of the ith call of DetachIP, from 0
+func (f *FakeIPService) DetachIPArgsForCall(i int) string {
+	args := f.args("DetachIP", i)
+	return args[0].(string)
+}
+
+type fakeDeleteIPResults struct {
+	r0 error
+}
+
+// DeleteIP records the call and returns the scripted results
+func (f *FakeIPService) DeleteIP(ipID string) error {
+	return f.DeleteIPContext(context.Background(), ipID)
+}
+
+// DeleteIPContext records the call and returns the scripted results
+func (f *FakeIPService) DeleteIPContext(ctx context.Context, ipID string) error {
+	i := f.record("DeleteIP", ipID)
+	if f.DeleteIPFunc != nil {
+		return f.DeleteIPFunc(ctx, ipID)
+	}
+	f.mu.Lock()
+	r := f.deleteIP.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// DeleteIPReturns sets the results of the calls of DeleteIP
+func (f *FakeIPService) DeleteIPReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteIP.results = fakeDeleteIPResults{r0}
+}
+
+// DeleteIPReturnsOnCall sets the results of the ith call of DeleteIP, from 0
+func (f *FakeIPService) DeleteIPReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteIP.returnsOnCall(i, fakeDeleteIPResults{r0})
+}
+
+// DeleteIPArgsForCall returns the arguments of the ith call of DeleteIP, from 0
+func (f *FakeIPService) DeleteIPArgsForCall(i int) string {
+	args := f.args("DeleteIP", i)
+	return args[0].(string)
+}
+
+type fakeListIPsIterResults struct {
+	r0 *Iterator[IPV4]
+}
+
+// ListIPsIter records the call and returns the scripted results
+func (f *FakeIPService) ListIPsIter(ctx context.Context) *Iterator[IPV4] {
+	i := f.record("ListIPsIter")
+	if f.ListIPsIterFunc != nil {
+		return f.ListIPsIterFunc(ctx)
+	}
+	f.mu.Lock()
+	r := f.listIPsIter.next(i)
+	f.mu.Unlock()
+	if r.r0 == nil {
+		r.r0 = SliceIterator[IPV4](nil, nil)
+	}
+	return r.r0
+}
+
+// ListIPsIterReturns sets the results of the calls of ListIPsIter
+func (f *FakeIPService) ListIPsIterReturns(r0 *Iterator[IPV4]) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listIPsIter.results = fakeListIPsIterResults{r0}
+}
+
+// ListIPsIterReturnsOnCall sets the results of the ith call of ListIPsIter, from 0
+func (f *FakeIPService) ListIPsIterReturnsOnCall(i int, r0 *Iterator[IPV4]) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listIPsIter.returnsOnCall(i, fakeListIPsIterResults{r0})
+}
+
+// FakeSecurityGroupService is a SecurityGroupService recording its calls and returning scripted results,
+// the calls which aren't scripted return the zero values
+type FakeSecurityGroupService struct {
+	fakeRecorder
+
+	// GetSecurityGroupsFunc, if set, answers the calls of GetSecurityGroups
+	GetSecurityGroupsFunc func(context.Context) (*GetSecurityGroups, error)
+
+	// GetASecurityGroupFunc, if set, answers the calls of GetASecurityGroup
+	GetASecurityGroupFunc func(context.Context, string) (*GetSecurityGroup, error)
+
+	// PostSecurityGroupFunc, if set, answers the calls of PostSecurityGroup
+	PostSecurityGroupFunc func(context.Context, NewSecurityGroup) error
+
+	// PutSecurityGroupFunc, if set, answers the calls of PutSecurityGroup
+	PutSecurityGroupFunc func(context.Context, UpdateSecurityGroup, string) error
+
+	// DeleteSecurityGroupFunc, if set, answers the calls of DeleteSecurityGroup
+	DeleteSecurityGroupFunc func(context.Context, string) error
+
+	// ListSecurityGroupsIterFunc, if set, answers the calls of ListSecurityGroupsIter
+	ListSecurityGroupsIterFunc func(context.Context) *Iterator[SecurityGroups]
+
+	// GetGroupRulesFunc, if set, answers the calls of GetGroupRules
+	GetGroupRulesFunc func(context.Context, string) (*GetGroupRules, error)
+
+	// GetAGroupRuleFunc, if set, answers the calls of GetAGroupRule
+	GetAGroupRuleFunc func(context.Context, string, string) (*GetGroupRule, error)
+
+	// PostGroupRuleFunc, if set, answers the calls of PostGroupRule
+	PostGroupRuleFunc func(context.Context, string, NewGroupRule) (*GroupRule, error)
+
+	// PutGroupRuleFunc, if set, answers the calls of PutGroupRule
+	PutGroupRuleFunc func(context.Context, NewGroupRule, string, string) error
+
+	// DeleteGroupRuleFunc, if set, answers the calls of DeleteGroupRule
+	DeleteGroupRuleFunc func(context.Context, string, string) error
+
+	getSecurityGroups      fakeScript[fakeGetSecurityGroupsResults]
+	getASecurityGroup      fakeScript[fakeGetASecurityGroupResults]
+	postSecurityGroup      fakeScript[fakePostSecurityGroupResults]
+	putSecurityGroup       fakeScript[fakePutSecurityGroupResults]
+	deleteSecurityGroup    fakeScript[fakeDeleteSecurityGroupResults]
+	listSecurityGroupsIter fakeScript[fakeListSecurityGroupsIterResults]
+	getGroupRules          fakeScript[fakeGetGroupRulesResults]
+	getAGroupRule          fakeScript[fakeGetAGroupRuleResults]
+	postGroupRule          fakeScript[fakePostGroupRuleResults]
+	putGroupRule           fakeScript[fakePutGroupRuleResults]
+	deleteGroupRule        fakeScript[fakeDeleteGroupRuleResults]
+}
+
+var _ SecurityGroupService = (*FakeSecurityGroupService)(nil)
+
+type fakeGetSecurityGroupsResults struct {
+	r0 *GetSecurityGroups
+	r1 error
+}
+
+// GetSecurityGroups records the call and returns the scripted results
+func (f *FakeSecurityGroupService) GetSecurityGroups() (*GetSecurityGroups, error) {
+	return f.GetSecurityGroupsContext(context.Background())
+}
+
+// GetSecurityGroupsContext records the call and returns the scripted results
+func (f *FakeSecurityGroupService) GetSecurityGroupsContext(ctx context.Context) (*GetSecurityGroups, error) {
+	i := f.record("GetSecurityGroups")
+	if f.GetSecurityGroupsFunc != nil {
+		return f.GetSecurityGroupsFunc(ctx)
+	}
+	f.mu.Lock()
+	r := f.getSecurityGroups.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetSecurityGroupsReturns sets the results of the calls of GetSecurityGroups
+func (f *FakeSecurityGroupService) GetSecurityGroupsReturns(r0 *GetSecurityGroups, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getSecurityGroups.results = fakeGetSecurityGroupsResults{r0, r1}
+}
+
+// GetSecurityGroupsReturnsOnCall sets the results of the ith call of GetSecurityGroups, from 0
+func (f *FakeSecurityGroupService) GetSecurityGroupsReturnsOnCall(i int, r0 *GetSecurityGroups, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getSecurityGroups.returnsOnCall(i, fakeGetSecurityGroupsResults{r0, r1})
+}
+
+type fakeGetASecurityGroupResults struct {
+	r0 *GetSecurityGroup
+	r1 error
+}
+
+// GetASecurityGroup records the call and returns the scripted results
+func (f *FakeSecurityGroupService) GetASecurityGroup(groupsID string) (*GetSecurityGroup, error) {
+	return f.GetASecurityGroupContext(context.Background(), groupsID)
+}
+
+// GetASecurityGroupContext records the call and returns the scripted results
+func (f *FakeSecurityGroupService) GetASecurityGroupContext(ctx context.Context, groupsID string) (*GetSecurityGroup, error) {
+	i := f.record("GetASecurityGroup", groupsID)
+	if f.GetASecurityGroupFunc != nil {
+		return f.GetASecurityGroupFunc(ctx, groupsID)
+	}
+	f.mu.Lock()
+	r := f.getASecurityGroup.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetASecurityGroupReturns sets the results of the calls of GetASecurityGroup
+func (f *FakeSecurityGroupService) GetASecurityGroupReturns(r0 *GetSecurityGroup, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getASecurityGroup.results = fakeGetASecurityGroupResults{r0, r1}
+}
+
+// GetASecurityGroupReturnsOnCall sets the results of the ith call of GetASecurityGroup, from 0
+func (f *FakeSecurityGroupService) GetASecurityGroupReturnsOnCall(i int, r0 *GetSecurityGroup, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getASecurityGroup.returnsOnCall(i, fakeGetASecurityGroupResults{r0, r1})
+}
+
+// GetASecurityGroupArgsForCall returns the arguments of the ith call of GetASecurityGroup, from 0
+func (f *FakeSecurityGroupService) GetASecurityGroupArgsForCall(i int) string {
+	args := f.args("GetASecurityGroup", i)
+	return args[0].(string)
+}
+
+type fakePostSecurityGroupResults struct {
+	r0 error
+}
+
+// PostSecurityGroup records the call and returns the scripted results
+func (f *FakeSecurityGroupService) PostSecurityGroup(group NewSecurityGroup) error {
+	return f.PostSecurityGroupContext(context.Background(), group)
+}
+
+// PostSecurityGroupContext records the call and returns the scripted results
+func (f *FakeSecurityGroupService) PostSecurityGroupContext(ctx context.Context, group NewSecurityGroup) error {
+	i := f.record("PostSecurityGroup", group)
+	if f.PostSecurityGroupFunc != nil {
+		return f.PostSecurityGroupFunc(ctx, group)
+	}
+	f.mu.Lock()
+	r := f.postSecurityGroup.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// PostSecurityGroupReturns sets the results of the calls of PostSecurityGroup
+func (f *FakeSecurityGroupService) PostSecurityGroupReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.postSecurityGroup.results = fakePostSecurityGroupResults{r0}
+}
+
+// PostSecurityGroupReturnsOnCall sets the results of the ith call of PostSecurityGroup, from 0
+func (f *FakeSecurityGroupService) PostSecurityGroupReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.postSecurityGroup.returnsOnCall(i, fakePostSecurityGroupResults{r0})
+}
+
+// PostSecurityGroupArgsForCall returns the arguments of the ith call of PostSecurityGroup, from 0
+func (f *FakeSecurityGroupService) PostSecurityGroupArgsForCall(i int) NewSecurityGroup {
+	args := f.args("PostSecurityGroup", i)
+	return args[0].(NewSecurityGroup)
+}
+
+type fakePutSecurityGroupResults struct {
+	r0 error
+}
+
+// PutSecurityGroup records the call and returns the scripted results
+func (f *FakeSecurityGroupService) PutSecurityGroup(group UpdateSecurityGroup, securityGroupID string) error {
+	return f.PutSecurityGroupContext(context.Background(), group, securityGroupID)
+}
+
+// PutSecurityGroupContext records the call and returns the scripted results
+func (f *FakeSecurityGroupService) PutSecurityGroupContext(ctx context.Context, group UpdateSecurityGroup, securityGroupID string) error {
+	i := f.record("PutSecurityGroup", group, securityGroupID)
+	if f.PutSecurityGroupFunc != nil {
+		return f.PutSecurityGroupFunc(ctx, group, securityGroupID)
+	}
+	f.mu.Lock()
+	r := f.putSecurityGroup.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// PutSecurityGroupReturns sets the results of the calls of PutSecurityGroup
+func (f *FakeSecurityGroupService) PutSecurityGroupReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.putSecurityGroup.results = fakePutSecurityGroupResults{r0}
+}
+
+// PutSecurityGroupReturnsOnCall sets the results of the ith call of PutSecurityGroup, from 0
+func (f *FakeSecurityGroupService) PutSecurityGroupReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.putSecurityGroup.returnsOnCall(i, fakePutSecurityGroupResults{r0})
+}
+
+// PutSecurityGroupArgsForCall returns the arguments of the ith call of PutSecurityGroup, from 0
+func (f *FakeSecurityGroupService) PutSecurityGroupArgsForCall(i int) (UpdateSecurityGroup, string) {
+	args := f.args("PutSecurityGroup", i)
+	return args[0].(UpdateSecurityGroup), args[1].(string)
+}
+
+type fakeDeleteSecurityGroupResults struct {
+	r0 error
+}
+
+// DeleteSecurityGroup records the call and returns the scripted results
+func (f *FakeSecurityGroupService) DeleteSecurityGroup(securityGroupID string) error {
+	return f.DeleteSecurityGroupContext(context.Background(), securityGroupID)
+}
+
+// DeleteSecurityGroupContext records the call and returns the scripted results
+func (f *FakeSecurityGroupService) DeleteSecurityGroupContext(ctx context.Context, securityGroupID string) error {
+	i := f.record("DeleteSecurityGroup", securityGroupID)
+	if f.DeleteSecurityGroupFunc != nil {
+		return f.DeleteSecurityGroupFunc(ctx, securityGroupID)
+	}
+	f.mu.Lock()
+	r := f.deleteSecurityGroup.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// DeleteSecurityGroupReturns sets the results of the calls of DeleteSecurityGroup
+func (f *FakeSecurityGroupService) DeleteSecurityGroupReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteSecurityGroup.results = fakeDeleteSecurityGroupResults{r0}
+}
+
+// DeleteSecurityGroupReturnsOnCall sets the results of the ith call of DeleteSecurityGroup, from 0
+func (f *FakeSecurityGroupService) DeleteSecurityGroupReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteSecurityGroup.returnsOnCall(i, fakeDeleteSecurityGroupResults{r0})
+}
+
+// DeleteSecurityGroupArgsForCall returns the arguments of the ith call of DeleteSecurityGroup, from 0
+func (f *FakeSecurityGroupService) DeleteSecurityGroupArgsForCall(i int) string {
+	args := f.args("DeleteSecurityGroup", i)
+	return args[0].(string)
+}
+
+type fakeListSecurityGroupsIterResults struct {
+	r0 *Iterator[SecurityGroups]
+}
+
+// ListSecurityGroupsIter records the call and returns the scripted results
+func (f *FakeSecurityGroupService) ListSecurityGroupsIter(ctx context.Context) *Iterator[SecurityGroups] {
+	i := f.record("ListSecurityGroupsIter")
+	if f.ListSecurityGroupsIterFunc != nil {
+		return f.ListSecurityGroupsIterFunc(ctx)
+	}
+	f.mu.Lock()
+	r := f.listSecurityGroupsIter.next(i)
+	f.mu.Unlock()
+	if r.r0 == nil {
+		r.r0 = SliceIterator[SecurityGroups](nil, nil)
+	}
+	return r.r0
+}
+
+// ListSecurityGroupsIterReturns sets the results of the calls of ListSecurityGroupsIter
+func (f *FakeSecurityGroupService) ListSecurityGroupsIterReturns(r0 *Iterator[SecurityGroups]) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listSecurityGroupsIter.results = fakeListSecurityGroupsIterResults{r0}
+}
+
+// ListSecurityGroupsIterReturnsOnCall sets the results of the ith call of ListSecurityGroupsIter, from 0
+func (f *FakeSecurityGroupService) ListSecurityGroupsIterReturnsOnCall(i int, r0 *Iterator[SecurityGroups]) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listSecurityGroupsIter.returnsOnCall(i, fakeListSecurityGroupsIterResults{r0})
+}
+
+type fakeGetGroupRulesResults struct {
+	r0 *GetGroupRules
+	r1 error
+}
+
+// GetGroupRules records the call and returns the scripted results
+func (f *FakeSecurityGroupService) GetGroupRules(groupID string) (*GetGroupRules, error) {
+	return f.GetGroupRulesContext(context.Background(), groupID)
+}
+
+// GetGroupRulesContext records the call and returns the scripted results
+func (f *FakeSecurityGroupService) GetGroupRulesContext(ctx context.Context, groupID string) (*GetGroupRules, error) {
+	i := f.record("GetGroupRules", groupID)
+	if f.GetGroupRulesFunc != nil {
+		return f.GetGroupRulesFunc(ctx, groupID)
+	}
+	f.mu.Lock()
+	r := f.getGroupRules.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetGroupRulesReturns sets the results of the calls of GetGroupRules
+func (f *FakeSecurityGroupService) GetGroupRulesReturns(r0 *GetGroupRules, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getGroupRules.results = fakeGetGroupRulesResults{r0, r1}
+}
+
+// GetGroupRulesReturnsOnCall sets the results of the ith call of GetGroupRules, from 0
+func (f *FakeSecurityGroupService) GetGroupRulesReturnsOnCall(i int, r0 *GetGroupRules, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getGroupRules.returnsOnCall(i, fakeGetGroupRulesResults{r0, r1})
+}
+
+// GetGroupRulesArgsForCall returns the arguments of the ith call of GetGroupRules, from 0
+func (f *FakeSecurityGroupService) GetGroupRulesArgsForCall(i int) string {
+	args := f.args("GetGroupRules", i)
+	return args[0].(string)
+}
+
+type fakeGetAGroupRuleResults struct {
+	r0 *GetGroupRule
+	r1 error
+}
+
+// GetAGroupRule records the call and returns the scripted results
+func (f *FakeSecurityGroupService) GetAGroupRule(groupID string, rulesID string) (*GetGroupRule, error) {
+	return f.GetAGroupRuleContext(context.Background(), groupID, rulesID)
+}
+
+// GetAGroupRuleContext records the call and returns the scripted results
+func (f *FakeSecurityGroupService) GetAGroupRuleContext(ctx context.Context, groupID string, rulesID string) (*GetGroupRule, error) {
+	i := f.record("GetAGroupRule", groupID, rulesID)
+	if f.GetAGroupRuleFunc != nil {
+		return f.GetAGroupRuleFunc(ctx, groupID, rulesID)
+	}
+	f.mu.Lock()
+	r := f.getAGroupRule.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetAGroupRuleReturns sets the results of the calls of GetAGroupRule
+func (f *FakeSecurityGroupService) GetAGroupRuleReturns(r0 *GetGroupRule, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getAGroupRule.results = fakeGetAGroupRuleResults{r0, r1}
+}
+
+// GetAGroupRuleReturnsOnCall sets the results of the ith call of GetAGroupRule, from 0
+func (f *FakeSecurityGroupService) GetAGroupRuleReturnsOnCall(i int, r0 *GetGroupRule, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getAGroupRule.returnsOnCall(i, fakeGetAGroupRuleResults{r0, r1})
+}
+
+// GetAGroupRuleArgsForCall returns the arguments of the ith call of GetAGroupRule, from 0
+func (f *FakeSecurityGroupService) GetAGroupRuleArgsForCall(i int) (string, string) {
+	args := f.args("GetAGroupRule", i)
+	return args[0].(string), args[1].(string)
+}
+
+type fakePostGroupRuleResults struct {
+	r0 *GroupRule
+	r1 error
+}
+
+// PostGroupRule records the call and returns the scripted results
+func (f *FakeSecurityGroupService) PostGroupRule(groupID string, rules NewGroupRule) (*GroupRule, error) {
+	return f.PostGroupRuleContext(context.Background(), groupID, rules)
+}
+
+// PostGroupRuleContext records the call and returns the scripted results
+func (f *FakeSecurityGroupService) PostGroupRuleContext(ctx context.Context, groupID string, rules NewGroupRule) (*GroupRule, error) {
+	i := f.record("PostGroupRule", groupID, rules)
+	if f.PostGroupRuleFunc != nil {
+		return f.PostGroupRuleFunc(ctx, groupID, rules)
+	}
+	f.mu.Lock()
+	r := f.postGroupRule.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// PostGroupRuleReturns sets the results of the calls of PostGroupRule
+func (f *FakeSecurityGroupService) PostGroupRuleReturns(r0 *GroupRule, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.postGroupRule.results = fakePostGroupRuleResults{r0, r1}
+}
+
+// PostGroupRuleReturnsOnCall sets the results of the ith call of PostGroupRule, from 0
+func (f *FakeSecurityGroupService) PostGroupRuleReturnsOnCall(i int, r0 *GroupRule, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.postGroupRule.returnsOnCall(i, fakePostGroupRuleResults{r0, r1})
+}
+
+// PostGroupRuleArgsForCall returns the arguments of the ith call of PostGroupRule, from 0
+func (f *FakeSecurityGroupService) PostGroupRuleArgsForCall(i int) (string, NewGroupRule) {
+	args := f.args("PostGroupRule", i)
+	return args[0].(string), args[1].(NewGroupRule)
+}
+
+type fakePutGroupRuleResults struct {
+	r0 error
+}
+
+// PutGroupRule records the call and returns the scripted results
+func (f *FakeSecurityGroupService) PutGroupRule(rules NewGroupRule, groupID string, ruleID string) error {
+	return f.PutGroupRuleContext(context.Background(), rules, groupID, ruleID)
+}
+
+// PutGroupRuleContext records the call and returns the scripted results
+func (f *FakeSecurityGroupService) PutGroupRuleContext(ctx context.Context, rules NewGroupRule, groupID string, ruleID string) error {
+	i := f.record("PutGroupRule", rules, groupID, ruleID)
+	if f.PutGroupRuleFunc != nil {
+		return f.PutGroupRuleFunc(ctx, rules, groupID, ruleID)
+	}
+	f.mu.Lock()
+	r := f.putGroupRule.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// PutGroupRuleReturns sets the results of the calls of PutGroupRule
+func (f *FakeSecurityGroupService) PutGroupRuleReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.putGroupRule.results = fakePutGroupRuleResults{r0}
+}
+
+// PutGroupRuleReturnsOnCall sets the results of the ith call of PutGroupRule, from 0
+func (f *FakeSecurityGroupService) PutGroupRuleReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.putGroupRule.returnsOnCall(i, fakePutGroupRuleResults{r0})
+}
+
+// PutGroupRuleArgsForCall returns the arguments of the ith call of PutGroupRule, from 0
+func (f *FakeSecurityGroupService) PutGroupRuleArgsForCall(i int) (NewGroupRule, string, string) {
+	args := f.args("PutGroupRule", i)
+	return args[0].(NewGroupRule), args[1].(string), args[2].(string)
+}
+
+type fakeDeleteGroupRuleResults struct {
+	r0 error
+}
+
+// DeleteGroupRule records the call and returns the scripted results
+func (f *FakeSecurityGroupService) DeleteGroupRule(groupID string, ruleID string) error {
+	return f.DeleteGroupRuleContext(context.Background(), groupID, ruleID)
+}
+
+// DeleteGroupRuleContext records the call and returns the scripted results
+func (f *FakeSecurityGroupService) DeleteGroupRuleContext(ctx context.Context, groupID string, ruleID string) error {
+	i := f.record("DeleteGroupRule", groupID, ruleID)
+	if f.DeleteGroupRuleFunc != nil {
+		return f.DeleteGroupRuleFunc(ctx, groupID, ruleID)
+	}
+	f.mu.Lock()
+	r := f.deleteGroupRule.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// DeleteGroupRuleReturns sets the results of the calls of DeleteGroupRule
+func (f *FakeSecurityGroupService) DeleteGroupRuleReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteGroupRule.results = fakeDeleteGroupRuleResults{r0}
+}
+
+// DeleteGroupRuleReturnsOnCall sets the results of the ith call of DeleteGroupRule, from 0
+func (f *FakeSecurityGroupService) DeleteGroupRuleReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteGroupRule.returnsOnCall(i, fakeDeleteGroupRuleResults{r0})
+}
+
+// DeleteGroupRuleArgsForCall returns the arguments of the ith call of DeleteGroupRule, from 0
+func (f *FakeSecurityGroupService) DeleteGroupRuleArgsForCall(i int) (string, string) {
+	args := f.args("DeleteGroupRule", i)
+	return args[0].(string), args[1].(string)
+}
+
+// FakeUserdataService is a UserdataService recording its calls and returning scripted results,
+// the calls which aren't scripted return the zero values
+type FakeUserdataService struct {
+	fakeRecorder
+
+	// GetUserdatasFunc, if set, answers the calls of GetUserdatas
+	GetUserdatasFunc func(context.Context, string, bool) (*Userdatas, error)
+
+	// GetUserdataFunc, if set, answers the calls of GetUserdata
+	GetUserdataFunc func(context.Context, string, string, bool) (*Userdata, error)
+
+	// PatchUserdataFunc, if set, answers the calls of PatchUserdata
+	PatchUserdataFunc func(context.Context, string, string, []byte, bool) error
+
+	// DeleteUserdataFunc, if set, answers the calls of DeleteUserdata
+	DeleteUserdataFunc func(context.Context, string, string, bool) error
+
+	getUserdatas   fakeScript[fakeGetUserdatasResults]
+	getUserdata    fakeScript[fakeGetUserdataResults]
+	patchUserdata  fakeScript[fakePatchUserdataResults]
+	deleteUserdata fakeScript[fakeDeleteUserdataResults]
+}
+
+var _ UserdataService = (*FakeUserdataService)(nil)
+
+type fakeGetUserdatasResults struct {
+	r0 *Userdatas
+	r1 error
+}
+
+// GetUserdatas records the call and returns the scripted results
+func (f *FakeUserdataService) GetUserdatas(serverID string, metadata bool) (*Userdatas, error) {
+	return f.GetUserdatasContext(context.Background(), serverID, metadata)
+}
+
+// GetUserdatasContext records the call and returns the scripted results
+func (f *FakeUserdataService) GetUserdatasContext(ctx context.Context, serverID string, metadata bool) (*Userdatas, error) {
+	i := f.record("GetUserdatas", serverID, metadata)
+	if f.GetUserdatasFunc != nil {
+		return f.GetUserdatasFunc(ctx, serverID, metadata)
+	}
+	f.mu.Lock()
+	r := f.getUserdatas.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetUserdatasReturns sets the results of the calls of GetUserdatas
+func (f *FakeUserdataService) GetUserdatasReturns(r0 *Userdatas, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getUserdatas.results = fakeGetUserdatasResults{r0, r1}
+}
+
+// GetUserdatasReturnsOnCall sets the results of the ith call of GetUserdatas, from 0
+func (f *FakeUserdataService) GetUserdatasReturnsOnCall(i int, r0 *Userdatas, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getUserdatas.returnsOnCall(i, fakeGetUserdatasResults{r0, r1})
+}
+
+// GetUserdatasArgsForCall returns the arguments of the ith call of GetUserdatas, from 0
+func (f *FakeUserdataService) GetUserdatasArgsForCall(i int) (string, bool) {
+	args := f.args("GetUserdatas", i)
+	return args[0].(string), args[1].(bool)
+}
+
+type fakeGetUserdataResults struct {
+	r0 *Userdata
+	r1 error
+}
+
+// GetUserdata records the call and returns the scripted results
+func (f *FakeUserdataService) GetUserdata(serverID string, key string, metadata bool) (*Userdata, error) {
+	return f.GetUserdataContext(context.Background(), serverID, key, metadata)
+}
+
+// GetUserdataContext records the call and returns the scripted results
+func (f *FakeUserdataService) GetUserdataContext(ctx context.Context, serverID string, key string, metadata bool) (*Userdata, error) {
+	i := f.record("GetUserdata", serverID, key, metadata)
+	if f.GetUserdataFunc != nil {
+		return f.GetUserdataFunc(ctx, serverID, key, metadata)
+	}
+	f.mu.Lock()
+	r := f.getUserdata.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetUserdataReturns sets the results of the calls of GetUserdata
+func (f *FakeUserdataService) GetUserdataReturns(r0 *Userdata, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getUserdata.results = fakeGetUserdataResults{r0, r1}
+}
+
+// GetUserdataReturnsOnCall sets the results of the ith call of GetUserdata, from 0
+func (f *FakeUserdataService) GetUserdataReturnsOnCall(i int, r0 *Userdata, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getUserdata.returnsOnCall(i, fakeGetUserdataResults{r0, r1})
+}
+
+// GetUserdataArgsForCall returns the arguments of the ith call of GetUserdata, from 0
+func (f *FakeUserdataService) GetUserdataArgsForCall(i int) (string, string, bool) {
+	args := f.args("GetUserdata", i)
+	return args[0].(string), args[1].(string), args[2].(bool)
+}
+
+type fakePatchUserdataResults struct {
+	r0 error
+}
+
+// PatchUserdata records the call and returns the scripted results
+func (f *FakeUserdataService) PatchUserdata(serverID string, key string, value []byte, metadata bool) error {
+	return f.PatchUserdataContext(context.Background(), serverID, key, value, metadata)
+}
+
+// PatchUserdataContext records the call and returns the scripted results
+func (f *FakeUserdataService) PatchUserdataContext(ctx context.Context, serverID string, key string, value []byte, metadata bool) error {
+	i := f.record("PatchUserdata", serverID, key, value, metadata)
+	if f.PatchUserdataFunc != nil {
+		return f.PatchUserdataFunc(ctx, serverID, key, value, metadata)
+	}
+	f.mu.Lock()
+	r := f.patchUserdata.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// PatchUserdataReturns sets the results of the calls of PatchUserdata
+func (f *FakeUserdataService) PatchUserdataReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.patchUserdata.results = fakePatchUserdataResults{r0}
+}
+
+// PatchUserdataReturnsOnCall sets the results of the ith call of PatchUserdata, from 0
+func (f *FakeUserdataService) PatchUserdataReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.patchUserdata.returnsOnCall(i, fakePatchUserdataResults{r0})
+}
+
+// PatchUserdataArgsForCall returns the arguments of the ith call of PatchUserdata, from 0
+func (f *FakeUserdataService) PatchUserdataArgsForCall(i int) (string, string, []byte, bool) {
+	args := f.args("PatchUserdata", i)
+	return args[0].(string), args[1].(string), args[2].([]byte), args[3].(bool)
+}
+
+type fakeDeleteUserdataResults struct {
+	r0 error
+}
+
+// DeleteUserdata records the call and returns the scripted results
+func (f *FakeUserdataService) DeleteUserdata(serverID string, key string, metadata bool) error {
+	return f.DeleteUserdataContext(context.Background(), serverID, key, metadata)
+}
+
+// DeleteUserdataContext records the call and returns the scripted results
+func (f *FakeUserdataService) DeleteUserdataContext(ctx context.Context, serverID string, key string, metadata bool) error {
+	i := f.record("DeleteUserdata", serverID, key, metadata)
+	if f.DeleteUserdataFunc != nil {
+		return f.DeleteUserdataFunc(ctx, serverID, key, metadata)
+	}
+	f.mu.Lock()
+	r := f.deleteUserdata.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// DeleteUserdataReturns sets the results of the calls of DeleteUserdata
+func (f *FakeUserdataService) DeleteUserdataReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteUserdata.results = fakeDeleteUserdataResults{r0}
+}
+
+// DeleteUserdataReturnsOnCall sets the results of the ith call of DeleteUserdata, from 0
+func (f *FakeUserdataService) DeleteUserdataReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteUserdata.returnsOnCall(i, fakeDeleteUserdataResults{r0})
+}
+
+// DeleteUserdataArgsForCall returns the arguments of the ith call of DeleteUserdata, from 0
+func (f *FakeUserdataService) DeleteUserdataArgsForCall(i int) (string, string, bool) {
+	args := f.args("DeleteUserdata", i)
+	return args[0].(string), args[1].(string), args[2].(bool)
+}
+
+// FakeAccountService is a AccountService recording its calls and returning scripted results,
+// the calls which aren't scripted return the zero values
+type FakeAccountService struct {
+	fakeRecorder
+
+	// GetUserIDFunc, if set, answers the calls of GetUserID
+	GetUserIDFunc func(context.Context) (string, error)
+
+	// GetUserFunc, if set, answers the calls of GetUser
+	GetUserFunc func(context.Context) (*UserDefinition, error)
+
+	// PatchUserSSHKeyFunc, if set, answers the calls of PatchUserSSHKey
+	PatchUserSSHKeyFunc func(context.Context, string, UserPatchSSHKeyDefinition) error
+
+	// GetOrganizationFunc, if set, answers the calls of GetOrganization
+	GetOrganizationFunc func(context.Context) (*OrganizationsDefinition, error)
+
+	// GetPermissionsFunc, if set, answers the calls of GetPermissions
+	GetPermissionsFunc func(context.Context) (*PermissionDefinition, error)
+
+	// GetQuotasFunc, if set, answers the calls of GetQuotas
+	GetQuotasFunc func(context.Context) (*GetQuotas, error)
+
+	// GetDashboardFunc, if set, answers the calls of GetDashboard
+	GetDashboardFunc func(context.Context) (*Dashboard, error)
+
+	getUserID       fakeScript[fakeGetUserIDResults]
+	getUser         fakeScript[fakeGetUserResults]
+	patchUserSSHKey fakeScript[fakePatchUserSSHKeyResults]
+	getOrganization fakeScript[fakeGetOrganizationResults]
+	getPermissions  fakeScript[fakeGetPermissionsResults]
+	getQuotas       fakeScript[fakeGetQuotasResults]
+	getDashboard    fakeScript[fakeGetDashboardResults]
+}
+
+var _ AccountService = (*FakeAccountService)(nil)
+
+type fakeGetUserIDResults struct {
+	r0 string
+	r1 error
+}
+
+// GetUserID records the call and returns the scripted results
+func (f *FakeAccountService) GetUserID() (string, error) {
+	return f.GetUserIDContext(context.Background())
+}
+
+// GetUserIDContext records the call and returns the scripted results
+func (f *FakeAccountService) GetUserIDContext(ctx context.Context) (string, error) {
+	i := f.record("GetUserID")
+	if f.GetUserIDFunc != nil {
+		return f.GetUserIDFunc(ctx)
+	}
+	f.mu.Lock()
+	r := f.getUserID.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetUserIDReturns sets the results of the calls of GetUserID
+func (f *FakeAccountService) GetUserIDReturns(r0 string, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getUserID.results = fakeGetUserIDResults{r0, r1}
+}
+
+// GetUserIDReturnsOnCall sets the results of the ith call of GetUserID, from 0
+func (f *FakeAccountService) GetUserIDReturnsOnCall(i int, r0 string, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getUserID.returnsOnCall(i, fakeGetUserIDResults{r0, r1})
+}
+
+type fakeGetUserResults struct {
+	r0 *UserDefinition
+	r1 error
+}
+
+// GetUser records the call and returns the scripted results
+func (f *FakeAccountService) GetUser() (*UserDefinition, error) {
+	return f.GetUserContext(context.Background())
+}
+
+// GetUserContext records the call and returns the scripted results
+func (f *FakeAccountService) GetUserContext(ctx context.Context) (*UserDefinition, error) {
+	i := f.record("GetUser")
+	if f.GetUserFunc != nil {
+		return f.GetUserFunc(ctx)
+	}
+	f.mu.Lock()
+	r := f.getUser.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetUserReturns sets the results of the calls of GetUser
+func (f *FakeAccountService) GetUserReturns(r0 *UserDefinition, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getUser.results = fakeGetUserResults{r0, r1}
+}
+
+// GetUserReturnsOnCall sets the results of the ith call of GetUser, from 0
+func (f *FakeAccountService) GetUserReturnsOnCall(i int, r0 *UserDefinition, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getUser.returnsOnCall(i, fakeGetUserResults{r0, r1})
+}
+
+type fakePatchUserSSHKeyResults struct {
+	r0 error
+}
+
+// PatchUserSSHKey records the call and returns the scripted results
+func (f *FakeAccountService) PatchUserSSHKey(userID string, definition UserPatchSSHKeyDefinition) error {
+	return f.PatchUserSSHKeyContext(context.Background(), userID, definition)
+}
+
+// PatchUserSSHKeyContext records the call and returns the scripted results
+func (f *FakeAccountService) PatchUserSSHKeyContext(ctx context.Context, userID string, definition UserPatchSSHKeyDefinition) error {
+	i := f.record("PatchUserSSHKey", userID, definition)
+	if f.PatchUserSSHKeyFunc != nil {
+		return f.PatchUserSSHKeyFunc(ctx, userID, definition)
+	}
+	f.mu.Lock()
+	r := f.patchUserSSHKey.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// PatchUserSSHKeyReturns sets the results of the calls of PatchUserSSHKey
+func (f *FakeAccountService) PatchUserSSHKeyReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.patchUserSSHKey.results = fakePatchUserSSHKeyResults{r0}
+}
+
+// PatchUserSSHKeyReturnsOnCall sets the results of the ith call of PatchUserSSHKey, from 0
+func (f *FakeAccountService) PatchUserSSHKeyReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.patchUserSSHKey.returnsOnCall(i, fakePatchUserSSHKeyResults{r0})
+}
+
+// PatchUserSSHKeyArgsForCall returns the arguments of the ith call of PatchUserSSHKey, from 0
+func (f *FakeAccountService) PatchUserSSHKeyArgsForCall(i int) (string, UserPatchSSHKeyDefinition) {
+	args := f.args("PatchUserSSHKey", i)
+	return args[0].(string), args[1].(UserPatchSSHKeyDefinition)
+}
+
+type fakeGetOrganizationResults struct {
+	r0 *OrganizationsDefinition
+	r1 error
+}
+
+// GetOrganization records the call and returns the scripted results
+func (f *FakeAccountService) GetOrganization() (*OrganizationsDefinition, error) {
+	return f.GetOrganizationContext(context.Background())
+}
+
+// GetOrganizationContext records the call and returns the scripted results
+func (f *FakeAccountService) GetOrganizationContext(ctx context.Context) (*OrganizationsDefinition, error) {
+	i := f.record("GetOrganization")
+	if f.GetOrganizationFunc != nil {
+		return f.GetOrganizationFunc(ctx)
+	}
+	f.mu.Lock()
+	r := f.getOrganization.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetOrganizationReturns sets the results of the calls of GetOrganization
+func (f *FakeAccountService) GetOrganizationReturns(r0 *OrganizationsDefinition, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getOrganization.results = fakeGetOrganizationResults{r0, r1}
+}
+
+// GetOrganizationReturnsOnCall sets the results of the ith call of GetOrganization, from 0
+func (f *FakeAccountService) GetOrganizationReturnsOnCall(i int, r0 *OrganizationsDefinition, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getOrganization.returnsOnCall(i, fakeGetOrganizationResults{r0, r1})
+}
+
+type fakeGetPermissionsResults struct {
+	r0 *PermissionDefinition
+	r1 error
+}
+
+// GetPermissions records the call and returns the scripted results
+func (f *FakeAccountService) GetPermissions() (*PermissionDefinition, error) {
+	return f.GetPermissionsContext(context.Background())
+}
+
+// GetPermissionsContext records the call and returns the scripted results
+func (f *FakeAccountService) GetPermissionsContext(ctx context.Context) (*PermissionDefinition, error) {
+	i := f.record("GetPermissions")
+	if f.GetPermissionsFunc != nil {
+		return f.GetPermissionsFunc(ctx)
+	}
+	f.mu.Lock()
+	r := f.getPermissions.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetPermissionsReturns sets the results of the calls of GetPermissions
+func (f *FakeAccountService) GetPermissionsReturns(r0 *PermissionDefinition, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getPermissions.results = fakeGetPermissionsResults{r0, r1}
+}
+
+// GetPermissionsReturnsOnCall sets the results of the ith call of GetPermissions, from 0
+func (f *FakeAccountService) GetPermissionsReturnsOnCall(i int, r0 *PermissionDefinition, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getPermissions.returnsOnCall(i, fakeGetPermissionsResults{r0, r1})
+}
+
+type fakeGetQuotasResults struct {
+	r0 *GetQuotas
+	r1 error
+}
+
+// GetQuotas records the call and returns the scripted results
+func (f *FakeAccountService) GetQuotas() (*GetQuotas, error) {
+	return f.GetQuotasContext(context.Background())
+}
+
+// GetQuotasContext records the call and returns the scripted results
+func (f *FakeAccountService) GetQuotasContext(ctx context.Context) (*GetQuotas, error) {
+	i := f.record("GetQuotas")
+	if f.GetQuotasFunc != nil {
+		return f.GetQuotasFunc(ctx)
+	}
+	f.mu.Lock()
+	r := f.getQuotas.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetQuotasReturns sets the results of the calls of GetQuotas
+func (f *FakeAccountService) GetQuotasReturns(r0 *GetQuotas, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getQuotas.results = fakeGetQuotasResults{r0, r1}
+}
+
+// GetQuotasReturnsOnCall sets the results of the ith call of GetQuotas, from 0
+func (f *FakeAccountService) GetQuotasReturnsOnCall(i int, r0 *GetQuotas, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getQuotas.returnsOnCall(i, fakeGetQuotasResults{r0, r1})
+}
+
+type fakeGetDashboardResults struct {
+	r0 *Dashboard
+	r1 error
+}
+
+// GetDashboard records the call and returns the scripted results
+func (f *FakeAccountService) GetDashboard() (*Dashboard, error) {
+	return f.GetDashboardContext(context.Background())
+}
+
+// GetDashboardContext records the call and returns the scripted results
+func (f *FakeAccountService) GetDashboardContext(ctx context.Context) (*Dashboard, error) {
+	i := f.record("GetDashboard")
+	if f.GetDashboardFunc != nil {
+		return f.GetDashboardFunc(ctx)
+	}
+	f.mu.Lock()
+	r := f.getDashboard.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetDashboardReturns sets the results of the calls of GetDashboard
+func (f *FakeAccountService) GetDashboardReturns(r0 *Dashboard, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getDashboard.results = fakeGetDashboardResults{r0, r1}
+}
+
+// GetDashboardReturnsOnCall sets the results of the ith call of GetDashboard, from 0
+func (f *FakeAccountService) GetDashboardReturnsOnCall(i int, r0 *Dashboard, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getDashboard.returnsOnCall(i, fakeGetDashboardResults{r0, r1})
+}
+
+// FakeMarketplaceService is a MarketplaceService recording its calls and returning scripted results,
+// the calls which aren't scripted return the zero values
+type FakeMarketplaceService struct {
+	fakeRecorder
+
+	// GetMarketPlaceImagesFunc, if set, answers the calls of GetMarketPlaceImages
+	GetMarketPlaceImagesFunc func(context.Context, string) (*MarketImages, error)
+
+	// GetMarketPlaceImageVersionsFunc, if set, answers the calls of GetMarketPlaceImageVersions
+	GetMarketPlaceImageVersionsFunc func(context.Context, string, string) (*MarketVersions, error)
+
+	// GetMarketPlaceImageCurrentVersionFunc, if set, answers the calls of GetMarketPlaceImageCurrentVersion
+	GetMarketPlaceImageCurrentVersionFunc func(context.Context, string) (*MarketVersion, error)
+
+	// GetMarketPlaceLocalImagesFunc, if set, answers the calls of GetMarketPlaceLocalImages
+	GetMarketPlaceLocalImagesFunc func(context.Context, string, string, string) (*MarketLocalImages, error)
+
+	// PostMarketPlaceImageFunc, if set, answers the calls of PostMarketPlaceImage
+	PostMarketPlaceImageFunc func(context.Context, MarketImage) error
+
+	// PostMarketPlaceImageVersionFunc, if set, answers the calls of PostMarketPlaceImageVersion
+	PostMarketPlaceImageVersionFunc func(context.Context, string, MarketVersion) error
+
+	// PostMarketPlaceLocalImageFunc, if set, answers the calls of PostMarketPlaceLocalImage
+	PostMarketPlaceLocalImageFunc func(context.Context, string, string, string, MarketLocalImage) error
+
+	// PutMarketPlaceImageFunc, if set, answers the calls of PutMarketPlaceImage
+	PutMarketPlaceImageFunc func(context.Context, string, MarketImage) error
+
+	// PutMarketPlaceImageVersionFunc, if set, answers the calls of PutMarketPlaceImageVersion
+	PutMarketPlaceImageVersionFunc func(context.Context, string, string, MarketVersion) error
+
+	// PutMarketPlaceLocalImageFunc, if set, answers the calls of PutMarketPlaceLocalImage
+	PutMarketPlaceLocalImageFunc func(context.Context, string, string, string, MarketLocalImage) error
+
+	// DeleteMarketPlaceImageFunc, if set, answers the calls of DeleteMarketPlaceImage
+	DeleteMarketPlaceImageFunc func(context.Context, string) error
+
+	// DeleteMarketPlaceImageVersionFunc, if set, answers the calls of DeleteMarketPlaceImageVersion
+	DeleteMarketPlaceImageVersionFunc func(context.Context, string, string) error
+
+	// DeleteMarketPlaceLocalImageFunc, if set, answers the calls of DeleteMarketPlaceLocalImage
+	DeleteMarketPlaceLocalImageFunc func(context.Context, string, string, string) error
+
+	getMarketPlaceImages              fakeScript[fakeGetMarketPlaceImagesResults]
+	getMarketPlaceImageVersions       fakeScript[fakeGetMarketPlaceImageVersionsResults]
+	getMarketPlaceImageCurrentVersion fakeScript[fakeGetMarketPlaceImageCurrentVersionResults]
+	getMarketPlaceLocalImages         fakeScript[fakeGetMarketPlaceLocalImagesResults]
+	postMarketPlaceImage              fakeScript[fakePostMarketPlaceImageResults]
+	postMarketPlaceImageVersion       fakeScript[fakePostMarketPlaceImageVersionResults]
+	postMarketPlaceLocalImage         fakeScript[fakePostMarketPlaceLocalImageResults]
+	putMarketPlaceImage               fakeScript[fakePutMarketPlaceImageResults]
+	putMarketPlaceImageVersion        fakeScript[fakePutMarketPlaceImageVersionResults]
+	putMarketPlaceLocalImage          fakeScript[fakePutMarketPlaceLocalImageResults]
+	deleteMarketPlaceImage            fakeScript[fakeDeleteMarketPlaceImageResults]
+	deleteMarketPlaceImageVersion     fakeScript[fakeDeleteMarketPlaceImageVersionResults]
+	deleteMarketPlaceLocalImage       fakeScript[fakeDeleteMarketPlaceLocalImageResults]
+}
+
+var _ MarketplaceService = (*FakeMarketplaceService)(nil)
+
+type fakeGetMarketPlaceImagesResults struct {
+	r0 *MarketImages
+	r1 error
+}
+
+// GetMarketPlaceImages records the call and returns the scripted results
+func (f *FakeMarketplaceService) GetMarketPlaceImages(uuidImage string) (*MarketImages, error) {
+	return f.GetMarketPlaceImagesContext(context.Background(), uuidImage)
+}
+
+// GetMarketPlaceImagesContext records the call and returns the scripted results
+func (f *FakeMarketplaceService) GetMarketPlaceImagesContext(ctx context.Context, uuidImage string) (*MarketImages, error) {
+	i := f.record("GetMarketPlaceImages", uuidImage)
+	if f.GetMarketPlaceImagesFunc != nil {
+		return f.GetMarketPlaceImagesFunc(ctx, uuidImage)
+	}
+	f.mu.Lock()
+	r := f.getMarketPlaceImages.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetMarketPlaceImagesReturns sets the results of the calls of GetMarketPlaceImages
+func (f *FakeMarketplaceService) GetMarketPlaceImagesReturns(r0 *MarketImages, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getMarketPlaceImages.results = fakeGetMarketPlaceImagesResults{r0, r1}
+}
+
+// GetMarketPlaceImagesReturnsOnCall sets the results of the ith call of GetMarketPlaceImages, from 0
+func (f *FakeMarketplaceService) GetMarketPlaceImagesReturnsOnCall(i int, r0 *MarketImages, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getMarketPlaceImages.returnsOnCall(i, fakeGetMarketPlaceImagesResults{r0, r1})
+}
+
+// GetMarketPlaceImagesArgsForCall returns the arguments of the ith call of GetMarketPlaceImages, from 0
+func (f *FakeMarketplaceService) GetMarketPlaceImagesArgsForCall(i int) string {
+	args := f.args("GetMarketPlaceImages", i)
+	return args[0].(string)
+}
+
+type fakeGetMarketPlaceImageVersionsResults struct {
+	r0 *MarketVersions
+	r1 error
+}
+
+// GetMarketPlaceImageVersions records the call and returns the scripted results
+func (f *FakeMarketplaceService) GetMarketPlaceImageVersions(uuidImage string, uuidVersion string) (*MarketVersions, error) {
+	return f.GetMarketPlaceImageVersionsContext(context.Background(), uuidImage, uuidVersion)
+}
+
+// GetMarketPlaceImageVersionsContext records the call and returns the scripted results
+func (f *FakeMarketplaceService) GetMarketPlaceImageVersionsContext(ctx context.Context, uuidImage string, uuidVersion string) (*MarketVersions, error) {
+	i := f.record("GetMarketPlaceImageVersions", uuidImage, uuidVersion)
+	if f.GetMarketPlaceImageVersionsFunc != nil {
+		return f.GetMarketPlaceImageVersionsFunc(ctx, uuidImage, uuidVersion)
+	}
+	f.mu.Lock()
+	r := f.getMarketPlaceImageVersions.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetMarketPlaceImageVersionsReturns sets the results of the calls of GetMarketPlaceImageVersions
+func (f *FakeMarketplaceService) GetMarketPlaceImageVersionsReturns(r0 *MarketVersions, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getMarketPlaceImageVersions.results = fakeGetMarketPlaceImageVersionsResults{r0, r1}
+}
+
+// GetMarketPlaceImageVersionsReturnsOnCall sets the results of the ith call of GetMarketPlaceImageVersions, from 0
+func (f *FakeMarketplaceService) GetMarketPlaceImageVersionsReturnsOnCall(i int, r0 *MarketVersions, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getMarketPlaceImageVersions.returnsOnCall(i, fakeGetMarketPlaceImageVersionsResults{r0, r1})
+}
+
+// GetMarketPlaceImageVersionsArgsForCall returns the arguments of the ith call of GetMarketPlaceImageVersions, from 0
+func (f *FakeMarketplaceService) GetMarketPlaceImageVersionsArgsForCall(i int) (string, string) {
+	args := f.args("GetMarketPlaceImageVersions", i)
+	return args[0].(string), args[1].(string)
+}
+
+type fakeGetMarketPlaceImageCurrentVersionResults struct {
+	r0 *MarketVersion
+	r1 error
+}
+
+// GetMarketPlaceImageCurrentVersion records the call and returns the scripted results
+func (f *FakeMarketplaceService) GetMarketPlaceImageCurrentVersion(uuidImage string) (*MarketVersion, error) {
+	return f.GetMarketPlaceImageCurrentVersionContext(context.Background(), uuidImage)
+}
+
+// GetMarketPlaceImageCurrentVersionContext records the call and returns the scripted results
+func (f *FakeMarketplaceService) GetMarketPlaceImageCurrentVersionContext(ctx context.Context, uuidImage string) (*MarketVersion, error) {
+	i := f.record("GetMarketPlaceImageCurrentVersion", uuidImage)
+	if f.GetMarketPlaceImageCurrentVersionFunc != nil {
+		return f.GetMarketPlaceImageCurrentVersionFunc(ctx, uuidImage)
+	}
+	f.mu.Lock()
+	r := f.getMarketPlaceImageCurrentVersion.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetMarketPlaceImageCurrentVersionReturns sets the results of the calls of GetMarketPlaceImageCurrentVersion
+func (f *FakeMarketplaceService) GetMarketPlaceImageCurrentVersionReturns(r0 *MarketVersion, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getMarketPlaceImageCurrentVersion.results = fakeGetMarketPlaceImageCurrentVersionResults{r0, r1}
+}
+
+// GetMarketPlaceImageCurrentVersionReturnsOnCall sets the results of the ith call of GetMarketPlaceImageCurrentVersion, from 0
+func (f *FakeMarketplaceService) GetMarketPlaceImageCurrentVersionReturnsOnCall(i int, r0 *MarketVersion, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getMarketPlaceImageCurrentVersion.returnsOnCall(i, fakeGetMarketPlaceImageCurrentVersionResults{r0, r1})
+}
+
+// GetMarketPlaceImageCurrentVersionArgsForCall returns the arguments of the ith call of GetMarketPlaceImageCurrentVersion, from 0
+func (f *FakeMarketplaceService) GetMarketPlaceImageCurrentVersionArgsForCall(i int) string {
+	args := f.args("GetMarketPlaceImageCurrentVersion", i)
+	return args[0].(string)
+}
+
+type fakeGetMarketPlaceLocalImagesResults struct {
+	r0 *MarketLocalImages
+	r1 error
+}
+
+// GetMarketPlaceLocalImages records the call and returns the scripted results
+func (f *FakeMarketplaceService) GetMarketPlaceLocalImages(uuidImage string, uuidVersion string, uuidLocalImage string) (*MarketLocalImages, error) {
+	return f.GetMarketPlaceLocalImagesContext(context.Background(), uuidImage, uuidVersion, uuidLocalImage)
+}
+
+// GetMarketPlaceLocalImagesContext records the call and returns the scripted results
+func (f *FakeMarketplaceService) GetMarketPlaceLocalImagesContext(ctx context.Context, uuidImage string, uuidVersion string, uuidLocalImage string) (*MarketLocalImages, error) {
+	i := f.record("GetMarketPlaceLocalImages", uuidImage, uuidVersion, uuidLocalImage)
+	if f.GetMarketPlaceLocalImagesFunc != nil {
+		return f.GetMarketPlaceLocalImagesFunc(ctx, uuidImage, uuidVersion, uuidLocalImage)
+	}
+	f.mu.Lock()
+	r := f.getMarketPlaceLocalImages.next(i)
+	f.mu.Unlock()
+	return r.r0, r.r1
+}
+
+// GetMarketPlaceLocalImagesReturns sets the results of the calls of GetMarketPlaceLocalImages
+func (f *FakeMarketplaceService) GetMarketPlaceLocalImagesReturns(r0 *MarketLocalImages, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getMarketPlaceLocalImages.results = fakeGetMarketPlaceLocalImagesResults{r0, r1}
+}
+
+// GetMarketPlaceLocalImagesReturnsOnCall sets the results of the ith call of GetMarketPlaceLocalImages, from 0
+func (f *FakeMarketplaceService) GetMarketPlaceLocalImagesReturnsOnCall(i int, r0 *MarketLocalImages, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getMarketPlaceLocalImages.returnsOnCall(i, fakeGetMarketPlaceLocalImagesResults{r0, r1})
+}
+
+// GetMarketPlaceLocalImagesArgsForCall returns the arguments of the ith call of GetMarketPlaceLocalImages, from 0
+func (f *FakeMarketplaceService) GetMarketPlaceLocalImagesArgsForCall(i int) (string, string, string) {
+	args := f.args("GetMarketPlaceLocalImages", i)
+	return args[0].(string), args[1].(string), args[2].(string)
+}
+
+type fakePostMarketPlaceImageResults struct {
+	r0 error
+}
+
+// PostMarketPlaceImage records the call and returns the scripted results
+func (f *FakeMarketplaceService) PostMarketPlaceImage(image MarketImage) error {
+	return f.PostMarketPlaceImageContext(context.Background(), image)
+}
+
+// PostMarketPlaceImageContext records the call and returns the scripted results
+func (f *FakeMarketplaceService) PostMarketPlaceImageContext(ctx context.Context, image MarketImage) error {
+	i := f.record("PostMarketPlaceImage", image)
+	if f.PostMarketPlaceImageFunc != nil {
+		return f.PostMarketPlaceImageFunc(ctx, image)
+	}
+	f.mu.Lock()
+	r := f.postMarketPlaceImage.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// PostMarketPlaceImageReturns sets the results of the calls of PostMarketPlaceImage
+func (f *FakeMarketplaceService) PostMarketPlaceImageReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.postMarketPlaceImage.results = fakePostMarketPlaceImageResults{r0}
+}
+
+// PostMarketPlaceImageReturnsOnCall sets the results of the ith call of PostMarketPlaceImage, from 0
+func (f *FakeMarketplaceService) PostMarketPlaceImageReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.postMarketPlaceImage.returnsOnCall(i, fakePostMarketPlaceImageResults{r0})
+}
+
+// PostMarketPlaceImageArgsForCall returns the arguments of the ith call of PostMarketPlaceImage, from 0
+func (f *FakeMarketplaceService) PostMarketPlaceImageArgsForCall(i int) MarketImage {
+	args := f.args("PostMarketPlaceImage", i)
+	return args[0].(MarketImage)
+}
+
+type fakePostMarketPlaceImageVersionResults struct {
+	r0 error
+}
+
+// PostMarketPlaceImageVersion records the call and returns the scripted results
+func (f *FakeMarketplaceService) PostMarketPlaceImageVersion(uuidImage string, version MarketVersion) error {
+	return f.PostMarketPlaceImageVersionContext(context.Background(), uuidImage, version)
+}
+
+// PostMarketPlaceImageVersionContext records the call and returns the scripted results
+func (f *FakeMarketplaceService) PostMarketPlaceImageVersionContext(ctx context.Context, uuidImage string, version MarketVersion) error {
+	i := f.record("PostMarketPlaceImageVersion", uuidImage, version)
+	if f.PostMarketPlaceImageVersionFunc != nil {
+		return f.PostMarketPlaceImageVersionFunc(ctx, uuidImage, version)
+	}
+	f.mu.Lock()
+	r := f.postMarketPlaceImageVersion.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// PostMarketPlaceImageVersionReturns sets the results of the calls of PostMarketPlaceImageVersion
+func (f *FakeMarketplaceService) PostMarketPlaceImageVersionReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.postMarketPlaceImageVersion.results = fakePostMarketPlaceImageVersionResults{r0}
+}
+
+// PostMarketPlaceImageVersionReturnsOnCall sets the results of the ith call of PostMarketPlaceImageVersion, from 0
+func (f *FakeMarketplaceService) PostMarketPlaceImageVersionReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.postMarketPlaceImageVersion.returnsOnCall(i, fakePostMarketPlaceImageVersionResults{r0})
+}
+
+// PostMarketPlaceImageVersionArgsForCall returns the arguments of the ith call of PostMarketPlaceImageVersion, from 0
+func (f *FakeMarketplaceService) PostMarketPlaceImageVersionArgsForCall(i int) (string, MarketVersion) {
+	args := f.args("PostMarketPlaceImageVersion", i)
+	return args[0].(string), args[1].(MarketVersion)
+}
+
+type fakePostMarketPlaceLocalImageResults struct {
+	r0 error
+}
+
+// PostMarketPlaceLocalImage records the call and returns the scripted results
+func (f *FakeMarketplaceService) PostMarketPlaceLocalImage(uuidImage string, uuidVersion string, uuidLocalImage string, local MarketLocalImage) error {
+	return f.PostMarketPlaceLocalImageContext(context.Background(), uuidImage, uuidVersion, uuidLocalImage, local)
+}
+
+// PostMarketPlaceLocalImageContext records the call and returns the scripted results
+func (f *FakeMarketplaceService) PostMarketPlaceLocalImageContext(ctx context.Context, uuidImage string, uuidVersion string, uuidLocalImage string, local MarketLocalImage) error {
+	i := f.record("PostMarketPlaceLocalImage", uuidImage, uuidVersion, uuidLocalImage, local)
+	if f.PostMarketPlaceLocalImageFunc != nil {
+		return f.PostMarketPlaceLocalImageFunc(ctx, uuidImage, uuidVersion, uuidLocalImage, local)
+	}
+	f.mu.Lock()
+	r := f.postMarketPlaceLocalImage.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// PostMarketPlaceLocalImageReturns sets the results of the calls of PostMarketPlaceLocalImage
+func (f *FakeMarketplaceService) PostMarketPlaceLocalImageReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.postMarketPlaceLocalImage.results = fakePostMarketPlaceLocalImageResults{r0}
+}
+
+// PostMarketPlaceLocalImageReturnsOnCall sets the results of the ith call of PostMarketPlaceLocalImage, from 0
+func (f *FakeMarketplaceService) PostMarketPlaceLocalImageReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.postMarketPlaceLocalImage.returnsOnCall(i, fakePostMarketPlaceLocalImageResults{r0})
+}
+
+// PostMarketPlaceLocalImageArgsForCall returns the arguments of the ith call of PostMarketPlaceLocalImage, from 0
+func (f *FakeMarketplaceService) PostMarketPlaceLocalImageArgsForCall(i int) (string, string, string, MarketLocalImage) {
+	args := f.args("PostMarketPlaceLocalImage", i)
+	return args[0].(string), args[1].(string), args[2].(string), args[3].(MarketLocalImage)
+}
+
+type fakePutMarketPlaceImageResults struct {
+	r0 error
+}
+
+// PutMarketPlaceImage records the call and returns the scripted results
+func (f *FakeMarketplaceService) PutMarketPlaceImage(uuidImage string, image MarketImage) error {
+	return f.PutMarketPlaceImageContext(context.Background(), uuidImage, image)
+}
+
+// PutMarketPlaceImageContext records the call and returns the scripted results
+func (f *FakeMarketplaceService) PutMarketPlaceImageContext(ctx context.Context, uuidImage string, image MarketImage) error {
+	i := f.record("PutMarketPlaceImage", uuidImage, image)
+	if f.PutMarketPlaceImageFunc != nil {
+		return f.PutMarketPlaceImageFunc(ctx, uuidImage, image)
+	}
+	f.mu.Lock()
+	r := f.putMarketPlaceImage.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// PutMarketPlaceImageReturns sets the results of the calls of PutMarketPlaceImage
+func (f *FakeMarketplaceService) PutMarketPlaceImageReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.putMarketPlaceImage.results = fakePutMarketPlaceImageResults{r0}
+}
+
+// PutMarketPlaceImageReturnsOnCall sets the results of the ith call of PutMarketPlaceImage, from 0
+func (f *FakeMarketplaceService) PutMarketPlaceImageReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.putMarketPlaceImage.returnsOnCall(i, fakePutMarketPlaceImageResults{r0})
+}
+
+// PutMarketPlaceImageArgsForCall returns the arguments of the ith call of PutMarketPlaceImage, from 0
+func (f *FakeMarketplaceService) PutMarketPlaceImageArgsForCall(i int) (string, MarketImage) {
+	args := f.args("PutMarketPlaceImage", i)
+	return args[0].(string), args[1].(MarketImage)
+}
+
+type fakePutMarketPlaceImageVersionResults struct {
+	r0 error
+}
+
+// PutMarketPlaceImageVersion records the call and returns the scripted results
+func (f *FakeMarketplaceService) PutMarketPlaceImageVersion(uuidImage string, uuidVersion string, version MarketVersion) error {
+	return f.PutMarketPlaceImageVersionContext(context.Background(), uuidImage, uuidVersion, version)
+}
+
+// PutMarketPlaceImageVersionContext records the call and returns the scripted results
+func (f *FakeMarketplaceService) PutMarketPlaceImageVersionContext(ctx context.Context, uuidImage string, uuidVersion string, version MarketVersion) error {
+	i := f.record("PutMarketPlaceImageVersion", uuidImage, uuidVersion, version)
+	if f.PutMarketPlaceImageVersionFunc != nil {
+		return f.PutMarketPlaceImageVersionFunc(ctx, uuidImage, uuidVersion, version)
+	}
+	f.mu.Lock()
+	r := f.putMarketPlaceImageVersion.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// PutMarketPlaceImageVersionReturns sets the results of the calls of PutMarketPlaceImageVersion
+func (f *FakeMarketplaceService) PutMarketPlaceImageVersionReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.putMarketPlaceImageVersion.results = fakePutMarketPlaceImageVersionResults{r0}
+}
+
+// PutMarketPlaceImageVersionReturnsOnCall sets the results of the ith call of PutMarketPlaceImageVersion, from 0
+func (f *FakeMarketplaceService) PutMarketPlaceImageVersionReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.putMarketPlaceImageVersion.returnsOnCall(i, fakePutMarketPlaceImageVersionResults{r0})
+}
+
+// PutMarketPlaceImageVersionArgsForCall returns the arguments of the ith call of PutMarketPlaceImageVersion, from 0
+func (f *FakeMarketplaceService) PutMarketPlaceImageVersionArgsForCall(i int) (string, string, MarketVersion) {
+	args := f.args("PutMarketPlaceImageVersion", i)
+	return args[0].(string), args[1].(string), args[2].(MarketVersion)
+}
+
+type fakePutMarketPlaceLocalImageResults struct {
+	r0 error
+}
+
+// PutMarketPlaceLocalImage records the call and returns the scripted results
+func (f *FakeMarketplaceService) PutMarketPlaceLocalImage(uuidImage string, uuidVersion string, uuidLocalImage string, local MarketLocalImage) error {
+	return f.PutMarketPlaceLocalImageContext(context.Background(), uuidImage, uuidVersion, uuidLocalImage, local)
+}
+
+// PutMarketPlaceLocalImageContext records the call and returns the scripted results
+func (f *FakeMarketplaceService) PutMarketPlaceLocalImageContext(ctx context.Context, uuidImage string, uuidVersion string, uuidLocalImage string, local MarketLocalImage) error {
+	i := f.record("PutMarketPlaceLocalImage", uuidImage, uuidVersion, uuidLocalImage, local)
+	if f.PutMarketPlaceLocalImageFunc != nil {
+		return f.PutMarketPlaceLocalImageFunc(ctx, uuidImage, uuidVersion, uuidLocalImage, local)
+	}
+	f.mu.Lock()
+	r := f.putMarketPlaceLocalImage.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// PutMarketPlaceLocalImageReturns sets the results of the calls of PutMarketPlaceLocalImage
+func (f *FakeMarketplaceService) PutMarketPlaceLocalImageReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.putMarketPlaceLocalImage.results = fakePutMarketPlaceLocalImageResults{r0}
+}
+
+// PutMarketPlaceLocalImageReturnsOnCall sets the results of the ith call of PutMarketPlaceLocalImage, from 0
+func (f *FakeMarketplaceService) PutMarketPlaceLocalImageReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.putMarketPlaceLocalImage.returnsOnCall(i, fakePutMarketPlaceLocalImageResults{r0})
+}
+
+// PutMarketPlaceLocalImageArgsForCall returns the arguments of the ith call of PutMarketPlaceLocalImage, from 0
+func (f *FakeMarketplaceService) PutMarketPlaceLocalImageArgsForCall(i int) (string, string, string, MarketLocalImage) {
+	args := f.args("PutMarketPlaceLocalImage", i)
+	return args[0].(string), args[1].(string), args[2].(string), args[3].(MarketLocalImage)
+}
+
+type fakeDeleteMarketPlaceImageResults struct {
+	r0 error
+}
+
+// DeleteMarketPlaceImage records the call and returns the scripted results
+func (f *FakeMarketplaceService) DeleteMarketPlaceImage(uuidImage string) error {
+	return f.DeleteMarketPlaceImageContext(context.Background(), uuidImage)
+}
+
+// DeleteMarketPlaceImageContext records the call and returns the scripted results
+func (f *FakeMarketplaceService) DeleteMarketPlaceImageContext(ctx context.Context, uuidImage string) error {
+	i := f.record("DeleteMarketPlaceImage", uuidImage)
+	if f.DeleteMarketPlaceImageFunc != nil {
+		return f.DeleteMarketPlaceImageFunc(ctx, uuidImage)
+	}
+	f.mu.Lock()
+	r := f.deleteMarketPlaceImage.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// DeleteMarketPlaceImageReturns sets the results of the calls of DeleteMarketPlaceImage
+func (f *FakeMarketplaceService) DeleteMarketPlaceImageReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteMarketPlaceImage.results = fakeDeleteMarketPlaceImageResults{r0}
+}
+
+// DeleteMarketPlaceImageReturnsOnCall sets the results of the ith call of DeleteMarketPlaceImage, from 0
+func (f *FakeMarketplaceService) DeleteMarketPlaceImageReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteMarketPlaceImage.returnsOnCall(i, fakeDeleteMarketPlaceImageResults{r0})
+}
+
+// DeleteMarketPlaceImageArgsForCall returns the arguments of the ith call of DeleteMarketPlaceImage, from 0
+func (f *FakeMarketplaceService) DeleteMarketPlaceImageArgsForCall(i int) string {
+	args := f.args("DeleteMarketPlaceImage", i)
+	return args[0].(string)
+}
+
+type fakeDeleteMarketPlaceImageVersionResults struct {
+	r0 error
+}
+
+// DeleteMarketPlaceImageVersion records the call and returns the scripted results
+func (f *FakeMarketplaceService) DeleteMarketPlaceImageVersion(uuidImage string, uuidVersion string) error {
+	return f.DeleteMarketPlaceImageVersionContext(context.Background(), uuidImage, uuidVersion)
+}
+
+// DeleteMarketPlaceImageVersionContext records the call and returns the scripted results
+func (f *FakeMarketplaceService) DeleteMarketPlaceImageVersionContext(ctx context.Context, uuidImage string, uuidVersion string) error {
+	i := f.record("DeleteMarketPlaceImageVersion", uuidImage, uuidVersion)
+	if f.DeleteMarketPlaceImageVersionFunc != nil {
+		return f.DeleteMarketPlaceImageVersionFunc(ctx, uuidImage, uuidVersion)
+	}
+	f.mu.Lock()
+	r := f.deleteMarketPlaceImageVersion.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// DeleteMarketPlaceImageVersionReturns sets the results of the calls of DeleteMarketPlaceImageVersion
+func (f *FakeMarketplaceService) DeleteMarketPlaceImageVersionReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteMarketPlaceImageVersion.results = fakeDeleteMarketPlaceImageVersionResults{r0}
+}
+
+// DeleteMarketPlaceImageVersionReturnsOnCall sets the results of the ith call of DeleteMarketPlaceImageVersion, from 0
+func (f *FakeMarketplaceService) DeleteMarketPlaceImageVersionReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteMarketPlaceImageVersion.returnsOnCall(i, fakeDeleteMarketPlaceImageVersionResults{r0})
+}
+
+// DeleteMarketPlaceImageVersionArgsForCall returns the arguments of the ith call of DeleteMarketPlaceImageVersion, from 0
+func (f *FakeMarketplaceService) DeleteMarketPlaceImageVersionArgsForCall(i int) (string, string) {
+	args := f.args("DeleteMarketPlaceImageVersion", i)
+	return args[0].(string), args[1].(string)
+}
+
+type fakeDeleteMarketPlaceLocalImageResults struct {
+	r0 error
+}
+
+// DeleteMarketPlaceLocalImage records the call and returns the scripted results
+func (f *FakeMarketplaceService) DeleteMarketPlaceLocalImage(uuidImage string, uuidVersion string, uuidLocalImage string) error {
+	return f.DeleteMarketPlaceLocalImageContext(context.Background(), uuidImage, uuidVersion, uuidLocalImage)
+}
+
+// DeleteMarketPlaceLocalImageContext records the call and returns the scripted results
+func (f *FakeMarketplaceService) DeleteMarketPlaceLocalImageContext(ctx context.Context, uuidImage string, uuidVersion string, uuidLocalImage string) error {
+	i := f.record("DeleteMarketPlaceLocalImage", uuidImage, uuidVersion, uuidLocalImage)
+	if f.DeleteMarketPlaceLocalImageFunc != nil {
+		return f.DeleteMarketPlaceLocalImageFunc(ctx, uuidImage, uuidVersion, uuidLocalImage)
+	}
+	f.mu.Lock()
+	r := f.deleteMarketPlaceLocalImage.next(i)
+	f.mu.Unlock()
+	return r.r0
+}
+
+// DeleteMarketPlaceLocalImageReturns sets the results of the calls of DeleteMarketPlaceLocalImage
+func (f *FakeMarketplaceService) DeleteMarketPlaceLocalImageReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteMarketPlaceLocalImage.results = fakeDeleteMarketPlaceLocalImageResults{r0}
+}
+
+// DeleteMarketPlaceLocalImageReturnsOnCall sets the results of the ith call of DeleteMarketPlaceLocalImage, from 0
+func (f *FakeMarketplaceService) DeleteMarketPlaceLocalImageReturnsOnCall(i int, r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleteMarketPlaceLocalImage.returnsOnCall(i, fakeDeleteMarketPlaceLocalImageResults{r0})
+}
+
+// DeleteMarketPlaceLocalImageArgsForCall returns the arguments of the ith call of DeleteMarketPlaceLocalImage, from 0
+func (f *FakeMarketplaceService) DeleteMarketPlaceLocalImageArgsForCall(i int) (string, string, string) {
+	args := f.args("DeleteMarketPlaceLocalImage", i)
+	return args[0].(string), args[1].(string), args[2].(string)
+}
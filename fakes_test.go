@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// stopServer is an example of code depending on a service instead of API
+func stopServer(servers ServerService, serverID string) error {
+	server, err := servers.GetServer(serverID)
+	if err != nil {
+		return err
+	}
+	if server.State == "stopped" {
+		return nil
+	}
+	return servers.PostServerAction(serverID, "poweroff")
+}
+
+func TestFakeServerService(t *testing.T) {
+	fake := &FakeServerService{}
+	fake.GetServerReturns(&Server{State: "running"}, nil)
+	fake.GetServerReturnsOnCall(1, &Server{State: "stopped"}, nil)
+
+	if err := stopServer(fake, "server-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := stopServer(fake, "server-2"); err != nil {
+		t.Fatal(err)
+	}
+	if fake.CallCount("GetServer") != 2 || fake.CallCount("PostServerAction") != 1 {
+		t.Errorf("unexpected calls: %+v", fake.Calls())
+	}
+	if serverID := fake.GetServerArgsForCall(1); serverID != "server-2" {
+		t.Errorf("expected the second call to get server-2, got %s", serverID)
+	}
+	if serverID, action := fake.PostServerActionArgsForCall(0); serverID != "server-1" || action != "poweroff" {
+		t.Errorf("expected server-1 to be powered off, got %s %s", serverID, action)
+	}
+
+	failure := errors.New("boom")
+	fake.PostServerActionFunc = func(ctx context.Context, serverID, action string) error {
+		return failure
+	}
+	if err := stopServer(fake, "server-3"); err != failure {
+		t.Errorf("expected the error of PostServerActionFunc, got %v", err)
+	}
+	calls := fake.Calls()
+	if last := calls[len(calls)-1]; last.Method != "PostServerAction" || last.Args[0] != "server-3" {
+		t.Errorf("unexpected last call: %+v", last)
+	}
+}
+
+func TestFakeVolumeService_iterator(t *testing.T) {
+	fake := &FakeVolumeService{}
+
+	it := fake.ListVolumesIter(context.Background())
+	if it.Next() || it.Err() != nil {
+		t.Error("expected an empty iterator by default")
+	}
+
+	failure := errors.New("boom")
+	fake.ListVolumesIterReturns(SliceIterator([]Volume{{Name: "a"}, {Name: "b"}}, failure))
+	it = fake.ListVolumesIter(context.Background())
+	defer it.Close()
+	var names []string
+	for it.Next() {
+		names = append(names, it.Value().Name)
+	}
+	if len(names) != 2 || names[1] != "b" || it.Err() != failure {
+		t.Errorf("unexpected iteration: %v, %v", names, it.Err())
+	}
+}
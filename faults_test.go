@@ -0,0 +1,524 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smola/scaleway-sdk/scwtest"
+)
+
+// faultFixture holds the resources used by the methods under test
+type faultFixture struct {
+	user, server, volume, snapshot, image, ip, group, rule string
+	market, version, localImage                            string
+}
+
+// newFaultFixture creates the fixture resources, before any fault is injected
+func newFaultFixture(t *testing.T, s *API, srv *scwtest.Server) faultFixture {
+	t.Helper()
+	var (
+		f   faultFixture
+		err error
+	)
+	f.user = srv.UserID
+	f.server = srv.AddServer("web", "VC1S")
+	f.volume = srv.AddVolume("data", 10000000000)
+	if f.snapshot, err = s.PostSnapshot(f.volume, "data-snapshot"); err != nil {
+		t.Fatal(err)
+	}
+	if f.image, err = s.PostImage(f.snapshot, "data-image", "", "x86_64"); err != nil {
+		t.Fatal(err)
+	}
+	ip, err := s.NewIP()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.ip = ip.IP.ID
+	if err = s.PostSecurityGroup(NewSecurityGroup{Organization: s.Organization, Name: "web"}); err != nil {
+		t.Fatal(err)
+	}
+	groups, err := s.GetSecurityGroups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, group := range groups.SecurityGroups {
+		if group.ID != srv.DefaultSecurityGroup {
+			f.group = group.ID
+		}
+	}
+	rule, err := s.PostGroupRule(f.group, NewGroupRule{Action: "accept", Direction: "inbound", IPRange: "0.0.0.0/0", Protocol: "TCP", DestPortFrom: 80})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.rule = rule.ID
+	if err = s.PatchUserdata(f.server, "cloud-init", []byte("#cloud-config\n"), false); err != nil {
+		t.Fatal(err)
+	}
+	srv.SetMetadataServer(f.server)
+	market, err := s.GetMarketPlaceImages("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.market = market.Images[0].ID
+	f.version = market.Images[0].CurrentPublicVersion
+	locals, err := s.GetMarketPlaceLocalImages(f.market, f.version, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.localImage = locals.LocalImages[0].ID
+	return f
+}
+
+// faultMethod describes how a method of API talks to the API
+type faultMethod struct {
+	name string
+
+	// idempotent is set when every request of the method may be retried
+	idempotent bool
+
+	// reads is set when the method reads a response body, decodes when it
+	// also decodes it
+	reads, decodes bool
+
+	run func(ctx context.Context, s *API, f faultFixture) error
+}
+
+// ignore drops the result of a method
+func ignore[T any](_ T, err error) error {
+	return err
+}
+
+var faultMethods = []faultMethod{
+	{"GetServers", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetServersContext(ctx, true, 0))
+	}},
+	{"GetServer", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetServerContext(ctx, f.server))
+	}},
+	{"PostServer", false, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		image := f.image
+		return ignore(s.PostServerContext(ctx, ServerDefinition{Name: "db", Image: &image, CommercialType: "VC1S"}))
+	}},
+	{"PatchServer", false, true, false, func(ctx context.Context, s *API, f faultFixture) error {
+		name := "api"
+		return s.PatchServerContext(ctx, f.server, ServerPatchDefinition{Name: &name})
+	}},
+	{"PostServerAction", false, true, false, func(ctx context.Context, s *API, f faultFixture) error {
+		return s.PostServerActionContext(ctx, f.server, "poweron")
+	}},
+	{"DeleteServer", true, false, false, func(ctx context.Context, s *API, f faultFixture) error {
+		return s.DeleteServerContext(ctx, f.server)
+	}},
+	{"GetVolumes", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetVolumesContext(ctx))
+	}},
+	{"GetVolume", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetVolumeContext(ctx, f.volume))
+	}},
+	{"PostVolume", false, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.PostVolumeContext(ctx, VolumeDefinition{Name: "logs", Size: 1000000000, Type: "l_ssd"}))
+	}},
+	{"PutVolume", true, true, false, func(ctx context.Context, s *API, f faultFixture) error {
+		name := "backup"
+		return s.PutVolumeContext(ctx, f.volume, VolumePutDefinition{Name: &name})
+	}},
+	{"DeleteVolume", true, false, false, func(ctx context.Context, s *API, f faultFixture) error {
+		return s.DeleteVolumeContext(ctx, f.volume)
+	}},
+	{"GetSnapshots", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetSnapshotsContext(ctx))
+	}},
+	{"GetSnapshot", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetSnapshotContext(ctx, f.snapshot))
+	}},
+	{"PostSnapshot", false, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.PostSnapshotContext(ctx, f.volume, "again"))
+	}},
+	{"DeleteSnapshot", true, false, false, func(ctx context.Context, s *API, f faultFixture) error {
+		if err := s.DeleteImageContext(ctx, f.image); err != nil {
+			return err
+		}
+		return s.DeleteSnapshotContext(ctx, f.snapshot)
+	}},
+	{"GetImages", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetImagesContext(ctx))
+	}},
+	{"GetImage", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetImageContext(ctx, f.image))
+	}},
+	{"PostImage", false, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.PostImageContext(ctx, f.snapshot, "again", "", "x86_64"))
+	}},
+	{"DeleteImage", true, false, false, func(ctx context.Context, s *API, f faultFixture) error {
+		return s.DeleteImageContext(ctx, f.image)
+	}},
+	{"GetBootscripts", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetBootscriptsContext(ctx))
+	}},
+	{"GetIPS", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetIPSContext(ctx))
+	}},
+	{"GetIP", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetIPContext(ctx, f.ip))
+	}},
+	{"NewIP", false, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.NewIPContext(ctx))
+	}},
+	{"AttachIP", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return s.AttachIPContext(ctx, f.ip, f.server)
+	}},
+	{"DetachIP", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return s.DetachIPContext(ctx, f.ip)
+	}},
+	{"DeleteIP", true, false, false, func(ctx context.Context, s *API, f faultFixture) error {
+		return s.DeleteIPContext(ctx, f.ip)
+	}},
+	{"GetSecurityGroups", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetSecurityGroupsContext(ctx))
+	}},
+	{"GetASecurityGroup", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetASecurityGroupContext(ctx, f.group))
+	}},
+	{"PostSecurityGroup", false, true, false, func(ctx context.Context, s *API, f faultFixture) error {
+		return s.PostSecurityGroupContext(ctx, NewSecurityGroup{Organization: s.Organization, Name: "db"})
+	}},
+	{"PutSecurityGroup", true, true, false, func(ctx context.Context, s *API, f faultFixture) error {
+		return s.PutSecurityGroupContext(ctx, UpdateSecurityGroup{Organization: s.Organization, Name: "api"}, f.group)
+	}},
+	{"DeleteSecurityGroup", true, false, false, func(ctx context.Context, s *API, f faultFixture) error {
+		return s.DeleteSecurityGroupContext(ctx, f.group)
+	}},
+	{"GetGroupRules", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetGroupRulesContext(ctx, f.group))
+	}},
+	{"GetAGroupRule", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetAGroupRuleContext(ctx, f.group, f.rule))
+	}},
+	{"PostGroupRule", false, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.PostGroupRuleContext(ctx, f.group, NewGroupRule{Action: "accept", Direction: "inbound", IPRange: "0.0.0.0/0", Protocol: "TCP", DestPortFrom: 443}))
+	}},
+	{"PutGroupRule", true, true, false, func(ctx context.Context, s *API, f faultFixture) error {
+		return s.PutGroupRuleContext(ctx, NewGroupRule{Action: "drop", Direction: "inbound", IPRange: "0.0.0.0/0", Protocol: "TCP", DestPortFrom: 80}, f.group, f.rule)
+	}},
+	{"DeleteGroupRule", true, false, false, func(ctx context.Context, s *API, f faultFixture) error {
+		return s.DeleteGroupRuleContext(ctx, f.group, f.rule)
+	}},
+	{"GetUserdatas", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetUserdatasContext(ctx, f.server, false))
+	}},
+	{"GetUserdatas/metadata", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetUserdatasContext(ctx, f.server, true))
+	}},
+	{"GetUserdata", true, true, false, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetUserdataContext(ctx, f.server, "cloud-init", false))
+	}},
+	{"PatchUserdata", false, false, false, func(ctx context.Context, s *API, f faultFixture) error {
+		return s.PatchUserdataContext(ctx, f.server, "ssh", []byte("ssh-rsa AAAA"), false)
+	}},
+	{"DeleteUserdata", true, false, false, func(ctx context.Context, s *API, f faultFixture) error {
+		return s.DeleteUserdataContext(ctx, f.server, "cloud-init", false)
+	}},
+	{"GetTasks", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetTasksContext(ctx))
+	}},
+	{"GetDashboard", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetDashboardContext(ctx))
+	}},
+	{"GetContainers", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetContainersContext(ctx))
+	}},
+	{"GetServerAvailabilities", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetServerAvailabilitiesContext(ctx))
+	}},
+	{"GetUserID", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetUserIDContext(ctx))
+	}},
+	{"GetUser", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetUserContext(ctx))
+	}},
+	{"PatchUserSSHKey", false, true, false, func(ctx context.Context, s *API, f faultFixture) error {
+		return s.PatchUserSSHKeyContext(ctx, f.user, UserPatchSSHKeyDefinition{SSHPublicKeys: []KeyDefinition{{Key: "ssh-rsa AAAA jane"}}})
+	}},
+	{"GetOrganization", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetOrganizationContext(ctx))
+	}},
+	{"GetPermissions", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetPermissionsContext(ctx))
+	}},
+	{"GetQuotas", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetQuotasContext(ctx))
+	}},
+	{"GetMarketPlaceImages", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetMarketPlaceImagesContext(ctx, f.market))
+	}},
+	{"GetMarketPlaceImageVersions", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetMarketPlaceImageVersionsContext(ctx, f.market, ""))
+	}},
+	{"GetMarketPlaceImageCurrentVersion", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetMarketPlaceImageCurrentVersionContext(ctx, f.market))
+	}},
+	{"GetMarketPlaceLocalImages", true, true, true, func(ctx context.Context, s *API, f faultFixture) error {
+		return ignore(s.GetMarketPlaceLocalImagesContext(ctx, f.market, f.version, f.localImage))
+	}},
+	{"PostMarketPlaceImage", false, true, false, func(ctx context.Context, s *API, f faultFixture) error {
+		return s.PostMarketPlaceImageContext(ctx, MarketImage{Name: "Debian"})
+	}},
+	{"PostMarketPlaceImageVersion", false, true, false, func(ctx context.Context, s *API, f faultFixture) error {
+		var version MarketVersion
+		version.Version.Name = "next"
+		return s.PostMarketPlaceImageVersionContext(ctx, f.market, version)
+	}},
+	{"PutMarketPlaceImage", true, true, false, func(ctx context.Context, s *API, f faultFixture) error {
+		return s.PutMarketPlaceImageContext(ctx, f.market, MarketImage{Name: "Ubuntu"})
+	}},
+	{"PutMarketPlaceImageVersion", true, true, false, func(ctx context.Context, s *API, f faultFixture) error {
+		var version MarketVersion
+		version.Version.Name = "renamed"
+		return s.PutMarketPlaceImageVersionContext(ctx, f.market, f.version, version)
+	}},
+	{"PutMarketPlaceLocalImage", false, true, false, func(ctx context.Context, s *API, f faultFixture) error {
+		var local MarketLocalImage
+		local.LocalImages.Arch = "arm"
+		local.LocalImages.Zone = "par1"
+		return s.PutMarketPlaceLocalImageContext(ctx, f.market, f.version, f.localImage, local)
+	}},
+	{"DeleteMarketPlaceImage", true, false, false, func(ctx context.Context, s *API, f faultFixture) error {
+		return s.DeleteMarketPlaceImageContext(ctx, f.market)
+	}},
+	{"DeleteMarketPlaceImageVersion", true, false, false, func(ctx context.Context, s *API, f faultFixture) error {
+		return s.DeleteMarketPlaceImageVersionContext(ctx, f.market, f.version)
+	}},
+	{"DeleteMarketPlaceLocalImage", true, false, false, func(ctx context.Context, s *API, f faultFixture) error {
+		return s.DeleteMarketPlaceLocalImageContext(ctx, f.market, f.version, f.localImage)
+	}},
+}
+
+// faultCase is a fault injected into every request, and its expected
+// outcome for a method
+type faultCase struct {
+	name   string
+	rule   scwtest.Rule
+	expect func(m faultMethod, err error) bool
+
+	// timeout bounds the method, if set
+	timeout time.Duration
+}
+
+var faultCases = []faultCase{
+	{
+		name:    "slow",
+		rule:    scwtest.Rule{Fault: scwtest.Delay(200 * time.Millisecond)},
+		timeout: 20 * time.Millisecond,
+		expect: func(m faultMethod, err error) bool {
+			return errors.Is(err, context.DeadlineExceeded)
+		},
+	},
+	{
+		name: "5xx burst",
+		rule: scwtest.Rule{Calls: scwtest.Range(1, 2), Fault: scwtest.Status(http.StatusServiceUnavailable)},
+		expect: func(m faultMethod, err error) bool {
+			if m.idempotent {
+				return err == nil
+			}
+			return errors.Is(err, ErrServerError)
+		},
+	},
+	{
+		name: "outage",
+		rule: scwtest.Rule{Fault: scwtest.Status(http.StatusServiceUnavailable)},
+		expect: func(m faultMethod, err error) bool {
+			return errors.Is(err, ErrServerError)
+		},
+	},
+	{
+		name: "rate limited",
+		rule: scwtest.Rule{Calls: []int{1}, Fault: scwtest.RateLimited(0)},
+		expect: func(m faultMethod, err error) bool {
+			// throttled requests weren't processed, even POST is retried
+			return err == nil
+		},
+	},
+	{
+		name: "disconnect",
+		rule: scwtest.Rule{Calls: []int{1}, Fault: scwtest.Disconnect()},
+		expect: func(m faultMethod, err error) bool {
+			if m.idempotent {
+				return err == nil
+			}
+			var apiErr APIError
+			return err != nil && !errors.As(err, &apiErr)
+		},
+	},
+	{
+		name: "malformed",
+		rule: scwtest.Rule{Fault: scwtest.Malformed()},
+		expect: func(m faultMethod, err error) bool {
+			if m.decodes {
+				var syntaxErr *json.SyntaxError
+				return errors.As(err, &syntaxErr)
+			}
+			return err == nil
+		},
+	},
+	{
+		name: "truncated",
+		rule: scwtest.Rule{Fault: scwtest.Truncate(5)},
+		expect: func(m faultMethod, err error) bool {
+			if m.reads {
+				return errors.Is(err, io.ErrUnexpectedEOF)
+			}
+			return err == nil
+		},
+	},
+}
+
+func TestFaults(t *testing.T) {
+	for _, fault := range faultCases {
+		fault := fault
+		t.Run(fault.name, func(t *testing.T) {
+			for _, m := range faultMethods {
+				m := m
+				t.Run(m.name, func(t *testing.T) {
+					t.Parallel()
+					s, srv := newFakeAPI(t)
+					f := newFaultFixture(t, s, srv)
+					s.SetRetryPolicy(testRetryPolicy)
+					srv.Inject(fault.rule)
+
+					ctx := context.Background()
+					if fault.timeout > 0 {
+						var cancel context.CancelFunc
+						ctx, cancel = context.WithTimeout(ctx, fault.timeout)
+						defer cancel()
+					}
+					if err := m.run(ctx, s, f); !fault.expect(m, err) {
+						t.Errorf("unexpected outcome: %v", err)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestFaults_outageAttempts(t *testing.T) {
+	s, srv := newFakeAPI(t)
+	f := newFaultFixture(t, s, srv)
+	s.SetRetryPolicy(testRetryPolicy)
+	srv.Inject(scwtest.Rule{Fault: scwtest.Status(http.StatusServiceUnavailable)})
+
+	if _, err := s.GetServer(f.server); !errors.Is(err, ErrServerError) {
+		t.Fatalf("expected a server error, got %v", err)
+	}
+	// the failure of the HEAD request is ignored, then the GET request fails
+	if calls := srv.Calls("", ""); calls != 2*testRetryPolicy.MaxAttempts {
+		t.Errorf("expected GetServer to give up after %d attempts of HEAD and GET, got %d calls", testRetryPolicy.MaxAttempts, calls)
+	}
+	if _, err := s.NewIP(); !errors.Is(err, ErrServerError) {
+		t.Fatalf("expected a server error, got %v", err)
+	}
+	if calls := srv.Calls("", ""); calls != 2*testRetryPolicy.MaxAttempts+1 {
+		t.Errorf("expected NewIP not to be retried, got %d calls", calls-2*testRetryPolicy.MaxAttempts)
+	}
+}
+
+// addVolumes creates count volumes on the fake
+func addVolumes(srv *scwtest.Server, count int) {
+	for i := 0; i < count; i++ {
+		srv.AddVolume("data", 1000000000)
+	}
+}
+
+func TestFaults_wrongTotalCount(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		count int
+		want  int
+	}{
+		// the pages following a full last page are fetched
+		{"too low", 10, 120},
+		{"none", 0, 120},
+		{"one page short", 60, 120},
+		// the extra pages are empty
+		{"too high", 400, 120},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s, srv := newFakeAPI(t)
+			addVolumes(srv, 120)
+			srv.Inject(scwtest.Rule{Method: "HEAD", Path: "/compute/volumes", Fault: scwtest.TotalCount(tt.count)})
+
+			volumes, err := s.GetVolumes()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(*volumes) != tt.want {
+				t.Errorf("expected %d volumes, got %d", tt.want, len(*volumes))
+			}
+		})
+	}
+
+	// the iterator doesn't use the HEAD request, it stops on the first short page
+	s, srv := newFakeAPI(t)
+	addVolumes(srv, 120)
+	srv.Inject(scwtest.Rule{Method: "HEAD", Path: "/compute/volumes", Fault: scwtest.TotalCount(10)})
+	it := s.ListVolumesIter(context.Background())
+	defer it.Close()
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if count != 120 {
+		t.Errorf("expected the iterator to list 120 volumes, got %d", count)
+	}
+}
+
+func TestFaults_pagesIgnored(t *testing.T) {
+	// the API returns the same full page whatever the page requested
+	volumes := make([]string, perPage)
+	for i := range volumes {
+		volumes[i] = fmt.Sprintf(`{"id": "%d"}`, i)
+	}
+	calls := 0
+	s := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprintf(w, `{"volumes": [%s]}`, strings.Join(volumes, ","))
+	}))
+
+	got, err := s.GetVolumes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*got) != perPage || calls > 4 {
+		t.Errorf("expected the %d volumes of the page, got %d in %d requests", perPage, len(*got), calls)
+	}
+}
+
+func TestFaults_pagesChanging(t *testing.T) {
+	s, srv := newFakeAPI(t)
+	addVolumes(srv, 100)
+	// 20 volumes are created between the HEAD and the GET requests
+	srv.Inject(scwtest.Rule{
+		Method: "HEAD",
+		Path:   "/compute/volumes",
+		Calls:  []int{1},
+		Fault:  scwtest.Mutate(func() { addVolumes(srv, 20) }),
+	})
+
+	volumes, err := s.GetVolumes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the last page counted by HEAD is full, the following ones are fetched
+	if len(*volumes) != 120 {
+		t.Errorf("expected the 120 volumes, got %d", len(*volumes))
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/smola/scaleway-sdk
+
+go 1.24
+
+require (
+	go.opentelemetry.io/otel v1.28.0
+	go.opentelemetry.io/otel/trace v1.28.0
+	golang.org/x/sync v0.7.0
+	gopkg.in/yaml.v3 v3.0.1
+)
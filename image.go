@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -25,10 +26,10 @@ type Image struct {
 	Name string `json:"name,omitempty"`
 
 	// CreationDate is the creation date of the image
-	CreationDate string `json:"creation_date,omitempty"`
+	CreationDate Timestamp `json:"creation_date,omitzero"`
 
 	// ModificationDate is the date of the last modification of the image
-	ModificationDate string `json:"modification_date,omitempty"`
+	ModificationDate Timestamp `json:"modification_date,omitzero"`
 
 	// RootVolume is the root volume bound to the image
 	RootVolume Volume `json:"root_volume,omitempty"`
@@ -45,6 +46,9 @@ type Image struct {
 	// Arch is the architecture target of the image
 	Arch string `json:"arch,omitempty"`
 
+	// Tags represents user-defined tags
+	Tags []string `json:"tags,omitempty"`
+
 	// FIXME: extra_volumes
 }
 
@@ -91,14 +95,14 @@ type MarketLocalImage struct {
 
 // MarketVersionDefinition represents version of marketplace image
 type MarketVersionDefinition struct {
-	CreationDate string `json:"creation_date"`
-	ID           string `json:"id"`
+	CreationDate Timestamp `json:"creation_date"`
+	ID           string    `json:"id"`
 	Image        struct {
 		ID   string `json:"id"`
 		Name string `json:"name"`
 	} `json:"image"`
-	ModificationDate string `json:"modification_date"`
-	Name             string `json:"name"`
+	ModificationDate Timestamp `json:"modification_date"`
+	Name             string    `json:"name"`
 	MarketLocalImages
 }
 
@@ -114,14 +118,14 @@ type MarketVersion struct {
 
 // MarketImage represents MarketPlace image
 type MarketImage struct {
-	Categories           []string `json:"categories"`
-	CreationDate         string   `json:"creation_date"`
-	CurrentPublicVersion string   `json:"current_public_version"`
-	Description          string   `json:"description"`
-	ID                   string   `json:"id"`
-	Logo                 string   `json:"logo"`
-	ModificationDate     string   `json:"modification_date"`
-	Name                 string   `json:"name"`
+	Categories           []string  `json:"categories"`
+	CreationDate         Timestamp `json:"creation_date"`
+	CurrentPublicVersion string    `json:"current_public_version"`
+	Description          string    `json:"description"`
+	ID                   string    `json:"id"`
+	Logo                 string    `json:"logo"`
+	ModificationDate     Timestamp `json:"modification_date"`
+	Name                 string    `json:"name"`
 	Organization         struct {
 		ID   string `json:"id"`
 		Name string `json:"name"`
@@ -132,6 +136,14 @@ type MarketImage struct {
 
 // PostImage creates a new image
 func (s *API) PostImage(volumeID string, name string, bootscript string, arch string) (string, error) {
+	return s.PostImageContext(context.Background(), volumeID, name, bootscript, arch)
+}
+
+// PostImageContext is like PostImage but uses ctx for the underlying requests
+func (s *API) PostImageContext(ctx context.Context, volumeID string, name string, bootscript string, arch string) (_ string, err error) {
+	ctx, op := s.startOperation(ctx, "PostImage", "image", "")
+	defer op.End(&err)
+
 	definition := ImageDefinition{
 		SnapshotIDentifier: volumeID,
 		Name:               name,
@@ -142,7 +154,7 @@ func (s *API) PostImage(volumeID string, name string, bootscript string, arch st
 		definition.DefaultBootscript = &bootscript
 	}
 
-	resp, err := s.PostResponse(s.computeAPI, "images", definition)
+	resp, err := s.PostResponseContext(ctx, s.computeAPI, "images", definition)
 	if err != nil {
 		return "", err
 	}
@@ -162,7 +174,29 @@ func (s *API) PostImage(volumeID string, name string, bootscript string, arch st
 
 // GetImages gets the list of images from the API
 func (s *API) GetImages() (*[]MarketImage, error) {
-	images, err := s.GetMarketPlaceImages("")
+	return s.GetImagesContext(context.Background())
+}
+
+// GetImagesContext is like GetImages but uses ctx for the underlying requests
+func (s *API) GetImagesContext(ctx context.Context) (_ *[]MarketImage, err error) {
+	ctx, op := s.startOperation(ctx, "GetImages", "image", "")
+	defer op.End(&err)
+
+	images, err := s.marketImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	orgaImages, err := s.organizationImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	images = append(images, orgaImages...)
+	return &images, nil
+}
+
+// marketImages returns the images of the marketplace, which are global
+func (s *API) marketImages(ctx context.Context) ([]MarketImage, error) {
+	images, err := s.GetMarketPlaceImagesContext(ctx, "")
 	if err != nil {
 		return nil, err
 	}
@@ -175,9 +209,15 @@ func (s *API) GetImages() (*[]MarketImage, error) {
 			}
 		}
 	}
+	return images.Images, nil
+}
+
+// organizationImages returns the images of the organization in the region,
+// as marketplace images of the category MyImages
+func (s *API) organizationImages(ctx context.Context) ([]MarketImage, error) {
 	values := url.Values{}
 	values.Set("organization", s.Organization)
-	resp, err := s.GetResponsePaginate(s.computeAPI, "images", values)
+	resp, err := s.GetResponsePaginateContext(ctx, s.computeAPI, "images", values)
 	if err != nil {
 		return nil, err
 	}
@@ -193,8 +233,9 @@ func (s *API) GetImages() (*[]MarketImage, error) {
 		return nil, err
 	}
 
+	images := []MarketImage{}
 	for _, orgaImage := range OrgaImages.Images {
-		images.Images = append(images.Images, MarketImage{
+		images = append(images, MarketImage{
 			Categories:           []string{"MyImages"},
 			CreationDate:         orgaImage.CreationDate,
 			CurrentPublicVersion: orgaImage.Identifier,
@@ -212,8 +253,7 @@ func (s *API) GetImages() (*[]MarketImage, error) {
 								{
 									Arch: orgaImage.Arch,
 									ID:   orgaImage.Identifier,
-									// TODO: fecth images from ams1 and par1
-									Zone: s.Region,
+									Zone: s.region.Zone(),
 								},
 							},
 						},
@@ -222,12 +262,20 @@ func (s *API) GetImages() (*[]MarketImage, error) {
 			},
 		})
 	}
-	return &images.Images, nil
+	return images, nil
 }
 
 // GetImage gets an image from the API
 func (s *API) GetImage(imageID string) (*Image, error) {
-	resp, err := s.GetResponsePaginate(s.computeAPI, "images/"+imageID, url.Values{})
+	return s.GetImageContext(context.Background(), imageID)
+}
+
+// GetImageContext is like GetImage but uses ctx for the underlying requests
+func (s *API) GetImageContext(ctx context.Context, imageID string) (_ *Image, err error) {
+	ctx, op := s.startOperation(ctx, "GetImage", "image", imageID)
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.computeAPI, "images/"+imageID, url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -248,7 +296,15 @@ func (s *API) GetImage(imageID string) (*Image, error) {
 
 // DeleteImage deletes a image
 func (s *API) DeleteImage(imageID string) error {
-	resp, err := s.DeleteResponse(s.computeAPI, fmt.Sprintf("images/%s", imageID))
+	return s.DeleteImageContext(context.Background(), imageID)
+}
+
+// DeleteImageContext is like DeleteImage but uses ctx for the underlying requests
+func (s *API) DeleteImageContext(ctx context.Context, imageID string) (err error) {
+	ctx, op := s.startOperation(ctx, "DeleteImage", "image", imageID)
+	defer op.End(&err)
+
+	resp, err := s.DeleteResponseContext(ctx, s.computeAPI, fmt.Sprintf("images/%s", imageID))
 	if err != nil {
 		return err
 	}
@@ -262,7 +318,15 @@ func (s *API) DeleteImage(imageID string) error {
 
 // GetMarketPlaceImages returns images from marketplace
 func (s *API) GetMarketPlaceImages(uuidImage string) (*MarketImages, error) {
-	resp, err := s.GetResponsePaginate(MarketplaceAPI, fmt.Sprintf("images/%s", uuidImage), url.Values{})
+	return s.GetMarketPlaceImagesContext(context.Background(), uuidImage)
+}
+
+// GetMarketPlaceImagesContext is like GetMarketPlaceImages but uses ctx for the underlying requests
+func (s *API) GetMarketPlaceImagesContext(ctx context.Context, uuidImage string) (_ *MarketImages, err error) {
+	ctx, op := s.startOperation(ctx, "GetMarketPlaceImages", "marketplace_image", uuidImage)
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.marketplaceAPI, fmt.Sprintf("images/%s", uuidImage), url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -293,7 +357,15 @@ func (s *API) GetMarketPlaceImages(uuidImage string) (*MarketImages, error) {
 
 // GetMarketPlaceImageVersions returns image version
 func (s *API) GetMarketPlaceImageVersions(uuidImage, uuidVersion string) (*MarketVersions, error) {
-	resp, err := s.GetResponsePaginate(MarketplaceAPI, fmt.Sprintf("images/%v/versions/%s", uuidImage, uuidVersion), url.Values{})
+	return s.GetMarketPlaceImageVersionsContext(context.Background(), uuidImage, uuidVersion)
+}
+
+// GetMarketPlaceImageVersionsContext is like GetMarketPlaceImageVersions but uses ctx for the underlying requests
+func (s *API) GetMarketPlaceImageVersionsContext(ctx context.Context, uuidImage, uuidVersion string) (_ *MarketVersions, err error) {
+	ctx, op := s.startOperation(ctx, "GetMarketPlaceImageVersions", "marketplace_version", uuidVersion)
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.marketplaceAPI, fmt.Sprintf("images/%v/versions/%s", uuidImage, uuidVersion), url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -323,7 +395,15 @@ func (s *API) GetMarketPlaceImageVersions(uuidImage, uuidVersion string) (*Marke
 
 // GetMarketPlaceImageCurrentVersion return the image current version
 func (s *API) GetMarketPlaceImageCurrentVersion(uuidImage string) (*MarketVersion, error) {
-	resp, err := s.GetResponsePaginate(MarketplaceAPI, fmt.Sprintf("images/%v/versions/current", uuidImage), url.Values{})
+	return s.GetMarketPlaceImageCurrentVersionContext(context.Background(), uuidImage)
+}
+
+// GetMarketPlaceImageCurrentVersionContext is like GetMarketPlaceImageCurrentVersion but uses ctx for the underlying requests
+func (s *API) GetMarketPlaceImageCurrentVersionContext(ctx context.Context, uuidImage string) (_ *MarketVersion, err error) {
+	ctx, op := s.startOperation(ctx, "GetMarketPlaceImageCurrentVersion", "marketplace_version", "")
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.marketplaceAPI, fmt.Sprintf("images/%v/versions/current", uuidImage), url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -343,7 +423,15 @@ func (s *API) GetMarketPlaceImageCurrentVersion(uuidImage string) (*MarketVersio
 
 // GetMarketPlaceLocalImages returns images from local region
 func (s *API) GetMarketPlaceLocalImages(uuidImage, uuidVersion, uuidLocalImage string) (*MarketLocalImages, error) {
-	resp, err := s.GetResponsePaginate(MarketplaceAPI, fmt.Sprintf("images/%v/versions/%s/local_images/%s", uuidImage, uuidVersion, uuidLocalImage), url.Values{})
+	return s.GetMarketPlaceLocalImagesContext(context.Background(), uuidImage, uuidVersion, uuidLocalImage)
+}
+
+// GetMarketPlaceLocalImagesContext is like GetMarketPlaceLocalImages but uses ctx for the underlying requests
+func (s *API) GetMarketPlaceLocalImagesContext(ctx context.Context, uuidImage, uuidVersion, uuidLocalImage string) (_ *MarketLocalImages, err error) {
+	ctx, op := s.startOperation(ctx, "GetMarketPlaceLocalImages", "marketplace_local_image", uuidLocalImage)
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.marketplaceAPI, fmt.Sprintf("images/%v/versions/%s/local_images/%s", uuidImage, uuidVersion, uuidLocalImage), url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -371,7 +459,15 @@ func (s *API) GetMarketPlaceLocalImages(uuidImage, uuidVersion, uuidLocalImage s
 
 // PostMarketPlaceImage adds new image
 func (s *API) PostMarketPlaceImage(images MarketImage) error {
-	resp, err := s.PostResponse(MarketplaceAPI, "images/", images)
+	return s.PostMarketPlaceImageContext(context.Background(), images)
+}
+
+// PostMarketPlaceImageContext is like PostMarketPlaceImage but uses ctx for the underlying requests
+func (s *API) PostMarketPlaceImageContext(ctx context.Context, images MarketImage) (err error) {
+	ctx, op := s.startOperation(ctx, "PostMarketPlaceImage", "marketplace_image", images.ID)
+	defer op.End(&err)
+
+	resp, err := s.PostResponseContext(ctx, s.marketplaceAPI, "images/", images)
 	if err != nil {
 		return err
 	}
@@ -382,7 +478,15 @@ func (s *API) PostMarketPlaceImage(images MarketImage) error {
 
 // PostMarketPlaceImageVersion adds new image version
 func (s *API) PostMarketPlaceImageVersion(uuidImage string, version MarketVersion) error {
-	resp, err := s.PostResponse(MarketplaceAPI, fmt.Sprintf("images/%v/versions", uuidImage), version)
+	return s.PostMarketPlaceImageVersionContext(context.Background(), uuidImage, version)
+}
+
+// PostMarketPlaceImageVersionContext is like PostMarketPlaceImageVersion but uses ctx for the underlying requests
+func (s *API) PostMarketPlaceImageVersionContext(ctx context.Context, uuidImage string, version MarketVersion) (err error) {
+	ctx, op := s.startOperation(ctx, "PostMarketPlaceImageVersion", "marketplace_version", version.Version.ID)
+	defer op.End(&err)
+
+	resp, err := s.PostResponseContext(ctx, s.marketplaceAPI, fmt.Sprintf("images/%v/versions", uuidImage), version)
 	if err != nil {
 		return err
 	}
@@ -393,7 +497,15 @@ func (s *API) PostMarketPlaceImageVersion(uuidImage string, version MarketVersio
 
 // PostMarketPlaceLocalImage adds new local image
 func (s *API) PostMarketPlaceLocalImage(uuidImage, uuidVersion, uuidLocalImage string, local MarketLocalImage) error {
-	resp, err := s.PostResponse(MarketplaceAPI, fmt.Sprintf("images/%v/versions/%s/local_images/%v", uuidImage, uuidVersion, uuidLocalImage), local)
+	return s.PostMarketPlaceLocalImageContext(context.Background(), uuidImage, uuidVersion, uuidLocalImage, local)
+}
+
+// PostMarketPlaceLocalImageContext is like PostMarketPlaceLocalImage but uses ctx for the underlying requests
+func (s *API) PostMarketPlaceLocalImageContext(ctx context.Context, uuidImage, uuidVersion, uuidLocalImage string, local MarketLocalImage) (err error) {
+	ctx, op := s.startOperation(ctx, "PostMarketPlaceLocalImage", "marketplace_local_image", uuidLocalImage)
+	defer op.End(&err)
+
+	resp, err := s.PostResponseContext(ctx, s.marketplaceAPI, fmt.Sprintf("images/%v/versions/%s/local_images/%v", uuidImage, uuidVersion, uuidLocalImage), local)
 	if err != nil {
 		return err
 	}
@@ -404,7 +516,15 @@ func (s *API) PostMarketPlaceLocalImage(uuidImage, uuidVersion, uuidLocalImage s
 
 // PutMarketPlaceImage updates image
 func (s *API) PutMarketPlaceImage(uudiImage string, images MarketImage) error {
-	resp, err := s.PutResponse(MarketplaceAPI, fmt.Sprintf("images/%v", uudiImage), images)
+	return s.PutMarketPlaceImageContext(context.Background(), uudiImage, images)
+}
+
+// PutMarketPlaceImageContext is like PutMarketPlaceImage but uses ctx for the underlying requests
+func (s *API) PutMarketPlaceImageContext(ctx context.Context, uudiImage string, images MarketImage) (err error) {
+	ctx, op := s.startOperation(ctx, "PutMarketPlaceImage", "marketplace_image", uudiImage)
+	defer op.End(&err)
+
+	resp, err := s.PutResponseContext(ctx, s.marketplaceAPI, fmt.Sprintf("images/%v", uudiImage), images)
 	if err != nil {
 		return err
 	}
@@ -415,7 +535,15 @@ func (s *API) PutMarketPlaceImage(uudiImage string, images MarketImage) error {
 
 // PutMarketPlaceImageVersion updates image version
 func (s *API) PutMarketPlaceImageVersion(uuidImage, uuidVersion string, version MarketVersion) error {
-	resp, err := s.PutResponse(MarketplaceAPI, fmt.Sprintf("images/%v/versions/%v", uuidImage, uuidVersion), version)
+	return s.PutMarketPlaceImageVersionContext(context.Background(), uuidImage, uuidVersion, version)
+}
+
+// PutMarketPlaceImageVersionContext is like PutMarketPlaceImageVersion but uses ctx for the underlying requests
+func (s *API) PutMarketPlaceImageVersionContext(ctx context.Context, uuidImage, uuidVersion string, version MarketVersion) (err error) {
+	ctx, op := s.startOperation(ctx, "PutMarketPlaceImageVersion", "marketplace_version", uuidVersion)
+	defer op.End(&err)
+
+	resp, err := s.PutResponseContext(ctx, s.marketplaceAPI, fmt.Sprintf("images/%v/versions/%v", uuidImage, uuidVersion), version)
 	if err != nil {
 		return err
 	}
@@ -426,7 +554,15 @@ func (s *API) PutMarketPlaceImageVersion(uuidImage, uuidVersion string, version
 
 // PutMarketPlaceLocalImage updates local image
 func (s *API) PutMarketPlaceLocalImage(uuidImage, uuidVersion, uuidLocalImage string, local MarketLocalImage) error {
-	resp, err := s.PostResponse(MarketplaceAPI, fmt.Sprintf("images/%v/versions/%s/local_images/%v", uuidImage, uuidVersion, uuidLocalImage), local)
+	return s.PutMarketPlaceLocalImageContext(context.Background(), uuidImage, uuidVersion, uuidLocalImage, local)
+}
+
+// PutMarketPlaceLocalImageContext is like PutMarketPlaceLocalImage but uses ctx for the underlying requests
+func (s *API) PutMarketPlaceLocalImageContext(ctx context.Context, uuidImage, uuidVersion, uuidLocalImage string, local MarketLocalImage) (err error) {
+	ctx, op := s.startOperation(ctx, "PutMarketPlaceLocalImage", "marketplace_local_image", uuidLocalImage)
+	defer op.End(&err)
+
+	resp, err := s.PostResponseContext(ctx, s.marketplaceAPI, fmt.Sprintf("images/%v/versions/%s/local_images/%v", uuidImage, uuidVersion, uuidLocalImage), local)
 	if err != nil {
 		return err
 	}
@@ -437,7 +573,15 @@ func (s *API) PutMarketPlaceLocalImage(uuidImage, uuidVersion, uuidLocalImage st
 
 // DeleteMarketPlaceImage deletes image
 func (s *API) DeleteMarketPlaceImage(uudImage string) error {
-	resp, err := s.DeleteResponse(MarketplaceAPI, fmt.Sprintf("images/%v", uudImage))
+	return s.DeleteMarketPlaceImageContext(context.Background(), uudImage)
+}
+
+// DeleteMarketPlaceImageContext is like DeleteMarketPlaceImage but uses ctx for the underlying requests
+func (s *API) DeleteMarketPlaceImageContext(ctx context.Context, uudImage string) (err error) {
+	ctx, op := s.startOperation(ctx, "DeleteMarketPlaceImage", "marketplace_image", uudImage)
+	defer op.End(&err)
+
+	resp, err := s.DeleteResponseContext(ctx, s.marketplaceAPI, fmt.Sprintf("images/%v", uudImage))
 	if err != nil {
 		return err
 	}
@@ -448,7 +592,15 @@ func (s *API) DeleteMarketPlaceImage(uudImage string) error {
 
 // DeleteMarketPlaceImageVersion delete image version
 func (s *API) DeleteMarketPlaceImageVersion(uuidImage, uuidVersion string) error {
-	resp, err := s.DeleteResponse(MarketplaceAPI, fmt.Sprintf("images/%v/versions/%v", uuidImage, uuidVersion))
+	return s.DeleteMarketPlaceImageVersionContext(context.Background(), uuidImage, uuidVersion)
+}
+
+// DeleteMarketPlaceImageVersionContext is like DeleteMarketPlaceImageVersion but uses ctx for the underlying requests
+func (s *API) DeleteMarketPlaceImageVersionContext(ctx context.Context, uuidImage, uuidVersion string) (err error) {
+	ctx, op := s.startOperation(ctx, "DeleteMarketPlaceImageVersion", "marketplace_version", uuidVersion)
+	defer op.End(&err)
+
+	resp, err := s.DeleteResponseContext(ctx, s.marketplaceAPI, fmt.Sprintf("images/%v/versions/%v", uuidImage, uuidVersion))
 	if err != nil {
 		return err
 	}
@@ -459,7 +611,15 @@ func (s *API) DeleteMarketPlaceImageVersion(uuidImage, uuidVersion string) error
 
 // DeleteMarketPlaceLocalImage deletes local image
 func (s *API) DeleteMarketPlaceLocalImage(uuidImage, uuidVersion, uuidLocalImage string) error {
-	resp, err := s.DeleteResponse(MarketplaceAPI, fmt.Sprintf("images/%v/versions/%s/local_images/%v", uuidImage, uuidVersion, uuidLocalImage))
+	return s.DeleteMarketPlaceLocalImageContext(context.Background(), uuidImage, uuidVersion, uuidLocalImage)
+}
+
+// DeleteMarketPlaceLocalImageContext is like DeleteMarketPlaceLocalImage but uses ctx for the underlying requests
+func (s *API) DeleteMarketPlaceLocalImageContext(ctx context.Context, uuidImage, uuidVersion, uuidLocalImage string) (err error) {
+	ctx, op := s.startOperation(ctx, "DeleteMarketPlaceLocalImage", "marketplace_local_image", uuidLocalImage)
+	defer op.End(&err)
+
+	resp, err := s.DeleteResponseContext(ctx, s.marketplaceAPI, fmt.Sprintf("images/%v/versions/%s/local_images/%v", uuidImage, uuidVersion, uuidLocalImage))
 	if err != nil {
 		return err
 	}
@@ -467,3 +627,17 @@ func (s *API) DeleteMarketPlaceLocalImage(uuidImage, uuidVersion, uuidLocalImage
 	_, err = s.handleHTTPError([]int{http.StatusNoContent}, resp)
 	return err
 }
+
+// ListImagesIter returns an iterator over the images of the organization
+func (s *API) ListImagesIter(ctx context.Context) *Iterator[Image] {
+	values := url.Values{}
+	values.Set("organization", s.Organization)
+	return newIterator[Image](ctx, s, listing{
+		operation:    "ListImagesIter",
+		resourceType: "image",
+		apiURL:       s.computeAPI,
+		resource:     "images",
+		values:       values,
+		key:          "images",
+	}, nil)
+}
@@ -0,0 +1,266 @@
+// Command genfakes generates the Fake* implementations of the service
+// interfaces of the api package, see services.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+)
+
+// param is a parameter or a result of a method
+type param struct {
+	name, typ string
+}
+
+// operation is a method of a service, or the pair M and MContext
+type operation struct {
+	name string
+
+	// withContext is the name of the method taking a context, if any
+	withContext string
+
+	// plain is set when M comes with MContext
+	plain bool
+
+	params  []param
+	results []param
+}
+
+type service struct {
+	name       string
+	operations []operation
+}
+
+func main() {
+	output := flag.String("o", "fakes_gen.go", "output file")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		log.Fatal("usage: genfakes -o fakes_gen.go services.go")
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, flag.Arg(0), nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var services []service
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			spec := spec.(*ast.TypeSpec)
+			iface, ok := spec.Type.(*ast.InterfaceType)
+			if !ok || !strings.HasSuffix(spec.Name.Name, "Service") {
+				continue
+			}
+			services = append(services, parseService(fset, spec.Name.Name, iface))
+		}
+	}
+
+	var b bytes.Buffer
+	generate(&b, file.Name.Name, services)
+	content, err := format.Source(b.Bytes())
+	if err != nil {
+		os.Stdout.Write(b.Bytes())
+		log.Fatal(err)
+	}
+	if err = ioutil.WriteFile(*output, content, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func parseService(fset *token.FileSet, name string, iface *ast.InterfaceType) service {
+	methods := map[string]bool{}
+	for _, field := range iface.Methods.List {
+		methods[field.Names[0].Name] = true
+	}
+
+	s := service{name: name}
+	for _, field := range iface.Methods.List {
+		method := field.Names[0].Name
+		if methods[method+"Context"] {
+			// generated with MContext
+			continue
+		}
+		fn := field.Type.(*ast.FuncType)
+		op := operation{
+			name:    method,
+			params:  fields(fset, fn.Params),
+			results: fields(fset, fn.Results),
+		}
+		if len(op.params) > 0 && op.params[0].typ == "context.Context" {
+			op.withContext = method
+			op.params = op.params[1:]
+			if plain := strings.TrimSuffix(method, "Context"); plain != method && methods[plain] {
+				op.name = plain
+				op.plain = true
+			}
+		}
+		s.operations = append(s.operations, op)
+	}
+	return s
+}
+
+func fields(fset *token.FileSet, list *ast.FieldList) []param {
+	var ret []param
+	if list == nil {
+		return ret
+	}
+	for _, field := range list.List {
+		var b bytes.Buffer
+		printer.Fprint(&b, fset, field.Type)
+		if len(field.Names) == 0 {
+			ret = append(ret, param{name: fmt.Sprintf("r%d", len(ret)), typ: b.String()})
+			continue
+		}
+		for _, name := range field.Names {
+			ret = append(ret, param{name: name.Name, typ: b.String()})
+		}
+	}
+	return ret
+}
+
+func join(params []param, f func(int, param) string) string {
+	var ret []string
+	for i, p := range params {
+		ret = append(ret, f(i, p))
+	}
+	return strings.Join(ret, ", ")
+}
+
+func declare(params []param) string {
+	return join(params, func(_ int, p param) string { return p.name + " " + p.typ })
+}
+
+func names(params []param) string {
+	return join(params, func(_ int, p param) string { return p.name })
+}
+
+func types(params []param) string {
+	return join(params, func(_ int, p param) string { return p.typ })
+}
+
+// resultsType returns the name of the struct holding the results of op
+func resultsType(op operation) string {
+	return "fake" + op.name + "Results"
+}
+
+// script returns the name of the field holding the results of op
+func script(op operation) string {
+	return strings.ToLower(op.name[:1]) + op.name[1:]
+}
+
+func generate(b *bytes.Buffer, pkg string, services []service) {
+	fmt.Fprintf(b, "// Code generated by genfakes from services.go. DO NOT EDIT.\n\n")
+	fmt.Fprintf(b, "package %s\n\nimport \"context\"\n\n", pkg)
+
+	for _, s := range services {
+		fake := "Fake" + s.name
+		fmt.Fprintf(b, "// %s is a %s recording its calls and returning scripted results,\n", fake, s.name)
+		fmt.Fprintf(b, "// the calls which aren't scripted return the zero values\n")
+		fmt.Fprintf(b, "type %s struct {\n\tfakeRecorder\n\n", fake)
+		for _, op := range s.operations {
+			ctx := ""
+			if op.withContext != "" {
+				ctx = "context.Context"
+				if len(op.params) > 0 {
+					ctx += ", "
+				}
+			}
+			fmt.Fprintf(b, "\t// %sFunc, if set, answers the calls of %s\n", op.name, op.name)
+			fmt.Fprintf(b, "\t%sFunc func(%s%s) (%s)\n\n", op.name, ctx, types(op.params), types(op.results))
+		}
+		for _, op := range s.operations {
+			fmt.Fprintf(b, "\t%s fakeScript[%s]\n", script(op), resultsType(op))
+		}
+		fmt.Fprintf(b, "}\n\nvar _ %s = (*%s)(nil)\n\n", s.name, fake)
+
+		for _, op := range s.operations {
+			generateOperation(b, fake, op)
+		}
+	}
+}
+
+func generateOperation(b *bytes.Buffer, fake string, op operation) {
+	results := make([]param, len(op.results))
+	for i, r := range op.results {
+		results[i] = param{name: fmt.Sprintf("r%d", i), typ: r.typ}
+	}
+
+	fmt.Fprintf(b, "type %s struct {\n", resultsType(op))
+	for _, r := range results {
+		fmt.Fprintf(b, "\t%s %s\n", r.name, r.typ)
+	}
+	fmt.Fprintf(b, "}\n\n")
+
+	args := names(op.params)
+	ctxParam, ctxArg := "", ""
+	if op.withContext != "" {
+		ctxParam, ctxArg = "ctx context.Context", "ctx"
+		if len(op.params) > 0 {
+			ctxParam += ", "
+			ctxArg += ", "
+		}
+	}
+	if op.plain {
+		fmt.Fprintf(b, "// %s records the call and returns the scripted results\n", op.name)
+		fmt.Fprintf(b, "func (f *%s) %s(%s) (%s) {\n", fake, op.name, declare(op.params), types(op.results))
+		fmt.Fprintf(b, "\treturn f.%s(context.Background()%s)\n}\n\n", op.withContext, prefixed(args))
+	}
+	method := op.name
+	if op.withContext != "" {
+		method = op.withContext
+	}
+	fmt.Fprintf(b, "// %s records the call and returns the scripted results\n", method)
+	fmt.Fprintf(b, "func (f *%s) %s(%s%s) (%s) {\n", fake, method, ctxParam, declare(op.params), types(op.results))
+	fmt.Fprintf(b, "\ti := f.record(%q%s)\n", op.name, prefixed(args))
+	fmt.Fprintf(b, "\tif f.%sFunc != nil {\n\t\treturn f.%sFunc(%s%s)\n\t}\n", op.name, op.name, ctxArg, args)
+	fmt.Fprintf(b, "\tf.mu.Lock()\n\tr := f.%s.next(i)\n\tf.mu.Unlock()\n", script(op))
+	for _, r := range results {
+		if item := strings.TrimPrefix(r.typ, "*Iterator["); item != r.typ {
+			// a nil iterator can't be used, an empty one is returned instead
+			fmt.Fprintf(b, "\tif r.%s == nil {\n\t\tr.%s = SliceIterator[%s(nil, nil)\n\t}\n", r.name, r.name, item)
+		}
+	}
+	fmt.Fprintf(b, "\treturn %s\n}\n\n", join(results, func(_ int, p param) string { return "r." + p.name }))
+
+	fmt.Fprintf(b, "// %sReturns sets the results of the calls of %s\n", op.name, op.name)
+	fmt.Fprintf(b, "func (f *%s) %sReturns(%s) {\n", fake, op.name, declare(results))
+	fmt.Fprintf(b, "\tf.mu.Lock()\n\tdefer f.mu.Unlock()\n")
+	fmt.Fprintf(b, "\tf.%s.results = %s{%s}\n}\n\n", script(op), resultsType(op), names(results))
+
+	fmt.Fprintf(b, "// %sReturnsOnCall sets the results of the ith call of %s, from 0\n", op.name, op.name)
+	fmt.Fprintf(b, "func (f *%s) %sReturnsOnCall(i int%s) {\n", fake, op.name, prefixed(declare(results)))
+	fmt.Fprintf(b, "\tf.mu.Lock()\n\tdefer f.mu.Unlock()\n")
+	fmt.Fprintf(b, "\tf.%s.returnsOnCall(i, %s{%s})\n}\n\n", script(op), resultsType(op), names(results))
+
+	if len(op.params) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "// %sArgsForCall returns the arguments of the ith call of %s, from 0\n", op.name, op.name)
+	fmt.Fprintf(b, "func (f *%s) %sArgsForCall(i int) (%s) {\n", fake, op.name, types(op.params))
+	fmt.Fprintf(b, "\targs := f.args(%q, i)\n", op.name)
+	fmt.Fprintf(b, "\treturn %s\n}\n\n", join(op.params, func(i int, p param) string {
+		return fmt.Sprintf("args[%d].(%s)", i, p.typ)
+	}))
+}
+
+// prefixed returns list preceded by a comma, if not empty
+func prefixed(list string) string {
+	if list == "" {
+		return ""
+	}
+	return ", " + list
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -50,7 +51,15 @@ type GetIP struct {
 
 // GetIP returns a GetIP
 func (s *API) GetIP(ipID string) (*GetIP, error) {
-	resp, err := s.GetResponsePaginate(s.computeAPI, fmt.Sprintf("ips/%s", ipID), url.Values{})
+	return s.GetIPContext(context.Background(), ipID)
+}
+
+// GetIPContext is like GetIP but uses ctx for the underlying requests
+func (s *API) GetIPContext(ctx context.Context, ipID string) (_ *GetIP, err error) {
+	ctx, op := s.startOperation(ctx, "GetIP", "ip", ipID)
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.computeAPI, fmt.Sprintf("ips/%s", ipID), url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +79,15 @@ func (s *API) GetIP(ipID string) (*GetIP, error) {
 
 // GetIPS returns a GetIPS
 func (s *API) GetIPS() (*GetIPS, error) {
-	resp, err := s.GetResponsePaginate(s.computeAPI, "ips", url.Values{})
+	return s.GetIPSContext(context.Background())
+}
+
+// GetIPSContext is like GetIPS but uses ctx for the underlying requests
+func (s *API) GetIPSContext(ctx context.Context) (_ *GetIPS, err error) {
+	ctx, op := s.startOperation(ctx, "GetIPS", "ip", "")
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.computeAPI, "ips", url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -90,11 +107,19 @@ func (s *API) GetIPS() (*GetIPS, error) {
 
 // NewIP returns a new IP
 func (s *API) NewIP() (*GetIP, error) {
+	return s.NewIPContext(context.Background())
+}
+
+// NewIPContext is like NewIP but uses ctx for the underlying requests
+func (s *API) NewIPContext(ctx context.Context) (_ *GetIP, err error) {
+	ctx, op := s.startOperation(ctx, "NewIP", "ip", "")
+	defer op.End(&err)
+
 	var orga struct {
 		Organization string `json:"organization"`
 	}
 	orga.Organization = s.Organization
-	resp, err := s.PostResponse(s.computeAPI, "ips", orga)
+	resp, err := s.PostResponseContext(ctx, s.computeAPI, "ips", orga)
 	if err != nil {
 		return nil, err
 	}
@@ -114,6 +139,14 @@ func (s *API) NewIP() (*GetIP, error) {
 
 // AttachIP attachs an IP to a server
 func (s *API) AttachIP(ipID, serverID string) error {
+	return s.AttachIPContext(context.Background(), ipID, serverID)
+}
+
+// AttachIPContext is like AttachIP but uses ctx for the underlying requests
+func (s *API) AttachIPContext(ctx context.Context, ipID, serverID string) (err error) {
+	ctx, op := s.startOperation(ctx, "AttachIP", "ip", ipID)
+	defer op.End(&err)
+
 	var update struct {
 		Address      string  `json:"address"`
 		ID           string  `json:"id"`
@@ -122,7 +155,7 @@ func (s *API) AttachIP(ipID, serverID string) error {
 		Server       string  `json:"server"`
 	}
 
-	ip, err := s.GetIP(ipID)
+	ip, err := s.GetIPContext(ctx, ipID)
 	if err != nil {
 		return err
 	}
@@ -130,22 +163,31 @@ func (s *API) AttachIP(ipID, serverID string) error {
 	update.ID = ip.IP.ID
 	update.Organization = ip.IP.Organization
 	update.Server = serverID
-	resp, err := s.PutResponse(s.computeAPI, fmt.Sprintf("ips/%s", ipID), update)
+	resp, err := s.PutResponseContext(ctx, s.computeAPI, fmt.Sprintf("ips/%s", ipID), update)
 	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
 	_, err = s.handleHTTPError([]int{http.StatusOK}, resp)
 	return err
 }
 
 // DetachIP detaches an IP from a server
 func (s *API) DetachIP(ipID string) error {
-	ip, err := s.GetIP(ipID)
+	return s.DetachIPContext(context.Background(), ipID)
+}
+
+// DetachIPContext is like DetachIP but uses ctx for the underlying requests
+func (s *API) DetachIPContext(ctx context.Context, ipID string) (err error) {
+	ctx, op := s.startOperation(ctx, "DetachIP", "ip", ipID)
+	defer op.End(&err)
+
+	ip, err := s.GetIPContext(ctx, ipID)
 	if err != nil {
 		return err
 	}
 	ip.IP.Server = nil
-	resp, err := s.PutResponse(s.computeAPI, fmt.Sprintf("ips/%s", ipID), ip.IP)
+	resp, err := s.PutResponseContext(ctx, s.computeAPI, fmt.Sprintf("ips/%s", ipID), ip.IP)
 	if err != nil {
 		return err
 	}
@@ -156,7 +198,15 @@ func (s *API) DetachIP(ipID string) error {
 
 // DeleteIP deletes an IP
 func (s *API) DeleteIP(ipID string) error {
-	resp, err := s.DeleteResponse(s.computeAPI, fmt.Sprintf("ips/%s", ipID))
+	return s.DeleteIPContext(context.Background(), ipID)
+}
+
+// DeleteIPContext is like DeleteIP but uses ctx for the underlying requests
+func (s *API) DeleteIPContext(ctx context.Context, ipID string) (err error) {
+	ctx, op := s.startOperation(ctx, "DeleteIP", "ip", ipID)
+	defer op.End(&err)
+
+	resp, err := s.DeleteResponseContext(ctx, s.computeAPI, fmt.Sprintf("ips/%s", ipID))
 	if err != nil {
 		return err
 	}
@@ -164,3 +214,14 @@ func (s *API) DeleteIP(ipID string) error {
 	_, err = s.handleHTTPError([]int{http.StatusNoContent}, resp)
 	return err
 }
+
+// ListIPsIter returns an iterator over the IPs
+func (s *API) ListIPsIter(ctx context.Context) *Iterator[IPV4] {
+	return newIterator[IPV4](ctx, s, listing{
+		operation:    "ListIPsIter",
+		resourceType: "ip",
+		apiURL:       s.computeAPI,
+		resource:     "ips",
+		key:          "ips",
+	}, nil)
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// prefetchPages is the number of pages an Iterator fetches ahead of its caller
+const prefetchPages = 2
+
+var errIteratorClosed = errors.New("iterator closed")
+
+// Iterator iterates over a collection of the API, fetching its pages on
+// demand and decoding its items one at a time.
+// Close must be called if the iteration is stopped before Next returns false.
+//
+//	it := api.ListServersIter(ctx, true)
+//	defer it.Close()
+//	for it.Next() {
+//		server := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type Iterator[T any] struct {
+	items     chan T
+	err       error
+	current   T
+	cancel    context.CancelCauseFunc
+	transform func(*T)
+}
+
+// listing describes a paginated collection of the API
+type listing struct {
+	operation    string
+	resourceType string
+	apiURL       string
+	resource     string
+	values       url.Values
+
+	// key is the key holding the items in the pages, i.e: "servers"
+	key string
+}
+
+// newIterator starts to fetch the items of l, transform may be nil
+func newIterator[T any](ctx context.Context, s *API, l listing, transform func(*T)) *Iterator[T] {
+	ctx, cancel := context.WithCancelCause(ctx)
+	it := &Iterator[T]{
+		items:     make(chan T, prefetchPages*perPage),
+		cancel:    cancel,
+		transform: transform,
+	}
+	go func() {
+		defer close(it.items)
+		it.err = it.run(ctx, s, l)
+	}()
+	return it
+}
+
+// Next advances to the next item, it returns false when there are no more
+// items or when an error occurred
+func (it *Iterator[T]) Next() bool {
+	item, ok := <-it.items
+	if !ok {
+		it.cancel(nil)
+		return false
+	}
+	it.current = item
+	return true
+}
+
+// Value returns the current item
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+// Err returns the error which stopped the iteration, once Next returned false
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Close stops the iteration and releases its resources
+func (it *Iterator[T]) Close() {
+	it.cancel(errIteratorClosed)
+	for range it.items {
+	}
+}
+
+func (it *Iterator[T]) run(ctx context.Context, s *API, l listing) (err error) {
+	ctx, op := s.startOperation(ctx, l.operation, l.resourceType, "")
+	defer op.End(&err)
+	defer func() {
+		// stopping early isn't a failure
+		if context.Cause(ctx) == errIteratorClosed {
+			err = nil
+		}
+	}()
+
+	for page := 1; ; page++ {
+		query := url.Values{}
+		for k, v := range l.values {
+			query[k] = v
+		}
+		query.Set("per_page", strconv.Itoa(perPage))
+		query.Set("page", strconv.Itoa(page))
+		resp, err := s.response(ctx, "GET", fmt.Sprintf("%s/%s?%s", strings.TrimRight(l.apiURL, "/"), l.resource, query.Encode()), nil)
+		if err != nil {
+			return err
+		}
+		count, err := it.decodePage(ctx, s, resp, l.key)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+		s.observePages(ctx, 1)
+
+		total, err := strconv.Atoi(resp.Header.Get("X-Total-Count"))
+		if count < perPage || (err == nil && page*perPage >= total) {
+			return nil
+		}
+	}
+}
+
+// decodePage streams the items of a page, held by key, to the caller
+func (it *Iterator[T]) decodePage(ctx context.Context, s *API, resp *http.Response, key string) (int, error) {
+	if resp.StatusCode != http.StatusOK {
+		_, err := s.handleHTTPError([]int{http.StatusOK}, resp)
+		return 0, err
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	if err := expectDelim(dec, '{'); err != nil {
+		return 0, err
+	}
+	count := 0
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return count, err
+		}
+		if tok != key {
+			var skip json.RawMessage
+
+			if err := dec.Decode(&skip); err != nil {
+				return count, err
+			}
+			continue
+		}
+		if err := expectDelim(dec, '['); err != nil {
+			return count, err
+		}
+		for dec.More() {
+			var item T
+
+			if err := dec.Decode(&item); err != nil {
+				return count, err
+			}
+			if it.transform != nil {
+				it.transform(&item)
+			}
+			select {
+			case it.items <- item:
+				count++
+			case <-ctx.Done():
+				return count, ctx.Err()
+			}
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("unexpected %v in page, expected %v", tok, delim)
+	}
+	return nil
+}
+
+// SliceIterator returns an Iterator over items which fails with err after
+// them, if not nil. It stands for the iterators of the API in tests.
+func SliceIterator[T any](items []T, err error) *Iterator[T] {
+	_, cancel := context.WithCancelCause(context.Background())
+	it := &Iterator[T]{
+		items:  make(chan T, len(items)),
+		err:    err,
+		cancel: cancel,
+	}
+	for _, item := range items {
+		it.items <- item
+	}
+	close(it.items)
+	return it
+}
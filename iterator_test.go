@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+// snapshotsHandler serves n snapshots in pages, and fails page failPage
+func snapshotsHandler(n, failPage int, pages *int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(pages, 1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		if page == failPage {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"type": "invalid_request_error", "message": "invalid page"}`))
+			return
+		}
+		w.Header().Set("X-Total-Count", strconv.Itoa(n))
+		fmt.Fprint(w, `{"snapshots": [`)
+		for i := (page - 1) * perPage; i < page*perPage && i < n; i++ {
+			if i > (page-1)*perPage {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"id": "%d"}`, i)
+		}
+		fmt.Fprint(w, `], "meta": {}}`)
+	}
+}
+
+func TestIterator_All(t *testing.T) {
+	var pages int32
+	s := newTestAPI(t, snapshotsHandler(120, 0, &pages))
+
+	it := s.ListSnapshotsIter(context.Background())
+	count := 0
+	for it.Next() {
+		if id := it.Value().Identifier; id != strconv.Itoa(count) {
+			t.Errorf("expected snapshot %d, got %s", count, id)
+		}
+		count++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if count != 120 || pages != 3 {
+		t.Errorf("expected 120 snapshots in 3 pages, got %d in %d pages", count, pages)
+	}
+}
+
+func TestIterator_StopEarly(t *testing.T) {
+	var pages int32
+	s := newTestAPI(t, snapshotsHandler(1000, 0, &pages))
+
+	it := s.ListSnapshotsIter(context.Background())
+	for i := 0; i < 10 && it.Next(); i++ {
+	}
+	it.Close()
+	if err := it.Err(); err != nil {
+		t.Errorf("expected no error after Close, got %v", err)
+	}
+	if fetched := atomic.LoadInt32(&pages); fetched > prefetchPages+1 {
+		t.Errorf("expected at most %d pages to be fetched, got %d", prefetchPages+1, fetched)
+	}
+}
+
+func TestIterator_Error(t *testing.T) {
+	var pages int32
+	s := newTestAPI(t, snapshotsHandler(120, 2, &pages))
+
+	it := s.ListSnapshotsIter(context.Background())
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if _, ok := it.Err().(APIError); !ok {
+		t.Errorf("expected an APIError, got %v", it.Err())
+	}
+	if count != perPage {
+		t.Errorf("expected the first page to be returned, got %d snapshots", count)
+	}
+}
@@ -0,0 +1,231 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Labels are the key=value tags of a resource, a tag without "=" is a label
+// with an empty value
+type Labels map[string]string
+
+// ParseLabels returns the labels of tags, the last tag of a key wins
+func ParseLabels(tags []string) Labels {
+	labels := Labels{}
+	for _, tag := range tags {
+		key, value := splitLabel(tag)
+		labels[key] = value
+	}
+	return labels
+}
+
+// Tags returns the labels as tags, sorted
+func (l Labels) Tags() []string {
+	tags := make([]string, 0, len(l))
+	for key, value := range l {
+		if value == "" {
+			tags = append(tags, key)
+		} else {
+			tags = append(tags, key+"="+value)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// splitLabel returns the key and the value of a tag
+func splitLabel(tag string) (string, string) {
+	if i := strings.Index(tag, "="); i >= 0 {
+		return tag[:i], tag[i+1:]
+	}
+	return tag, ""
+}
+
+// Labeled is implemented by the resources with tags
+type Labeled interface {
+	Labels() Labels
+}
+
+// Labels returns the labels of the tags of the server
+func (s Server) Labels() Labels { return ParseLabels(s.Tags) }
+
+// Labels returns the labels of the tags of the volume
+func (v Volume) Labels() Labels { return ParseLabels(v.Tags) }
+
+// Labels returns the labels of the tags of the image
+func (i Image) Labels() Labels { return ParseLabels(i.Tags) }
+
+// FilterByLabels returns the items matching selector
+func FilterByLabels[T Labeled](items []T, selector Selector) []T {
+	ret := []T{}
+	for _, item := range items {
+		if selector.Matches(item.Labels()) {
+			ret = append(ret, item)
+		}
+	}
+	return ret
+}
+
+// Operators of the requirements of a Selector
+const (
+	selectEquals    = "="
+	selectNotEquals = "!="
+	selectIn        = "in"
+	selectNotIn     = "notin"
+	selectExists    = "exists"
+	selectNotExists = "!"
+)
+
+// Selector selects labels with Kubernetes-style requirements, i.e:
+// env=prod,role in (web,api),!legacy. The requirements are:
+//
+//	key=value, key==value  the label is set to value
+//	key!=value             the label isn't set to value, or is missing
+//	key in (a,b)           the label is set to one of the values
+//	key notin (a,b)        the label isn't set to one of the values, or is missing
+//	key                    the label is set
+//	!key                   the label is missing
+//
+// The zero Selector matches every label.
+type Selector struct {
+	requirements []requirement
+}
+
+type requirement struct {
+	key    string
+	op     string
+	values []string
+}
+
+var (
+	setRequirement = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\(([^()]*)\)$`)
+	validKey       = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`)
+)
+
+// ParseSelector parses a selector, i.e: env=prod,role in (web,api),!legacy
+func ParseSelector(selector string) (Selector, error) {
+	var ret Selector
+	for _, part := range splitRequirements(selector) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			if strings.TrimSpace(selector) == "" {
+				continue
+			}
+			return Selector{}, fmt.Errorf("invalid selector %q: empty requirement", selector)
+		}
+		r, err := parseRequirement(part)
+		if err != nil {
+			return Selector{}, fmt.Errorf("invalid selector %q: %v", selector, err)
+		}
+		ret.requirements = append(ret.requirements, r)
+	}
+	return ret, nil
+}
+
+// MustParseSelector is like ParseSelector but panics if the selector is invalid
+func MustParseSelector(selector string) Selector {
+	ret, err := ParseSelector(selector)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
+
+// splitRequirements splits a selector on the commas outside of parentheses
+func splitRequirements(selector string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range selector {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, selector[start:])
+}
+
+func parseRequirement(part string) (requirement, error) {
+	var r requirement
+	switch {
+	case setRequirement.MatchString(part):
+		match := setRequirement.FindStringSubmatch(part)
+		r = requirement{key: match[1], op: match[2]}
+		for _, value := range strings.Split(match[3], ",") {
+			r.values = append(r.values, strings.TrimSpace(value))
+		}
+	case strings.HasPrefix(part, "!") && !strings.Contains(part, "="):
+		r = requirement{key: strings.TrimSpace(part[1:]), op: selectNotExists}
+	case strings.Contains(part, "!="):
+		i := strings.Index(part, "!=")
+		r = requirement{key: strings.TrimSpace(part[:i]), op: selectNotEquals, values: []string{strings.TrimSpace(part[i+2:])}}
+	case strings.Contains(part, "="):
+		i := strings.Index(part, "=")
+		value := strings.TrimPrefix(part[i+1:], "=")
+		r = requirement{key: strings.TrimSpace(part[:i]), op: selectEquals, values: []string{strings.TrimSpace(value)}}
+	default:
+		r = requirement{key: part, op: selectExists}
+	}
+	if !validKey.MatchString(r.key) {
+		return requirement{}, fmt.Errorf("invalid key %q", r.key)
+	}
+	for _, value := range r.values {
+		if strings.ContainsAny(value, "=!(), ") {
+			return requirement{}, fmt.Errorf("invalid value %q", value)
+		}
+	}
+	return r, nil
+}
+
+// Matches returns true if labels meet every requirement of the selector
+func (s Selector) Matches(labels Labels) bool {
+	for _, r := range s.requirements {
+		value, ok := labels[r.key]
+		var matches bool
+		switch r.op {
+		case selectEquals, selectIn:
+			matches = ok && containsString(r.values, value)
+		case selectNotEquals, selectNotIn:
+			matches = !ok || !containsString(r.values, value)
+		case selectExists:
+			matches = ok
+		case selectNotExists:
+			matches = !ok
+		}
+		if !matches {
+			return false
+		}
+	}
+	return true
+}
+
+// Empty returns true if the selector matches every label
+func (s Selector) Empty() bool {
+	return len(s.requirements) == 0
+}
+
+// String returns the selector in its canonical form
+func (s Selector) String() string {
+	parts := make([]string, 0, len(s.requirements))
+	for _, r := range s.requirements {
+		switch r.op {
+		case selectEquals, selectNotEquals:
+			parts = append(parts, r.key+r.op+r.values[0])
+		case selectIn, selectNotIn:
+			parts = append(parts, fmt.Sprintf("%s %s (%s)", r.key, r.op, strings.Join(r.values, ",")))
+		case selectExists:
+			parts = append(parts, r.key)
+		case selectNotExists:
+			parts = append(parts, "!"+r.key)
+		}
+	}
+	return strings.Join(parts, ",")
+}
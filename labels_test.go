@@ -0,0 +1,70 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLabels(t *testing.T) {
+	labels := ParseLabels([]string{"env=prod", "legacy", "url=http://a?b=c", "env=staging"})
+	want := Labels{"env": "staging", "legacy": "", "url": "http://a?b=c"}
+	if !reflect.DeepEqual(labels, want) {
+		t.Errorf("unexpected labels: %v", labels)
+	}
+	if tags := labels.Tags(); !reflect.DeepEqual(tags, []string{"env=staging", "legacy", "url=http://a?b=c"}) {
+		t.Errorf("unexpected tags: %v", tags)
+	}
+}
+
+func TestSelector(t *testing.T) {
+	labels := Labels{"env": "prod", "role": "web", "legacy": ""}
+	for _, tt := range []struct {
+		selector  string
+		matches   bool
+		canonical string
+	}{
+		{"", true, ""},
+		{"env=prod", true, "env=prod"},
+		{"env == prod", true, "env=prod"},
+		{"env!=prod", false, "env!=prod"},
+		{"team!=ops", true, "team!=ops"},
+		{"env=prod,role in (web, api)", true, "env=prod,role in (web,api)"},
+		{"role notin (web,api)", false, "role notin (web,api)"},
+		{"team notin (ops)", true, "team notin (ops)"},
+		{"legacy", true, "legacy"},
+		{"legacy=", true, "legacy="},
+		{"!legacy", false, "!legacy"},
+		{"env=prod,role in (web,api),!legacy", false, "env=prod,role in (web,api),!legacy"},
+		{"env in (staging,prod),!team", true, "env in (staging,prod),!team"},
+	} {
+		selector, err := ParseSelector(tt.selector)
+		if err != nil {
+			t.Errorf("%q: %v", tt.selector, err)
+			continue
+		}
+		if selector.Matches(labels) != tt.matches {
+			t.Errorf("%q: expected matches to be %v", tt.selector, tt.matches)
+		}
+		if selector.String() != tt.canonical {
+			t.Errorf("%q: unexpected canonical form %q", tt.selector, selector.String())
+		}
+	}
+
+	for _, selector := range []string{"=prod", "env=prod,,role=web", "role in (web", "role in (a=b)", "!", "env prod"} {
+		if _, err := ParseSelector(selector); err == nil {
+			t.Errorf("%q: expected an error", selector)
+		}
+	}
+}
+
+func TestFilterByLabels(t *testing.T) {
+	selector := MustParseSelector("env=prod")
+	volumes := FilterByLabels([]Volume{{Name: "a", Tags: []string{"env=prod"}}, {Name: "b", Tags: []string{"env=dev"}}}, selector)
+	if len(volumes) != 1 || volumes[0].Name != "a" {
+		t.Errorf("unexpected volumes: %v", volumes)
+	}
+	images := FilterByLabels([]Image{{Name: "a"}, {Name: "b", Tags: []string{"env=prod"}}}, selector)
+	if len(images) != 1 || images[0].Name != "b" {
+		t.Errorf("unexpected images: %v", images)
+	}
+}
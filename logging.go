@@ -0,0 +1,96 @@
+package api
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+// SetLogger registers a logger recording each request sent to the API.
+// Headers and bodies are recorded as well when the logger is enabled for
+// slog.LevelDebug. The token is never recorded.
+func (s *API) SetLogger(logger *slog.Logger) {
+	s.logger = logger
+}
+
+// logging wraps next to log the requests it sends
+func (s *API) logging(next HTTPClient) HTTPClient {
+	return HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+		ctx := req.Context()
+		debug := s.logger.Enabled(ctx, slog.LevelDebug)
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("url", s.redact(req.URL.String())),
+			slog.Int64("request_size", req.ContentLength),
+		}
+		if debug {
+			attrs = append(attrs, slog.Any("request_headers", s.redactHeader(req.Header)))
+			if req.GetBody != nil {
+				if body, err := req.GetBody(); err == nil {
+					content, _ := ioutil.ReadAll(body)
+					body.Close()
+					attrs = append(attrs, slog.String("request_body", s.redact(string(content))))
+				}
+			}
+		}
+
+		start := time.Now()
+		resp, err := next.Do(req)
+		attrs = append(attrs, slog.Duration("latency", time.Since(start)))
+		if err != nil {
+			attrs = append(attrs, slog.String("error", s.redact(err.Error())))
+			s.logger.LogAttrs(ctx, slog.LevelError, "api request failed", attrs...)
+			return nil, err
+		}
+
+		attrs = append(attrs,
+			slog.Int("status", resp.StatusCode),
+			slog.String("request_id", resp.Header.Get("X-Request-Id")),
+		)
+		size := resp.ContentLength
+		if debug {
+			content, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewReader(content))
+			size = int64(len(content))
+			attrs = append(attrs,
+				slog.Any("response_headers", s.redactHeader(resp.Header)),
+				slog.String("response_body", s.redact(string(content))),
+			)
+		}
+		attrs = append(attrs, slog.Int64("response_size", size))
+		s.logger.LogAttrs(ctx, slog.LevelInfo, "api request", attrs...)
+		return resp, nil
+	})
+}
+
+// redact removes the token from value, i.e: from the URL of GetUserID
+func (s *API) redact(value string) string {
+	if s.Token == "" {
+		return value
+	}
+	return strings.Replace(value, s.Token, redacted, -1)
+}
+
+// redactHeader returns a copy of header without the token
+func (s *API) redactHeader(header http.Header) http.Header {
+	ret := make(http.Header, len(header))
+	for key, values := range header {
+		if key == "X-Auth-Token" {
+			ret[key] = []string{redacted}
+			continue
+		}
+		for _, value := range values {
+			ret.Add(key, s.redact(value))
+		}
+	}
+	return ret
+}
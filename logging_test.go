@@ -0,0 +1,71 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const testToken = "6e2bff6c-7b2f-4a5b-bd7f-48d3c8ce3a2e"
+
+func TestLogger_Redaction(t *testing.T) {
+	s := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "42")
+		w.Write([]byte(`{"token": {"id": "` + testToken + `", "user_id": "1"}}`))
+	}))
+	var logs bytes.Buffer
+	s.Token = testToken
+	s.SetLogger(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	// GetUserID embeds the token in the URL
+	s.accountAPI = s.computeAPI
+	if _, err := s.GetUserID(); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(logs.String(), testToken) {
+		t.Errorf("expected the token to be redacted, got %s", logs.String())
+	}
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected the HEAD and GET requests to be logged, got %d lines", len(lines))
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[1]), &entry); err != nil {
+		t.Fatal(err)
+	}
+	for key, expected := range map[string]interface{}{
+		"method":     "GET",
+		"url":        s.computeAPI + "/tokens/" + redacted,
+		"status":     float64(http.StatusOK),
+		"request_id": "42",
+	} {
+		if entry[key] != expected {
+			t.Errorf("expected %s to be %v, got %v", key, expected, entry[key])
+		}
+	}
+	if _, ok := entry["response_body"]; !ok {
+		t.Error("expected the response body to be logged in debug mode")
+	}
+}
+
+func TestLogger_Info(t *testing.T) {
+	s := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	var logs bytes.Buffer
+	s.SetLogger(slog.New(slog.NewJSONHandler(&logs, nil)))
+
+	if err := s.DeleteServer("1"); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(logs.String(), "response_body") || strings.Contains(logs.String(), "X-Auth-Token") {
+		t.Errorf("expected bodies and headers to be logged in debug mode only, got %s", logs.String())
+	}
+	if !strings.Contains(logs.String(), `"status":204`) {
+		t.Errorf("expected the status to be logged, got %s", logs.String())
+	}
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Metrics receives measurements about the calls to the API
+type Metrics interface {
+	// ObserveOperation is called when a method of the API (i.e: GetServer) returns
+	ObserveOperation(operation string, duration time.Duration, err error)
+
+	// ObserveRequest is called for each HTTP request, statusCode is 0 when no response was received
+	ObserveRequest(method string, family APIFamily, statusCode int, duration time.Duration)
+
+	// ObservePages is called when GetResponsePaginate fetched a resource in pages
+	ObservePages(operation string, pages int)
+}
+
+// SetMetrics registers the collector of the metrics
+func (s *API) SetMetrics(metrics Metrics) {
+	s.metrics = metrics
+}
+
+// ErrorLabels returns the type and the status code of err, to break error counts down
+func ErrorLabels(err error) (string, int) {
+	var apiErr APIError
+
+	switch {
+	case errors.As(err, &apiErr):
+		return apiErr.Type, apiErr.StatusCode
+	case errors.Is(err, context.Canceled):
+		return "canceled", 0
+	case errors.Is(err, context.DeadlineExceeded):
+		return "deadline_exceeded", 0
+	}
+	return "unknown", 0
+}
+
+// observePages reports the number of pages fetched by the operation running in ctx
+func (s *API) observePages(ctx context.Context, pages int) {
+	if s.metrics == nil {
+		return
+	}
+	name := operationName(ctx)
+	if name == "" {
+		name = "GetResponsePaginate"
+	}
+	s.metrics.ObservePages(name, pages)
+}
+
+// metering wraps next to measure the requests it sends
+func (s *API) metering(next HTTPClient) HTTPClient {
+	return HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next.Do(req)
+		statusCode := 0
+		if err == nil {
+			statusCode = resp.StatusCode
+		}
+		s.metrics.ObserveRequest(req.Method, s.apiFamily(req.URL.String()), statusCode, time.Since(start))
+		return resp, err
+	})
+}
+
+// OperationStats represents the measurements of an operation
+type OperationStats struct {
+	Calls     int
+	Errors    int
+	Latencies []time.Duration
+}
+
+// ErrorKey identifies a kind of error returned by an operation
+type ErrorKey struct {
+	Operation  string
+	Type       string
+	StatusCode int
+}
+
+// RequestKey identifies a kind of HTTP request
+type RequestKey struct {
+	Method     string
+	Family     APIFamily
+	StatusCode int
+}
+
+// MemoryMetrics is a Metrics keeping every measurement in memory, i.e: for tests
+type MemoryMetrics struct {
+	mu         sync.Mutex
+	operations map[string]*OperationStats
+	errors     map[ErrorKey]int
+	requests   map[RequestKey]int
+	pages      map[string]int
+}
+
+// NewMemoryMetrics returns an empty MemoryMetrics
+func NewMemoryMetrics() *MemoryMetrics {
+	return &MemoryMetrics{
+		operations: make(map[string]*OperationStats),
+		errors:     make(map[ErrorKey]int),
+		requests:   make(map[RequestKey]int),
+		pages:      make(map[string]int),
+	}
+}
+
+// ObserveOperation implements Metrics
+func (m *MemoryMetrics) ObserveOperation(operation string, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, ok := m.operations[operation]
+	if !ok {
+		stats = &OperationStats{}
+		m.operations[operation] = stats
+	}
+	stats.Calls++
+	stats.Latencies = append(stats.Latencies, duration)
+	if err != nil {
+		stats.Errors++
+		errorType, statusCode := ErrorLabels(err)
+		m.errors[ErrorKey{operation, errorType, statusCode}]++
+	}
+}
+
+// ObserveRequest implements Metrics
+func (m *MemoryMetrics) ObserveRequest(method string, family APIFamily, statusCode int, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[RequestKey{method, family, statusCode}]++
+}
+
+// ObservePages implements Metrics
+func (m *MemoryMetrics) ObservePages(operation string, pages int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.pages[operation] += pages
+}
+
+// Operation returns the measurements of an operation
+func (m *MemoryMetrics) Operation(operation string) OperationStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, ok := m.operations[operation]
+	if !ok {
+		return OperationStats{}
+	}
+	return OperationStats{
+		Calls:     stats.Calls,
+		Errors:    stats.Errors,
+		Latencies: append([]time.Duration(nil), stats.Latencies...),
+	}
+}
+
+// Errors returns the number of errors of each kind
+func (m *MemoryMetrics) Errors() map[ErrorKey]int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ret := make(map[ErrorKey]int, len(m.errors))
+	for key, count := range m.errors {
+		ret[key] = count
+	}
+	return ret
+}
+
+// Requests returns the number of HTTP requests of each kind
+func (m *MemoryMetrics) Requests() map[RequestKey]int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ret := make(map[RequestKey]int, len(m.requests))
+	for key, count := range m.requests {
+		ret[key] = count
+	}
+	return ret
+}
+
+// Pages returns the number of pages fetched by an operation
+func (m *MemoryMetrics) Pages(operation string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.pages[operation]
+}
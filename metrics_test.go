@@ -0,0 +1,84 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// paginatedHandler serves n servers, 50 per page, and 404 for a single server
+func paginatedHandler(n int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/servers" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"type": "unknown_resource", "message": "not found"}`))
+			return
+		}
+		w.Header().Set("X-Total-Count", fmt.Sprintf("%d", n))
+		fmt.Fprint(w, `{"servers": [{"id": "1"}]}`)
+	}
+}
+
+func TestMemoryMetrics(t *testing.T) {
+	s := newTestAPI(t, paginatedHandler(120))
+	metrics := NewMemoryMetrics()
+	s.SetMetrics(metrics)
+
+	if _, err := s.GetResponsePaginate(s.computeAPI, "servers", nil); err != nil {
+		t.Fatal(err)
+	}
+	if pages := metrics.Pages("GetResponsePaginate"); pages != 3 {
+		t.Errorf("expected 3 pages, got %d", pages)
+	}
+	if _, err := s.GetServer("1"); err == nil {
+		t.Fatal("expected GetServer to fail")
+	}
+	stats := metrics.Operation("GetServer")
+	if stats.Calls != 1 || stats.Errors != 1 || len(stats.Latencies) != 1 {
+		t.Errorf("expected 1 failed call, got %+v", stats)
+	}
+	if count := metrics.Errors()[ErrorKey{"GetServer", "unknown_resource", http.StatusNotFound}]; count != 1 {
+		t.Errorf("expected 1 unknown_resource error, got %d", count)
+	}
+	requests := metrics.Requests()
+	if count := requests[RequestKey{"GET", FamilyCompute, http.StatusOK}]; count != 3 {
+		t.Errorf("expected 3 successful GET requests, got %d", count)
+	}
+	if count := requests[RequestKey{"HEAD", FamilyCompute, http.StatusNotFound}]; count != 1 {
+		t.Errorf("expected 1 failed HEAD request, got %d", count)
+	}
+}
+
+func TestPrometheusMetrics(t *testing.T) {
+	metrics := NewPrometheusMetrics([]float64{0.1, 1})
+	metrics.ObserveOperation("GetServer", 50*time.Millisecond, nil)
+	metrics.ObserveOperation("GetServer", 2*time.Second, APIError{Type: "unknown_resource", StatusCode: 404})
+	metrics.ObserveRequest("GET", FamilyCompute, 200, 500*time.Millisecond)
+	metrics.ObservePages("GetServers", 3)
+
+	var b bytes.Buffer
+	if _, err := metrics.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		"# TYPE scaleway_sdk_operations_total counter",
+		`scaleway_sdk_operations_total{operation="GetServer"} 2`,
+		`scaleway_sdk_operation_errors_total{operation="GetServer",type="unknown_resource",status_code="404"} 1`,
+		"# TYPE scaleway_sdk_operation_duration_seconds histogram",
+		`scaleway_sdk_operation_duration_seconds_bucket{operation="GetServer",le="0.1"} 1`,
+		`scaleway_sdk_operation_duration_seconds_bucket{operation="GetServer",le="1"} 1`,
+		`scaleway_sdk_operation_duration_seconds_bucket{operation="GetServer",le="+Inf"} 2`,
+		`scaleway_sdk_operation_duration_seconds_sum{operation="GetServer"} 2.05`,
+		`scaleway_sdk_operation_duration_seconds_count{operation="GetServer"} 2`,
+		`scaleway_sdk_requests_total{method="GET",family="compute",status_code="200"} 1`,
+		`scaleway_sdk_request_duration_seconds_bucket{family="compute",le="1"} 1`,
+		`scaleway_sdk_pages_total{operation="GetServers"} 3`,
+	} {
+		if !strings.Contains(b.String(), line+"\n") {
+			t.Errorf("expected line %q in:\n%s", line, b.String())
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http"
+)
+
+// HTTPClientFunc is an adapter to use an ordinary function as HTTPClient
+type HTTPClientFunc func(*http.Request) (*http.Response, error)
+
+// Do calls f(req)
+func (f HTTPClientFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the HTTPClient sending the requests of the API.
+// It is called for each attempt, after the request has been built and
+// before the response is handled.
+type Middleware func(next HTTPClient) HTTPClient
+
+// RequestMutator returns a Middleware calling mutate before sending each
+// request, the request isn't sent if mutate returns an error
+func RequestMutator(mutate func(*http.Request) error) Middleware {
+	return func(next HTTPClient) HTTPClient {
+		return HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			if err := mutate(req); err != nil {
+				return nil, err
+			}
+			return next.Do(req)
+		})
+	}
+}
+
+// ResponseObserver returns a Middleware calling observe with the outcome of each request
+func ResponseObserver(observe func(*http.Request, *http.Response, error)) Middleware {
+	return func(next HTTPClient) HTTPClient {
+		return HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.Do(req)
+			observe(req, resp, err)
+			return resp, err
+		})
+	}
+}
+
+// Use appends middlewares to the chain, the first one registered is the outermost
+func (s *API) Use(middlewares ...Middleware) {
+	s.middlewares = append(s.middlewares, middlewares...)
+}
+
+// transport returns the client wrapped by the middlewares
+func (s *API) transport() HTTPClient {
+	client := s.client
+	if s.logger != nil {
+		// innermost, to record the requests as altered by the middlewares
+		client = s.logging(client)
+	}
+	if s.metrics != nil {
+		client = s.metering(client)
+	}
+	for i := len(s.middlewares) - 1; i >= 0; i-- {
+		client = s.middlewares[i](client)
+	}
+	// outermost, so that the middlewares can propagate the span of the request
+	return s.tracing(client)
+}
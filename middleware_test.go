@@ -0,0 +1,82 @@
+package api
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMiddleware_Chain(t *testing.T) {
+	var (
+		order    []string
+		observed []int
+	)
+	client := HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+		order = append(order, "client")
+		if got := req.Header.Get("X-Trace"); got != "outer,inner" {
+			t.Errorf("expected mutations to be applied in order, got %q", got)
+		}
+		if got := req.Header.Get("User-Agent"); got != "test-agent" {
+			t.Errorf("expected User-Agent test-agent, got %q", got)
+		}
+		return &http.Response{
+			StatusCode: http.StatusNoContent,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil
+	})
+	mutator := func(name string) Middleware {
+		return RequestMutator(func(req *http.Request) error {
+			order = append(order, name)
+			if trace := req.Header.Get("X-Trace"); trace != "" {
+				name = trace + "," + name
+			}
+			req.Header.Set("X-Trace", name)
+			return nil
+		})
+	}
+	s, err := New("organization", "token", "par1",
+		WithHTTPClient(client),
+		WithUserAgent("test-agent"),
+		WithMiddleware(mutator("outer"), mutator("inner")),
+		WithMiddleware(ResponseObserver(func(req *http.Request, resp *http.Response, err error) {
+			observed = append(observed, resp.StatusCode)
+		})),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.DeleteServer("1"); err != nil {
+		t.Fatalf("expected DeleteServer to succeed, got %v", err)
+	}
+	if got := strings.Join(order, ","); got != "outer,inner,client" {
+		t.Errorf("expected outer,inner,client, got %s", got)
+	}
+	if len(observed) != 1 || observed[0] != http.StatusNoContent {
+		t.Errorf("expected the response to be observed once, got %v", observed)
+	}
+}
+
+func TestMiddleware_RequestMutatorError(t *testing.T) {
+	errRefused := errors.New("refused")
+	s, err := New("organization", "token", "par1",
+		WithHTTPClient(HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			t.Error("expected the request not to be sent")
+			return nil, errRefused
+		})),
+		WithRetryPolicy(NoRetry),
+		WithMiddleware(RequestMutator(func(req *http.Request) error {
+			return errRefused
+		})),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.DeleteServer("1"); err != errRefused {
+		t.Errorf("expected %v, got %v", errRefused, err)
+	}
+}
@@ -0,0 +1,252 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// MultiRegionAPI queries several regions at once, with one client per region
+type MultiRegionAPI struct {
+	clients []*API
+}
+
+// NewMultiRegion creates a client for each of the regions, every registered
+// region if none is given; the options are applied to all the clients. The
+// endpoints of SCW_COMPUTE_API and SCW_AVAILABILITY_API only replace those of
+// DefaultRegion, the other regions would query the same endpoint otherwise.
+func NewMultiRegion(organization, token string, regions []string, options ...func(*API)) (*MultiRegionAPI, error) {
+	if len(regions) == 0 {
+		for _, region := range Regions() {
+			regions = append(regions, region.Name)
+		}
+	}
+	m := &MultiRegionAPI{}
+	for _, region := range regions {
+		client, err := newAPI(organization, token, region, region == DefaultRegion, options...)
+		if err != nil {
+			return nil, err
+		}
+		m.clients = append(m.clients, client)
+	}
+	return m, nil
+}
+
+// NewMultiRegionFromClients groups existing clients, one per region
+func NewMultiRegionFromClients(clients ...*API) (*MultiRegionAPI, error) {
+	seen := map[string]bool{}
+	for _, client := range clients {
+		if seen[client.Region] {
+			return nil, fmt.Errorf("several clients for region %s", client.Region)
+		}
+		seen[client.Region] = true
+	}
+	return &MultiRegionAPI{clients: clients}, nil
+}
+
+// Clients returns the client of each region
+func (m *MultiRegionAPI) Clients() []*API {
+	return append([]*API(nil), m.clients...)
+}
+
+// Client returns the client of a region, nil if there is none
+func (m *MultiRegionAPI) Client(region string) *API {
+	for _, client := range m.clients {
+		if client.Region == region {
+			return client
+		}
+	}
+	return nil
+}
+
+// Regional is a resource tagged with the region it comes from, the Region of
+// a global resource is empty
+type Regional[T any] struct {
+	Region string
+	Value  T
+}
+
+// RegionError is the error of a region
+type RegionError struct {
+	Region string
+	Err    error
+}
+
+func (e *RegionError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Region, e.Err)
+}
+
+func (e *RegionError) Unwrap() error {
+	return e.Err
+}
+
+// PartialError is returned when some regions failed, the results of the
+// other regions are returned along with it
+type PartialError struct {
+	// Errors holds the error of each failed region
+	Errors []*RegionError
+
+	// Regions is the number of regions queried
+	Regions int
+}
+
+func (e *PartialError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("%d of %d regions failed: %s", len(e.Errors), e.Regions, strings.Join(messages, "; "))
+}
+
+// Unwrap allows errors.Is and errors.As to match the error of any region
+func (e *PartialError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// Failed returns the regions which failed
+func (e *PartialError) Failed() []string {
+	regions := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		regions = append(regions, err.Region)
+	}
+	return regions
+}
+
+// IsPartial reports whether err is a PartialError with some regions succeeding
+func IsPartial(err error) bool {
+	var partial *PartialError
+	return errors.As(err, &partial) && len(partial.Errors) < partial.Regions
+}
+
+// collect lists the resources of every region concurrently, a failing region
+// doesn't interrupt the others
+func collect[T any](ctx context.Context, m *MultiRegionAPI, list func(context.Context, *API) ([]T, error)) ([]Regional[T], error) {
+	var (
+		wg      sync.WaitGroup
+		results = make([][]T, len(m.clients))
+		errs    = make([]error, len(m.clients))
+	)
+	for i, client := range m.clients {
+		wg.Add(1)
+		go func(i int, client *API) {
+			defer wg.Done()
+			results[i], errs[i] = list(ctx, client)
+		}(i, client)
+	}
+	wg.Wait()
+
+	var (
+		tagged  []Regional[T]
+		partial = &PartialError{Regions: len(m.clients)}
+	)
+	for i, client := range m.clients {
+		if errs[i] != nil {
+			partial.Errors = append(partial.Errors, &RegionError{Region: client.Region, Err: errs[i]})
+			continue
+		}
+		for _, value := range results[i] {
+			tagged = append(tagged, Regional[T]{Region: client.Region, Value: value})
+		}
+	}
+	if len(partial.Errors) > 0 {
+		return tagged, partial
+	}
+	return tagged, nil
+}
+
+// GetServers gets the servers of every region, only the running ones unless
+// all is true
+func (m *MultiRegionAPI) GetServers(all bool) ([]Regional[Server], error) {
+	return m.GetServersContext(context.Background(), all)
+}
+
+// GetServersContext is like GetServers but uses ctx for the underlying requests
+func (m *MultiRegionAPI) GetServersContext(ctx context.Context, all bool) ([]Regional[Server], error) {
+	opts := ListServersOptions{}
+	if !all {
+		opts.States = []string{"running"}
+	}
+	return collect(ctx, m, func(ctx context.Context, s *API) ([]Server, error) {
+		return s.ListServersContext(ctx, opts)
+	})
+}
+
+// GetVolumes gets the volumes of every region
+func (m *MultiRegionAPI) GetVolumes() ([]Regional[Volume], error) {
+	return m.GetVolumesContext(context.Background())
+}
+
+// GetVolumesContext is like GetVolumes but uses ctx for the underlying requests
+func (m *MultiRegionAPI) GetVolumesContext(ctx context.Context) ([]Regional[Volume], error) {
+	return collect(ctx, m, func(ctx context.Context, s *API) ([]Volume, error) {
+		volumes, err := s.GetVolumesContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return *volumes, nil
+	})
+}
+
+// GetSnapshots gets the snapshots of every region
+func (m *MultiRegionAPI) GetSnapshots() ([]Regional[Snapshot], error) {
+	return m.GetSnapshotsContext(context.Background())
+}
+
+// GetSnapshotsContext is like GetSnapshots but uses ctx for the underlying requests
+func (m *MultiRegionAPI) GetSnapshotsContext(ctx context.Context) ([]Regional[Snapshot], error) {
+	return collect(ctx, m, func(ctx context.Context, s *API) ([]Snapshot, error) {
+		snapshots, err := s.GetSnapshotsContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return *snapshots, nil
+	})
+}
+
+// GetIPS gets the IPs of every region
+func (m *MultiRegionAPI) GetIPS() ([]Regional[IPV4], error) {
+	return m.GetIPSContext(context.Background())
+}
+
+// GetIPSContext is like GetIPS but uses ctx for the underlying requests
+func (m *MultiRegionAPI) GetIPSContext(ctx context.Context) ([]Regional[IPV4], error) {
+	return collect(ctx, m, func(ctx context.Context, s *API) ([]IPV4, error) {
+		ips, err := s.GetIPSContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return ips.IPS, nil
+	})
+}
+
+// GetImages gets the images of the marketplace and of the organization in
+// every region. The marketplace is global: its images are fetched once and
+// returned with an empty Region.
+func (m *MultiRegionAPI) GetImages() ([]Regional[MarketImage], error) {
+	return m.GetImagesContext(context.Background())
+}
+
+// GetImagesContext is like GetImages but uses ctx for the underlying requests
+func (m *MultiRegionAPI) GetImagesContext(ctx context.Context) ([]Regional[MarketImage], error) {
+	if len(m.clients) == 0 {
+		return nil, nil
+	}
+	market, err := m.clients[0].marketImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	images := make([]Regional[MarketImage], 0, len(market))
+	for _, image := range market {
+		images = append(images, Regional[MarketImage]{Value: image})
+	}
+	regional, err := collect(ctx, m, func(ctx context.Context, s *API) ([]MarketImage, error) {
+		return s.organizationImages(ctx)
+	})
+	return append(images, regional...), err
+}
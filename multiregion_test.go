@@ -0,0 +1,208 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMultiRegionAPI_partialFailure(t *testing.T) {
+	par1 := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"servers": [{"id": "1"}, {"id": "2"}]}`))
+	}))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"type": "unknown_resource", "message": "not found"}`))
+	}))
+	defer srv.Close()
+	ams1, err := New("organization", "token", "ams1", WithRetryPolicy(NoRetry))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ams1.computeAPI = srv.URL
+
+	m, err := NewMultiRegionFromClients(par1, ams1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	servers, err := m.GetServers(true)
+
+	var partial *PartialError
+	if !errors.As(err, &partial) || !IsPartial(err) {
+		t.Fatalf("expected a partial error, got %v", err)
+	}
+	if failed := partial.Failed(); len(failed) != 1 || failed[0] != "ams1" {
+		t.Errorf("expected ams1 to fail, got %v", failed)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected the region error to be wrapped, got %v", err)
+	}
+	if len(servers) != 2 {
+		t.Fatalf("expected the 2 servers of par1, got %d", len(servers))
+	}
+	for _, server := range servers {
+		if server.Region != "par1" {
+			t.Errorf("expected server %s in par1, got %s", server.Value.Identifier, server.Region)
+		}
+		if server.Value.DNSPublic == "" {
+			t.Errorf("expected DNS names for server %s", server.Value.Identifier)
+		}
+	}
+}
+
+func TestNewMultiRegionFromClients_duplicate(t *testing.T) {
+	s, err := New("organization", "token", "par1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = NewMultiRegionFromClients(s, s); err == nil {
+		t.Error("expected an error for duplicate regions")
+	}
+}
+
+// serversHandler returns the servers ids
+func serversHandler(ids ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		servers := make([]string, 0, len(ids))
+		for _, id := range ids {
+			servers = append(servers, fmt.Sprintf(`{"id": %q}`, id))
+		}
+		fmt.Fprintf(w, `{"servers": [%s]}`, strings.Join(servers, ","))
+	}
+}
+
+// setRegions replaces the region registry for the duration of the test
+func setRegions(t *testing.T, list ...Region) {
+	regionsMu.Lock()
+	saved := regions
+	regions = map[string]Region{}
+	for _, region := range list {
+		regions[region.Name] = region
+	}
+	regionsMu.Unlock()
+	t.Cleanup(func() {
+		regionsMu.Lock()
+		regions = saved
+		regionsMu.Unlock()
+	})
+}
+
+func TestNewMultiRegion_environment(t *testing.T) {
+	env := httptest.NewServer(serversHandler("1", "2"))
+	defer env.Close()
+	test2 := httptest.NewServer(serversHandler("3"))
+	defer test2.Close()
+	setRegions(t,
+		Region{Name: DefaultRegion, ComputeAPI: "http://127.0.0.1:1/"},
+		Region{Name: "test2", ComputeAPI: test2.URL},
+	)
+	t.Setenv("SCW_COMPUTE_API", env.URL)
+
+	m, err := NewMultiRegion("organization", "token", nil, WithRetryPolicy(NoRetry))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if api := m.Client("test2").computeAPI; api != test2.URL {
+		t.Errorf("SCW_COMPUTE_API should only replace the endpoint of %s, test2 uses %s", DefaultRegion, api)
+	}
+	servers, err := m.GetServers(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, server := range servers {
+		if region, ok := got[server.Value.Identifier]; ok {
+			t.Errorf("server %s listed in %s and %s", server.Value.Identifier, region, server.Region)
+		}
+		got[server.Value.Identifier] = server.Region
+	}
+	expected := map[string]string{"1": DefaultRegion, "2": DefaultRegion, "3": "test2"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestGetServers_allRegions(t *testing.T) {
+	par1 := httptest.NewServer(serversHandler("1"))
+	defer par1.Close()
+	ams1 := httptest.NewServer(serversHandler("2"))
+	defer ams1.Close()
+	setRegions(t,
+		Region{Name: "par1", ComputeAPI: par1.URL},
+		Region{Name: "ams1", ComputeAPI: ams1.URL},
+	)
+
+	s, err := New("organization", "token", "par1", WithRetryPolicy(NoRetry))
+	if err != nil {
+		t.Fatal(err)
+	}
+	servers, err := s.GetServers(true, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*servers) != 2 {
+		t.Errorf("expected the servers of par1 and ams1, got %v", *servers)
+	}
+
+	// an overridden endpoint isn't regional, the other regions aren't queried
+	s.computeAPI = par1.URL + "/"
+	if servers, err = s.GetServers(true, 0); err != nil {
+		t.Fatal(err)
+	}
+	if len(*servers) != 1 || (*servers)[0].Identifier != "1" {
+		t.Errorf("expected the server of the endpoint only, got %v", *servers)
+	}
+}
+
+func TestMultiRegionAPI_images(t *testing.T) {
+	marketplace := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"images": [{"id": "market", "name": "Ubuntu"}]}`))
+	}))
+	defer marketplace.Close()
+
+	var clients []*API
+	for _, region := range []string{"par1", "ams1"} {
+		region := region
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"images": [{"id": "image-%s"}]}`, region)
+		}))
+		defer srv.Close()
+		client, err := New("organization", "token", region, WithRetryPolicy(NoRetry), WithEndpoints(Endpoints{
+			Compute:     srv.URL,
+			Marketplace: marketplace.URL,
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		clients = append(clients, client)
+	}
+	m, err := NewMultiRegionFromClients(clients...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	images, err := m.GetImages()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, image := range images {
+		// the images of the organization are identified by their version
+		id := image.Value.ID
+		if id == "" {
+			id = image.Value.CurrentPublicVersion
+		}
+		if _, ok := got[id]; ok {
+			t.Errorf("image %s listed twice", id)
+		}
+		got[id] = image.Region
+	}
+	expected := map[string]string{"market": "", "image-par1": "par1", "image-ams1": "ams1"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type operationKey struct{}
+
+// operation represents a call to a method of the API, i.e: GetServer
+type operation struct {
+	api   *API
+	name  string
+	span  trace.Span
+	start time.Time
+}
+
+// startOperation starts an operation on a resource, resourceID may be empty
+func (s *API) startOperation(ctx context.Context, name, resourceType, resourceID string) (context.Context, *operation) {
+	attrs := []attribute.KeyValue{
+		attribute.String("scaleway.region", s.Region),
+		attribute.String("scaleway.resource.type", resourceType),
+	}
+	if resourceID != "" {
+		attrs = append(attrs, attribute.String("scaleway.resource.id", resourceID))
+	}
+	ctx, span := s.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+	op := &operation{
+		api:   s,
+		name:  name,
+		span:  span,
+		start: time.Now(),
+	}
+	return context.WithValue(ctx, operationKey{}, op), op
+}
+
+// operationName returns the name of the operation running in ctx
+func operationName(ctx context.Context) string {
+	if op, ok := ctx.Value(operationKey{}).(*operation); ok {
+		return op.name
+	}
+	return ""
+}
+
+// End ends the operation with the error it returns, it is meant to be deferred
+func (o *operation) End(errp *error) {
+	err := *errp
+	if err != nil {
+		msg := o.api.redact(err.Error())
+		o.span.RecordError(errors.New(msg))
+		o.span.SetStatus(codes.Error, msg)
+	}
+	o.span.End()
+	if o.api.metrics != nil {
+		o.api.metrics.ObserveOperation(o.name, time.Since(o.start), err)
+	}
+}
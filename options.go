@@ -0,0 +1,79 @@
+package api
+
+import (
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithHTTPClient sets the HTTP client used to send requests
+func WithHTTPClient(client HTTPClient) func(*API) {
+	return func(s *API) {
+		s.client = client
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the requests
+func WithUserAgent(userAgent string) func(*API) {
+	return func(s *API) {
+		s.userAgent = userAgent
+	}
+}
+
+// WithMiddleware appends middlewares to the chain wrapping the HTTP client
+func WithMiddleware(middlewares ...Middleware) func(*API) {
+	return func(s *API) {
+		s.Use(middlewares...)
+	}
+}
+
+// WithRetryPolicy sets the retry policy
+func WithRetryPolicy(policy RetryPolicy) func(*API) {
+	return func(s *API) {
+		s.SetRetryPolicy(policy)
+	}
+}
+
+// WithRateLimiter sets the rate limiter, to share it between several clients
+func WithRateLimiter(limiter *RateLimiter) func(*API) {
+	return func(s *API) {
+		s.SetRateLimiter(limiter)
+	}
+}
+
+// WithLogger sets the logger recording the requests, see SetLogger
+func WithLogger(logger *slog.Logger) func(*API) {
+	return func(s *API) {
+		s.SetLogger(logger)
+	}
+}
+
+// WithTracerProvider sets the provider of the tracer, see SetTracerProvider
+func WithTracerProvider(provider trace.TracerProvider) func(*API) {
+	return func(s *API) {
+		s.SetTracerProvider(provider)
+	}
+}
+
+// WithMetrics sets the collector of the metrics
+func WithMetrics(metrics Metrics) func(*API) {
+	return func(s *API) {
+		s.SetMetrics(metrics)
+	}
+}
+
+// WithEndpoints overrides the URLs of the APIs, empty ones are left unchanged
+func WithEndpoints(endpoints Endpoints) func(*API) {
+	return func(s *API) {
+		override := func(dst *string, src string) {
+			if src != "" {
+				*dst = src
+			}
+		}
+		override(&s.computeAPI, endpoints.Compute)
+		override(&s.availabilityAPI, endpoints.Availability)
+		override(&s.accountAPI, endpoints.Account)
+		override(&s.marketplaceAPI, endpoints.Marketplace)
+		override(&s.metadataAPI, endpoints.Metadata)
+	}
+}
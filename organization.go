@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/url"
@@ -20,7 +21,15 @@ type OrganizationsDefinition struct {
 
 // GetOrganization returns Organization
 func (s *API) GetOrganization() (*OrganizationsDefinition, error) {
-	resp, err := s.GetResponsePaginate(AccountAPI, "organizations", url.Values{})
+	return s.GetOrganizationContext(context.Background())
+}
+
+// GetOrganizationContext is like GetOrganization but uses ctx for the underlying requests
+func (s *API) GetOrganizationContext(ctx context.Context) (_ *OrganizationsDefinition, err error) {
+	ctx, op := s.startOperation(ctx, "GetOrganization", "organization", s.Organization)
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.accountAPI, "organizations", url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -20,7 +21,15 @@ type PermissionDefinition struct {
 
 // GetPermissions returns the permissions
 func (s *API) GetPermissions() (*PermissionDefinition, error) {
-	resp, err := s.GetResponsePaginate(AccountAPI, fmt.Sprintf("tokens/%s/permissions", s.Token), url.Values{})
+	return s.GetPermissionsContext(context.Background())
+}
+
+// GetPermissionsContext is like GetPermissions but uses ctx for the underlying requests
+func (s *API) GetPermissionsContext(ctx context.Context) (_ *PermissionDefinition, err error) {
+	ctx, op := s.startOperation(ctx, "GetPermissions", "permissions", "")
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.accountAPI, fmt.Sprintf("tokens/%s/permissions", s.Token), url.Values{})
 	if err != nil {
 		return nil, err
 	}
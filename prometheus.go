@@ -0,0 +1,220 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are the upper bounds of the latency histograms, in seconds
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// PrometheusMetrics is a Metrics aggregating the measurements into counters
+// and histograms, exposed in the Prometheus text format
+type PrometheusMetrics struct {
+	mu               sync.Mutex
+	buckets          []float64
+	operations       map[string]*histogram
+	errors           map[ErrorKey]uint64
+	requests         map[RequestKey]uint64
+	requestDurations map[APIFamily]*histogram
+	pages            map[string]uint64
+}
+
+type histogram struct {
+	// counts holds the number of observations of each bucket, not cumulated
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func (h *histogram) observe(buckets []float64, value float64) {
+	if h.counts == nil {
+		h.counts = make([]uint64, len(buckets))
+	}
+	if i := sort.SearchFloat64s(buckets, value); i < len(buckets) {
+		h.counts[i]++
+	}
+	h.count++
+	h.sum += value
+}
+
+// NewPrometheusMetrics returns an empty PrometheusMetrics, using DefaultBuckets if buckets is nil
+func NewPrometheusMetrics(buckets []float64) *PrometheusMetrics {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &PrometheusMetrics{
+		buckets:          buckets,
+		operations:       make(map[string]*histogram),
+		errors:           make(map[ErrorKey]uint64),
+		requests:         make(map[RequestKey]uint64),
+		requestDurations: make(map[APIFamily]*histogram),
+		pages:            make(map[string]uint64),
+	}
+}
+
+// ObserveOperation implements Metrics
+func (m *PrometheusMetrics) ObserveOperation(operation string, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	h, ok := m.operations[operation]
+	if !ok {
+		h = &histogram{}
+		m.operations[operation] = h
+	}
+	h.observe(m.buckets, duration.Seconds())
+	if err != nil {
+		errorType, statusCode := ErrorLabels(err)
+		m.errors[ErrorKey{operation, errorType, statusCode}]++
+	}
+}
+
+// ObserveRequest implements Metrics
+func (m *PrometheusMetrics) ObserveRequest(method string, family APIFamily, statusCode int, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[RequestKey{method, family, statusCode}]++
+	h, ok := m.requestDurations[family]
+	if !ok {
+		h = &histogram{}
+		m.requestDurations[family] = h
+	}
+	h.observe(m.buckets, duration.Seconds())
+}
+
+// ObservePages implements Metrics
+func (m *PrometheusMetrics) ObservePages(operation string, pages int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.pages[operation] += uint64(pages)
+}
+
+// WriteTo writes the metrics to w in the Prometheus text exposition format
+func (m *PrometheusMetrics) WriteTo(w io.Writer) (int64, error) {
+	var b bytes.Buffer
+
+	m.mu.Lock()
+	operations := make(map[string]*histogram, len(m.operations))
+	counts := make(map[string]uint64, len(m.operations))
+	for operation, h := range m.operations {
+		operations[labels("operation", operation)] = h
+		counts[labels("operation", operation)] = h.count
+	}
+	errs := make(map[string]uint64, len(m.errors))
+	for key, count := range m.errors {
+		errs[labels("operation", key.Operation, "type", key.Type, "status_code", statusLabel(key.StatusCode))] = count
+	}
+	requests := make(map[string]uint64, len(m.requests))
+	for key, count := range m.requests {
+		requests[labels("method", key.Method, "family", string(key.Family), "status_code", statusLabel(key.StatusCode))] = count
+	}
+	requestDurations := make(map[string]*histogram, len(m.requestDurations))
+	for family, h := range m.requestDurations {
+		requestDurations[labels("family", string(family))] = h
+	}
+	pages := make(map[string]uint64, len(m.pages))
+	for operation, count := range m.pages {
+		pages[labels("operation", operation)] = count
+	}
+
+	writeCounter(&b, "scaleway_sdk_operations_total", "Number of calls to the methods of the API.", counts)
+	writeCounter(&b, "scaleway_sdk_operation_errors_total", "Number of errors returned by the methods of the API.", errs)
+	writeHistogram(&b, "scaleway_sdk_operation_duration_seconds", "Duration of the calls to the methods of the API.", m.buckets, operations)
+	writeCounter(&b, "scaleway_sdk_requests_total", "Number of HTTP requests sent to the API.", requests)
+	writeHistogram(&b, "scaleway_sdk_request_duration_seconds", "Duration of the HTTP requests sent to the API.", m.buckets, requestDurations)
+	writeCounter(&b, "scaleway_sdk_pages_total", "Number of pages fetched by paginated requests.", pages)
+	m.mu.Unlock()
+
+	return b.WriteTo(w)
+}
+
+// ServeHTTP serves the metrics, i.e: on /metrics
+func (m *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+func writeCounter(b *bytes.Buffer, name, help string, values map[string]uint64) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+	for _, key := range sortedKeys(values) {
+		fmt.Fprintf(b, "%s%s %d\n", name, key, values[key])
+	}
+}
+
+func writeHistogram(b *bytes.Buffer, name, help string, buckets []float64, values map[string]*histogram) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		h := values[key]
+		// the bucket label is appended to the other ones
+		prefix := strings.TrimSuffix(key, "}") + ","
+		var cumulated uint64
+		for i, bound := range buckets {
+			if h.counts != nil {
+				cumulated += h.counts[i]
+			}
+			fmt.Fprintf(b, "%s_bucket%sle=\"%s\"} %d\n", name, prefix, formatFloat(bound), cumulated)
+		}
+		fmt.Fprintf(b, "%s_bucket%sle=\"+Inf\"} %d\n", name, prefix, h.count)
+		fmt.Fprintf(b, "%s_sum%s %s\n", name, key, formatFloat(h.sum))
+		fmt.Fprintf(b, "%s_count%s %d\n", name, key, h.count)
+	}
+}
+
+// labels formats pairs of label names and values
+func labels(pairs ...string) string {
+	var b strings.Builder
+
+	b.WriteString("{")
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, "%s=\"%s\"", pairs[i], labelEscaper.Replace(pairs[i+1]))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func sortedKeys(values map[string]uint64) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// statusLabel formats a status code, 0 meaning that no response was received
+func statusLabel(statusCode int) string {
+	if statusCode == 0 {
+		return ""
+	}
+	return strconv.Itoa(statusCode)
+}
+
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -17,7 +18,15 @@ type GetQuotas struct {
 
 // GetQuotas returns a GetQuotas
 func (s *API) GetQuotas() (*GetQuotas, error) {
-	resp, err := s.GetResponsePaginate(AccountAPI, fmt.Sprintf("organizations/%s/quotas", s.Organization), url.Values{})
+	return s.GetQuotasContext(context.Background())
+}
+
+// GetQuotasContext is like GetQuotas but uses ctx for the underlying requests
+func (s *API) GetQuotasContext(ctx context.Context) (_ *GetQuotas, err error) {
+	ctx, op := s.startOperation(ctx, "GetQuotas", "quota", s.Organization)
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.accountAPI, fmt.Sprintf("organizations/%s/quotas", s.Organization), url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIFamily identifies a group of endpoints sharing a rate limit
+type APIFamily string
+
+// API families
+const (
+	FamilyCompute     APIFamily = "compute"
+	FamilyAccount     APIFamily = "account"
+	FamilyMarketplace APIFamily = "marketplace"
+	FamilyMetadata    APIFamily = "metadata"
+)
+
+// RateLimit represents a token bucket: Burst requests may be sent at once,
+// then Rate requests per second
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimiter throttles the requests sent to each API family, it may be
+// shared by several clients using the same token
+type RateLimiter struct {
+	mu      sync.Mutex
+	buckets map[APIFamily]*tokenBucket
+}
+
+// NewRateLimiter returns a RateLimiter which doesn't limit any family
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		buckets: make(map[APIFamily]*tokenBucket),
+	}
+}
+
+// SetLimit registers the rate limit of a family, a zero Rate removes the limit
+func (l *RateLimiter) SetLimit(family APIFamily, limit RateLimit) {
+	b := l.bucket(family)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.limit = limit
+	b.tokens = b.burst()
+	b.last = time.Now()
+}
+
+// Wait blocks until a request to family may be sent, or until ctx is done
+func (l *RateLimiter) Wait(ctx context.Context, family APIFamily) error {
+	b := l.bucket(family)
+
+	wait := b.reserve(time.Now())
+	if wait <= 0 {
+		return nil
+	}
+	if err := sleep(ctx, wait); err != nil {
+		b.release()
+		return err
+	}
+	return nil
+}
+
+// pause holds the requests to family for d, i.e: until the limit of the API resets
+func (l *RateLimiter) pause(family APIFamily, d time.Duration) {
+	b := l.bucket(family)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if until := time.Now().Add(d); until.After(b.until) {
+		b.until = until
+	}
+}
+
+func (l *RateLimiter) bucket(family APIFamily) *tokenBucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[family]
+	if !ok {
+		b = &tokenBucket{}
+		l.buckets[family] = b
+	}
+	return b
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+
+	// until is set when the API answers with 429, nothing is sent before
+	until time.Time
+}
+
+func (b *tokenBucket) burst() float64 {
+	if b.limit.Burst < 1 {
+		return 1
+	}
+	return float64(b.limit.Burst)
+}
+
+// reserve takes a token and returns how long to wait before using it
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var wait time.Duration
+	if now.Before(b.until) {
+		wait = b.until.Sub(now)
+	}
+	if b.limit.Rate <= 0 {
+		return wait
+	}
+	b.tokens += now.Sub(b.last).Seconds() * b.limit.Rate
+	if b.tokens > b.burst() {
+		b.tokens = b.burst()
+	}
+	b.last = now
+	b.tokens--
+	if b.tokens < 0 {
+		if d := time.Duration(-b.tokens / b.limit.Rate * float64(time.Second)); d > wait {
+			wait = d
+		}
+	}
+	return wait
+}
+
+// release gives back a token which won't be used
+func (b *tokenBucket) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.limit.Rate > 0 {
+		b.tokens++
+	}
+}
+
+// SetRateLimiter registers the rate limiter
+func (s *API) SetRateLimiter(limiter *RateLimiter) {
+	s.limiter = limiter
+}
+
+// apiFamily returns the family of the endpoint targeted by uri
+func (s *API) apiFamily(uri string) APIFamily {
+	switch {
+	case strings.HasPrefix(uri, strings.TrimRight(s.accountAPI, "/")):
+		return FamilyAccount
+	case strings.HasPrefix(uri, strings.TrimRight(s.marketplaceAPI, "/")):
+		return FamilyMarketplace
+	case strings.HasPrefix(uri, strings.TrimRight(s.metadataAPI, "/")):
+		return FamilyMetadata
+	}
+	return FamilyCompute
+}
+
+// parseRateLimitReset parses a X-RateLimit-Reset header, as a unix timestamp
+func parseRateLimitReset(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	timestamp, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	wait := time.Until(time.Unix(timestamp, 0))
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, true
+}
+
+// isRateLimited returns true if resp tells that the request was throttled
+func isRateLimited(resp *http.Response) bool {
+	return resp != nil && resp.StatusCode == http.StatusTooManyRequests
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	limiter := NewRateLimiter()
+	limiter.SetLimit(FamilyCompute, RateLimit{Rate: 100, Burst: 2})
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := limiter.Wait(context.Background(), FamilyCompute); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 2 requests are sent at once, the 2 others are spaced by 10ms
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("expected requests to be throttled, took %v", elapsed)
+	}
+
+	start = time.Now()
+	if err := limiter.Wait(context.Background(), FamilyAccount); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Millisecond {
+		t.Errorf("expected account requests not to be throttled, took %v", elapsed)
+	}
+}
+
+func TestRateLimiter_WaitCanceled(t *testing.T) {
+	limiter := NewRateLimiter()
+	limiter.SetLimit(FamilyCompute, RateLimit{Rate: 0.001})
+	limiter.Wait(context.Background(), FamilyCompute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx, FamilyCompute); err != context.DeadlineExceeded {
+		t.Errorf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestRateLimit_TooManyRequests(t *testing.T) {
+	var calls int32
+	s := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			reset := time.Now().Add(2 * time.Second).Unix()
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"server": {"id": "1"}}`))
+	}))
+
+	start := time.Now()
+	// POST requests are retried as well, since the API didn't process them
+	id, err := s.PostServer(ServerDefinition{Name: "test"})
+	if err != nil {
+		t.Fatalf("expected PostServer to succeed after a retry, got %v", err)
+	}
+	if id != "1" || calls != 2 {
+		t.Errorf("expected server 1 after 2 calls, got %q after %d calls", id, calls)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected to wait for the limit to reset, took %v", elapsed)
+	}
+}
+
+func TestAPI_apiFamily(t *testing.T) {
+	s, err := New("organization", "token", "par1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for uri, family := range map[string]APIFamily{
+		"https://cp-par1.scaleway.com/servers":              FamilyCompute,
+		AccountAPI + "tokens":                               FamilyAccount,
+		MarketplaceAPI + "/images":                          FamilyMarketplace,
+		MetadataAPI + "user_data":                           FamilyMetadata,
+		"https://availability-ams1.scaleway.com/avail.json": FamilyCompute,
+	} {
+		if got := s.apiFamily(uri); got != family {
+			t.Errorf("%s: expected %s, got %s", uri, family, got)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Region describes the endpoints of a  region
+type Region struct {
+	// Name is the identifier of the region (i.e: par1, ams1)
+	Name string
+
+	// ComputeAPI is the URL of the compute API
+	ComputeAPI string
+
+	// AvailabilityAPI is the URL of the availability API
+	AvailabilityAPI string
+
+	// MetadataAPI is the URL of the metadata API, MetadataAPI when empty
+	MetadataAPI string
+
+	// PublicDNS and PrivateDNS are the suffixes of the DNS names of the servers
+	PublicDNS  string
+	PrivateDNS string
+
+	// Zones are the identifiers of the zones of the region, the first one is the default
+	Zones []string
+}
+
+// DefaultRegion is the region used when none is given to New
+var DefaultRegion = "par1"
+
+var (
+	regionsMu sync.RWMutex
+	regions   = map[string]Region{
+		"par1": {
+			Name:            "par1",
+			ComputeAPI:      "https://cp-par1.scaleway.com/",
+			AvailabilityAPI: "https://availability.scaleway.com/",
+			PublicDNS:       ".pub.cloud.scaleway.com",
+			PrivateDNS:      ".priv.cloud.scaleway.com",
+			Zones:           []string{"par1"},
+		},
+		"ams1": {
+			Name:            "ams1",
+			ComputeAPI:      "https://cp-ams1.scaleway.com/",
+			AvailabilityAPI: "https://availability-ams1.scaleway.com/",
+			PublicDNS:       ".pub.cloud.scaleway.com",
+			PrivateDNS:      ".priv.cloud.scaleway.com",
+			Zones:           []string{"ams1"},
+		},
+	}
+)
+
+// RegisterRegion adds a region to the registry, or replaces the one with the same name
+func RegisterRegion(region Region) error {
+	if region.Name == "" {
+		return fmt.Errorf("cannot register a region without name")
+	}
+	if region.ComputeAPI == "" {
+		return fmt.Errorf("cannot register region %s without compute API", region.Name)
+	}
+	if len(region.Zones) == 0 {
+		region.Zones = []string{region.Name}
+	}
+	regionsMu.Lock()
+	defer regionsMu.Unlock()
+	regions[region.Name] = region
+	return nil
+}
+
+// LookupRegion returns a registered region, DefaultRegion if name is empty
+func LookupRegion(name string) (Region, error) {
+	if name == "" {
+		name = DefaultRegion
+	}
+	regionsMu.RLock()
+	defer regionsMu.RUnlock()
+	region, ok := regions[name]
+	if !ok {
+		return Region{}, fmt.Errorf("%s isn't a valid region", name)
+	}
+	return withDeprecatedEndpoints(region), nil
+}
+
+// Regions returns the registered regions, sorted by name
+func Regions() []Region {
+	regionsMu.RLock()
+	defer regionsMu.RUnlock()
+	list := make([]Region, 0, len(regions))
+	for _, region := range regions {
+		list = append(list, withDeprecatedEndpoints(region))
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// Zone returns the default zone of the region
+func (r Region) Zone() string {
+	if len(r.Zones) == 0 {
+		return r.Name
+	}
+	return r.Zones[0]
+}
+
+// setDNS fills the DNS names of a server of the region
+func (r Region) setDNS(server *Server) {
+	server.DNSPublic = server.Identifier + r.PublicDNS
+	server.DNSPrivate = server.Identifier + r.PrivateDNS
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLookupRegion(t *testing.T) {
+	region, err := LookupRegion("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if region.Name != DefaultRegion {
+		t.Errorf("expected %s, got %s", DefaultRegion, region.Name)
+	}
+	if _, err = LookupRegion("nowhere"); err == nil {
+		t.Error("expected an error for an unknown region")
+	}
+	if _, err = New("organization", "token", "nowhere"); err == nil {
+		t.Error("expected an error for an unknown region")
+	}
+}
+
+func TestRegisterRegion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"server": {"id": "1234"}}`))
+	}))
+	defer srv.Close()
+
+	if err := RegisterRegion(Region{Name: "test1"}); err == nil {
+		t.Error("expected an error for a region without compute API")
+	}
+	err := RegisterRegion(Region{
+		Name:       "test1",
+		ComputeAPI: srv.URL,
+		PublicDNS:  ".pub.test",
+		PrivateDNS: ".priv.test",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		regionsMu.Lock()
+		delete(regions, "test1")
+		regionsMu.Unlock()
+	}()
+
+	s, err := New("organization", "token", "test1", WithRetryPolicy(NoRetry))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Region != "test1" || s.region.Zone() != "test1" {
+		t.Errorf("unexpected region: %s, zone %s", s.Region, s.region.Zone())
+	}
+	server, err := s.GetServer("1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server.DNSPublic != "1234.pub.test" || server.DNSPrivate != "1234.priv.test" {
+		t.Errorf("unexpected DNS names: %s, %s", server.DNSPublic, server.DNSPrivate)
+	}
+}
+
+func TestDeprecatedEndpoints(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"server": {"id": "1234"}}`))
+	}))
+	defer srv.Close()
+
+	saved := ComputeAPIAms1
+	ComputeAPIAms1, URLPublicDNS = srv.URL, ".pub.proxy"
+	defer func() {
+		ComputeAPIAms1, URLPublicDNS = saved, builtinEndpoints[&URLPublicDNS]
+	}()
+
+	s, err := New("organization", "token", "ams1", WithRetryPolicy(NoRetry))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := s.GetServer("1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server.DNSPublic != "1234.pub.proxy" {
+		t.Errorf("unexpected DNS name: %s", server.DNSPublic)
+	}
+	region, err := LookupRegion("par1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if region.ComputeAPI != ComputeAPIPar1 || region.PublicDNS != ".pub.proxy" {
+		t.Errorf("unexpected region: %+v", region)
+	}
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy represents how requests failing with a transient error
+// (network failure, 502, 503 or 504) or throttled by the API (429) are retried
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts for a request, including the first one
+	MaxAttempts int
+
+	// MinBackoff is the delay before the first retry, doubled after each attempt
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the delay between two attempts (0 means no cap)
+	MaxBackoff time.Duration
+
+	// RetryNonIdempotent enables retries of POST and PATCH requests,
+	// which may create a resource twice (i.e: PostServer, NewIP).
+	// Throttled requests are always retried as the API didn't process them
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy is the retry policy of the clients returned by New
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  10 * time.Second,
+}
+
+// NoRetry disables retries
+var NoRetry = RetryPolicy{
+	MaxAttempts: 1,
+}
+
+// SetRetryPolicy registers the retry policy
+func (s *API) SetRetryPolicy(policy RetryPolicy) {
+	s.retryPolicy = policy
+}
+
+// shouldRetry returns true if the outcome of an attempt using method is worth another attempt
+func (p RetryPolicy) shouldRetry(ctx context.Context, method string, resp *http.Response, err error) bool {
+	if err == nil && isRateLimited(resp) {
+		return true
+	}
+	return p.canRetry(method) && retryable(ctx, resp, err)
+}
+
+// canRetry returns true if a request using method may be sent several times
+func (p RetryPolicy) canRetry(method string) bool {
+	switch method {
+	case "GET", "HEAD", "PUT", "DELETE":
+		return true
+	}
+	return p.RetryNonIdempotent
+}
+
+// backoff returns the delay to wait before the nth retry
+func (p RetryPolicy) backoff(retry int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return wait
+		}
+		if wait, ok := parseRateLimitReset(resp.Header.Get("X-RateLimit-Reset")); ok && isRateLimited(resp) {
+			return wait
+		}
+	}
+	wait := p.MinBackoff
+	for i := 1; i < retry && (p.MaxBackoff == 0 || wait < p.MaxBackoff); i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	// keep half of the delay and randomize the other half, so that clients
+	// failing together don't retry together
+	if half := int64(wait / 2); half > 0 {
+		wait = time.Duration(half + rand.Int63n(half+1))
+	}
+	return wait
+}
+
+// retryable returns true if the outcome of an attempt is worth another attempt
+func retryable(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		// errors caused by ctx would happen again
+		return ctx.Err() == nil
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header, in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	wait := time.Until(date)
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, true
+}
+
+// sleep waits for d, or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
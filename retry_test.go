@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  time.Millisecond,
+	MaxBackoff:  5 * time.Millisecond,
+}
+
+// newTestAPI returns a client whose compute API is served by handler
+func newTestAPI(t *testing.T, handler http.Handler) *API {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	s, err := New("organization", "token", "par1")
+	if err != nil {
+		t.Fatalf("unable to create client: %v", err)
+	}
+	s.computeAPI = srv.URL
+	s.SetRetryPolicy(testRetryPolicy)
+	return s
+}
+
+// failingHandler fails the first n requests with status, then returns body
+func failingHandler(n int32, status int, body string, calls *int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(calls, 1) <= n {
+			w.WriteHeader(status)
+			return
+		}
+		if r.Method == "POST" {
+			w.WriteHeader(http.StatusCreated)
+		}
+		w.Write([]byte(body))
+	}
+}
+
+func TestRetry_SafeMethods(t *testing.T) {
+	var calls int32
+	s := newTestAPI(t, failingHandler(2, http.StatusServiceUnavailable, `{"server": {"id": "1"}}`, &calls))
+
+	server, err := s.GetServer("1")
+	if err != nil {
+		t.Fatalf("expected GetServer to succeed after retries, got %v", err)
+	}
+	if server.Identifier != "1" {
+		t.Errorf("expected server 1, got %q", server.Identifier)
+	}
+	// HEAD fails twice, then HEAD and GET succeed
+	if calls != 4 {
+		t.Errorf("expected 4 calls, got %d", calls)
+	}
+}
+
+func TestRetry_MaxAttempts(t *testing.T) {
+	var calls int32
+	s := newTestAPI(t, failingHandler(10, http.StatusBadGateway, "", &calls))
+
+	if err := s.DeleteServer("1"); err == nil {
+		t.Fatal("expected DeleteServer to fail")
+	}
+	if calls != int32(testRetryPolicy.MaxAttempts) {
+		t.Errorf("expected %d calls, got %d", testRetryPolicy.MaxAttempts, calls)
+	}
+}
+
+func TestRetry_NonIdempotent(t *testing.T) {
+	var calls int32
+	s := newTestAPI(t, failingHandler(1, http.StatusServiceUnavailable, `{"server": {"id": "1"}}`, &calls))
+
+	if _, err := s.PostServer(ServerDefinition{Name: "test"}); err == nil {
+		t.Fatal("expected PostServer not to be retried")
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+
+	calls = 0
+	policy := testRetryPolicy
+	policy.RetryNonIdempotent = true
+	s.SetRetryPolicy(policy)
+	id, err := s.PostServer(ServerDefinition{Name: "test"})
+	if err != nil {
+		t.Fatalf("expected PostServer to succeed after a retry, got %v", err)
+	}
+	if id != "1" || calls != 2 {
+		t.Errorf("expected server 1 after 2 calls, got %q after %d calls", id, calls)
+	}
+}
+
+func TestRetry_Body(t *testing.T) {
+	var calls int32
+	s := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength == 0 {
+			t.Errorf("attempt %d was sent without a body", calls+1)
+		}
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+
+	if err := s.PutVolume("1", VolumePutDefinition{}); err != nil {
+		t.Fatalf("expected PutVolume to succeed after a retry, got %v", err)
+	}
+}
+
+func TestRetry_ContextCanceled(t *testing.T) {
+	var calls int32
+	s := newTestAPI(t, failingHandler(10, http.StatusServiceUnavailable, "", &calls))
+	s.SetRetryPolicy(RetryPolicy{MaxAttempts: 5, MinBackoff: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := s.DeleteServerContext(ctx, "1"); err != context.DeadlineExceeded {
+		t.Errorf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 4 * time.Second}
+
+	for retry, max := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second} {
+		wait := policy.backoff(retry+1, nil)
+		if wait < max/2 || wait > max {
+			t.Errorf("retry %d: expected a delay between %v and %v, got %v", retry+1, max/2, max, wait)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", "7")
+	if wait := policy.backoff(1, resp); wait != 7*time.Second {
+		t.Errorf("expected Retry-After to be honored, got %v", wait)
+	}
+	resp.Header.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	if wait := policy.backoff(1, resp); wait != 0 {
+		t.Errorf("expected a past Retry-After date to be honored, got %v", wait)
+	}
+}
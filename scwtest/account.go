@@ -0,0 +1,169 @@
+package scwtest
+
+import (
+	"crypto/md5"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type user struct {
+	record
+	email     string
+	firstname string
+	lastname  string
+	sshKeys   []string
+}
+
+func (s *Server) registerAccount() {
+	s.handle("GET", AccountPrefix, "tokens/{}", true, s.getToken)
+	s.handle("GET", AccountPrefix, "tokens/{}/permissions", true, s.getPermissions)
+	s.handle("GET", AccountPrefix, "organizations", true, s.listOrganizations)
+	s.handle("GET", AccountPrefix, "organizations/{}/quotas", true, s.getQuotas)
+	s.handle("GET", AccountPrefix, "users/{}", true, s.getUser)
+	s.handle("PATCH", AccountPrefix, "users/{}", true, s.patchUser)
+}
+
+func (s *Server) renderOrganization(withUsers bool) map[string]interface{} {
+	out := map[string]interface{}{
+		"id":    s.Organization,
+		"name":  "Fake organization",
+		"users": []interface{}{},
+	}
+	if withUsers {
+		out["users"] = []interface{}{s.renderUser()}
+	}
+	return out
+}
+
+func (s *Server) renderUser() map[string]interface{} {
+	keys := []interface{}{}
+	for _, key := range s.user.sshKeys {
+		keys = append(keys, map[string]interface{}{
+			"key":         key,
+			"fingerprint": fingerprint(key),
+		})
+	}
+	return map[string]interface{}{
+		"id":              s.user.id,
+		"email":           s.user.email,
+		"firstname":       s.user.firstname,
+		"lastname":        s.user.lastname,
+		"fullname":        s.user.firstname + " " + s.user.lastname,
+		"organizations":   []interface{}{s.renderOrganization(false)},
+		"roles":           []interface{}{s.renderRole()},
+		"ssh_public_keys": keys,
+	}
+}
+
+func (s *Server) renderRole() map[string]interface{} {
+	return map[string]interface{}{
+		"organization": s.renderOrganization(false),
+		"role":         "manager",
+	}
+}
+
+// fingerprint returns the MD5 fingerprint of the body of a public key
+func fingerprint(key string) string {
+	fields := strings.Fields(key)
+	if len(fields) > 1 {
+		key = fields[1]
+	}
+	sum := md5.Sum([]byte(key))
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02x", b)
+	}
+	return "2048 " + strings.Join(parts, ":")
+}
+
+func (s *Server) getToken(w http.ResponseWriter, r *http.Request, params []string) {
+	if params[0] != s.Token {
+		notFound(w, "token", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"token": map[string]interface{}{
+		"id":                  s.Token,
+		"user_id":             s.UserID,
+		"description":         "",
+		"roles":               s.renderRole(),
+		"expires":             nil,
+		"inherits_user_perms": true,
+	}})
+}
+
+func (s *Server) getPermissions(w http.ResponseWriter, r *http.Request, params []string) {
+	if params[0] != s.Token {
+		notFound(w, "token", params[0])
+		return
+	}
+	all := []string{s.Organization}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"permissions": map[string]interface{}{
+		"compute": map[string]interface{}{
+			"servers:read":  all,
+			"servers:write": all,
+			"volumes:read":  all,
+			"volumes:write": all,
+			"images:read":   all,
+			"images:write":  all,
+			"ips:read":      all,
+			"ips:write":     all,
+		},
+		"account": map[string]interface{}{
+			"organizations:read": all,
+			"users:read":         all,
+		},
+	}})
+}
+
+func (s *Server) listOrganizations(w http.ResponseWriter, r *http.Request, params []string) {
+	writeList(w, r, "organizations", []interface{}{s.renderOrganization(true)})
+}
+
+func (s *Server) getQuotas(w http.ResponseWriter, r *http.Request, params []string) {
+	if params[0] != s.Organization {
+		writeError(w, http.StatusForbidden, "invalid_request_error", "Forbidden", nil)
+		return
+	}
+	quotas := map[string]int{}
+	for name, value := range s.quotas {
+		quotas[name] = value
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"quotas": quotas})
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request, params []string) {
+	if params[0] != s.UserID {
+		notFound(w, "user", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"user": s.renderUser()})
+}
+
+func (s *Server) patchUser(w http.ResponseWriter, r *http.Request, params []string) {
+	if params[0] != s.UserID {
+		notFound(w, "user", params[0])
+		return
+	}
+	var definition struct {
+		SSHPublicKeys *[]struct {
+			Key string `json:"key"`
+		} `json:"ssh_public_keys"`
+	}
+	if !decode(w, r, &definition) {
+		return
+	}
+	if definition.SSHPublicKeys != nil {
+		keys := []string{}
+		for _, key := range *definition.SSHPublicKeys {
+			if len(strings.Fields(key.Key)) < 2 {
+				invalidField(w, "ssh_public_keys", "invalid public key")
+				return
+			}
+			keys = append(keys, key.Key)
+		}
+		s.user.sshKeys = keys
+	}
+	s.user.touch()
+	writeJSON(w, http.StatusOK, map[string]interface{}{"user": s.renderUser()})
+}
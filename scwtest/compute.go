@@ -0,0 +1,855 @@
+package scwtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// instance is a server of the compute API
+type instance struct {
+	record
+	name           string
+	commercialType string
+	arch           string
+	state          string
+	stateDetail    string
+	image          string
+	bootscript     string
+	securityGroup  string
+	publicIP       string
+	dynamicIP      bool
+	dynamicAddress string
+	dynamicID      string
+	privateIP      string
+	enableIPv6     bool
+	tags           []string
+	volumes        map[string]string
+	userData       map[string][]byte
+	transition     *transition
+}
+
+// transition is an action in progress, completed after reads reads
+type transition struct {
+	to        string
+	terminate bool
+	task      string
+	reads     int
+}
+
+// actions lists the states from which each action is allowed, the transient
+// state and the final one
+var actions = map[string]struct {
+	from      []string
+	transient string
+	to        string
+}{
+	"poweron":       {[]string{"stopped", "stopped in place"}, "starting", "running"},
+	"poweroff":      {[]string{"running", "stopped in place"}, "stopping", "stopped"},
+	"stop_in_place": {[]string{"running"}, "stopping", "stopped in place"},
+	"reboot":        {[]string{"running"}, "rebooting", "running"},
+	"terminate":     {[]string{"running"}, "stopping", ""},
+}
+
+type task struct {
+	record
+	description string
+	status      string
+	hrefFrom    string
+	progress    int
+	terminated  time.Time
+}
+
+type bootscript struct {
+	record
+	title     string
+	arch      string
+	kernel    string
+	initrd    string
+	public    bool
+	isDefault bool
+	bootArgs  string
+}
+
+func (s *Server) registerCompute() {
+	s.handle("GET", ComputePrefix, "servers", true, s.listServers)
+	s.handle("POST", ComputePrefix, "servers", true, s.createServer)
+	s.handle("GET", ComputePrefix, "servers/{}", true, s.getServer)
+	s.handle("PATCH", ComputePrefix, "servers/{}", true, s.patchServer)
+	s.handle("DELETE", ComputePrefix, "servers/{}", true, s.deleteServer)
+	s.handle("GET", ComputePrefix, "servers/{}/action", true, s.listActions)
+	s.handle("POST", ComputePrefix, "servers/{}/action", true, s.serverAction)
+	s.handle("GET", ComputePrefix, "servers/{}/user_data", true, s.withServer(s.listUserData))
+	s.handle("GET", ComputePrefix, "servers/{}/user_data/{}", true, s.withServer(s.getUserData))
+	s.handle("PATCH", ComputePrefix, "servers/{}/user_data/{}", true, s.withServer(s.patchUserData))
+	s.handle("DELETE", ComputePrefix, "servers/{}/user_data/{}", true, s.withServer(s.deleteUserData))
+
+	s.handle("GET", ComputePrefix, "volumes", true, s.listVolumes)
+	s.handle("POST", ComputePrefix, "volumes", true, s.createVolume)
+	s.handle("GET", ComputePrefix, "volumes/{}", true, s.getVolume)
+	s.handle("PUT", ComputePrefix, "volumes/{}", true, s.putVolume)
+	s.handle("DELETE", ComputePrefix, "volumes/{}", true, s.deleteVolume)
+
+	s.handle("GET", ComputePrefix, "snapshots", true, s.listSnapshots)
+	s.handle("POST", ComputePrefix, "snapshots", true, s.createSnapshot)
+	s.handle("GET", ComputePrefix, "snapshots/{}", true, s.getSnapshot)
+	s.handle("POST", ComputePrefix, "snapshots/{}/export", true, s.exportSnapshot)
+	s.handle("DELETE", ComputePrefix, "snapshots/{}", true, s.deleteSnapshot)
+
+	s.handle("GET", ComputePrefix, "images", true, s.listImages)
+	s.handle("POST", ComputePrefix, "images", true, s.createImage)
+	s.handle("GET", ComputePrefix, "images/{}", true, s.getImage)
+	s.handle("DELETE", ComputePrefix, "images/{}", true, s.deleteImage)
+
+	s.handle("GET", ComputePrefix, "ips", true, s.listIPs)
+	s.handle("POST", ComputePrefix, "ips", true, s.createIP)
+	s.handle("GET", ComputePrefix, "ips/{}", true, s.getIP)
+	s.handle("PUT", ComputePrefix, "ips/{}", true, s.putIP)
+	s.handle("DELETE", ComputePrefix, "ips/{}", true, s.deleteIP)
+
+	s.handle("GET", ComputePrefix, "security_groups", true, s.listSecurityGroups)
+	s.handle("POST", ComputePrefix, "security_groups", true, s.createSecurityGroup)
+	s.handle("GET", ComputePrefix, "security_groups/{}", true, s.getSecurityGroup)
+	s.handle("PUT", ComputePrefix, "security_groups/{}", true, s.putSecurityGroup)
+	s.handle("DELETE", ComputePrefix, "security_groups/{}", true, s.deleteSecurityGroup)
+	s.handle("GET", ComputePrefix, "security_groups/{}/rules", true, s.listRules)
+	s.handle("POST", ComputePrefix, "security_groups/{}/rules", true, s.createRule)
+	s.handle("GET", ComputePrefix, "security_groups/{}/rules/{}", true, s.getRule)
+	s.handle("PUT", ComputePrefix, "security_groups/{}/rules/{}", true, s.putRule)
+	s.handle("DELETE", ComputePrefix, "security_groups/{}/rules/{}", true, s.deleteRule)
+
+	s.handle("GET", ComputePrefix, "bootscripts", true, s.listBootscripts)
+	s.handle("GET", ComputePrefix, "bootscripts/{}", true, s.getBootscript)
+	s.handle("GET", ComputePrefix, "tasks", true, s.listTasks)
+	s.handle("GET", ComputePrefix, "tasks/{}", true, s.getTask)
+	s.handle("GET", ComputePrefix, "dashboard", true, s.dashboard)
+	s.handle("GET", ComputePrefix, "containers", true, s.listContainers)
+	s.handle("GET", ComputePrefix, "containers/{}", true, s.getContainer)
+}
+
+// archOf returns the architecture of a commercial type
+func archOf(commercialType string) string {
+	switch {
+	case commercialType == "C1":
+		return "arm"
+	case strings.HasPrefix(commercialType, "ARM64"):
+		return "arm64"
+	}
+	return "x86_64"
+}
+
+func (s *Server) renderServer(server *instance) map[string]interface{} {
+	volumes := map[string]interface{}{}
+	for index, id := range server.volumes {
+		if volume, ok := s.volumes[id]; ok {
+			volumes[index] = s.renderVolume(volume)
+		}
+	}
+	out := map[string]interface{}{
+		"name":                server.name,
+		"hostname":            server.name,
+		"arch":                server.arch,
+		"commercial_type":     server.commercialType,
+		"state":               server.state,
+		"state_detail":        server.stateDetail,
+		"organization":        s.Organization,
+		"tags":                append([]string{}, server.tags...),
+		"volumes":             volumes,
+		"dynamic_ip_required": server.dynamicIP,
+		"enable_ipv6":         server.enableIPv6,
+		"private_ip":          nil,
+		"public_ip":           nil,
+		"image":               nil,
+		"bootscript":          nil,
+		"ipv6":                nil,
+		"location":            nil,
+		"security_group":      nil,
+	}
+	if server.privateIP != "" {
+		out["private_ip"] = server.privateIP
+		out["location"] = map[string]interface{}{
+			"zone_id":     s.Zone,
+			"platform_id": "13",
+			"cluster_id":  "42",
+			"node_id":     "7",
+		}
+	}
+	if ip, ok := s.ips[server.publicIP]; ok {
+		out["public_ip"] = map[string]interface{}{"id": ip.id, "address": ip.address, "dynamic": false}
+	} else if server.dynamicAddress != "" {
+		out["public_ip"] = map[string]interface{}{"id": server.dynamicID, "address": server.dynamicAddress, "dynamic": true}
+	}
+	if image, ok := s.images[server.image]; ok {
+		out["image"] = s.renderImage(image)
+	}
+	if bootscript, ok := s.bootscripts[server.bootscript]; ok {
+		out["bootscript"] = renderBootscript(bootscript)
+	}
+	if group, ok := s.groups[server.securityGroup]; ok {
+		out["security_group"] = map[string]interface{}{"id": group.id, "name": group.name}
+	}
+	return server.dates(out)
+}
+
+// advance moves a server forward in its transition, it is called on each read
+func (s *Server) advance(server *instance) {
+	t := server.transition
+	if t == nil {
+		return
+	}
+	if t.reads > 0 {
+		t.reads--
+		return
+	}
+	server.transition = nil
+	s.completeTask(t.task)
+	if t.terminate {
+		for _, id := range server.volumes {
+			delete(s.volumes, id)
+		}
+		if ip, ok := s.ips[server.publicIP]; ok {
+			ip.server = ""
+		}
+		delete(s.servers, server.id)
+		return
+	}
+	s.setState(server, t.to)
+}
+
+// setState sets the state of a server and the fields which depend on it
+func (s *Server) setState(server *instance, state string) {
+	server.state = state
+	server.stateDetail = ""
+	server.touch()
+	switch state {
+	case "running":
+		server.stateDetail = "booted"
+		s.addresses++
+		server.privateIP = fmt.Sprintf("10.1.%d.%d", s.addresses/250, s.addresses%250+1)
+		if server.dynamicIP && server.publicIP == "" {
+			server.dynamicAddress = s.nextAddress()
+			server.dynamicID = newID()
+		}
+	case "stopped":
+		server.privateIP = ""
+		server.dynamicAddress = ""
+		server.dynamicID = ""
+	}
+}
+
+// lookupServer returns a server after advancing its transition, and writes
+// an error if it doesn't exist
+func (s *Server) lookupServer(w http.ResponseWriter, id string) (*instance, bool) {
+	server, ok := s.servers[id]
+	if ok {
+		s.advance(server)
+		server, ok = s.servers[id]
+	}
+	if !ok {
+		notFound(w, "server", id)
+	}
+	return server, ok
+}
+
+// withServer passes the server of the first parameter to handler
+func (s *Server) withServer(handler func(w http.ResponseWriter, r *http.Request, server *instance, params []string)) func(w http.ResponseWriter, r *http.Request, params []string) {
+	return func(w http.ResponseWriter, r *http.Request, params []string) {
+		server, ok := s.lookupServer(w, params[0])
+		if !ok {
+			return
+		}
+		handler(w, r, server, params[1:])
+	}
+}
+
+func (s *Server) listServers(w http.ResponseWriter, r *http.Request, params []string) {
+	for _, server := range sorted(s.servers) {
+		s.advance(server)
+	}
+	query := r.URL.Query()
+	items := []interface{}{}
+	for _, server := range sorted(s.servers) {
+		if state := query.Get("state"); state != "" && server.state != state {
+			continue
+		}
+		if name := query.Get("name"); name != "" && !strings.Contains(server.name, name) {
+			continue
+		}
+		if commercialType := query.Get("commercial_type"); commercialType != "" && server.commercialType != commercialType {
+			continue
+		}
+		if organization := query.Get("organization"); organization != "" && organization != s.Organization {
+			continue
+		}
+		items = append(items, s.renderServer(server))
+	}
+	writeList(w, r, "servers", items)
+}
+
+func (s *Server) getServer(w http.ResponseWriter, r *http.Request, params []string) {
+	server, ok := s.lookupServer(w, params[0])
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"server": s.renderServer(server)})
+}
+
+func (s *Server) createServer(w http.ResponseWriter, r *http.Request, params []string) {
+	var definition struct {
+		Name              string            `json:"name"`
+		Image             *string           `json:"image"`
+		Volumes           map[string]string `json:"volumes"`
+		DynamicIPRequired *bool             `json:"dynamic_ip_required"`
+		Bootscript        *string           `json:"bootscript"`
+		Tags              []string          `json:"tags"`
+		Organization      string            `json:"organization"`
+		CommercialType    string            `json:"commercial_type"`
+		PublicIP          string            `json:"public_ip"`
+		EnableIPV6        bool              `json:"enable_ipv6"`
+		SecurityGroup     string            `json:"security_group"`
+	}
+	if !decode(w, r, &definition) {
+		return
+	}
+	switch {
+	case definition.Name == "":
+		invalidField(w, "name", "required key not provided")
+		return
+	case definition.Organization != s.Organization:
+		invalidField(w, "organization", "invalid organization")
+		return
+	case definition.CommercialType == "":
+		invalidField(w, "commercial_type", "required key not provided")
+		return
+	}
+	if available, ok := s.availability[definition.CommercialType]; !ok || !available {
+		invalid(w, fmt.Sprintf("The commercial type %s is not available", definition.CommercialType))
+		return
+	}
+	if !s.quota(w, "servers", len(s.servers)) {
+		return
+	}
+
+	server := &instance{
+		record:         s.newRecord(),
+		name:           definition.Name,
+		commercialType: definition.CommercialType,
+		arch:           archOf(definition.CommercialType),
+		state:          "stopped",
+		securityGroup:  s.DefaultSecurityGroup,
+		enableIPv6:     definition.EnableIPV6,
+		tags:           definition.Tags,
+		volumes:        map[string]string{},
+		userData:       map[string][]byte{},
+	}
+	if definition.DynamicIPRequired != nil {
+		server.dynamicIP = *definition.DynamicIPRequired
+	}
+
+	var root *snapshot
+	if definition.Image != nil && *definition.Image != "" {
+		image, ok := s.images[*definition.Image]
+		if !ok {
+			invalidField(w, "image", fmt.Sprintf("image %q not found", *definition.Image))
+			return
+		}
+		if image.arch != server.arch {
+			invalid(w, fmt.Sprintf("The image %s is %s, %s needs %s", image.id, image.arch, server.commercialType, server.arch))
+			return
+		}
+		if _, ok := definition.Volumes["0"]; ok {
+			invalidField(w, "volumes", "the volume 0 comes from the image")
+			return
+		}
+		server.image = image.id
+		server.bootscript = image.bootscript
+		root = s.snapshots[image.rootSnapshot]
+	} else if _, ok := definition.Volumes["0"]; !ok {
+		invalidField(w, "volumes", "a root volume or an image is required")
+		return
+	}
+	for index, id := range definition.Volumes {
+		volume, ok := s.volumes[id]
+		if !ok {
+			invalidField(w, "volumes", fmt.Sprintf("volume %q not found", id))
+			return
+		}
+		if volume.server != "" {
+			invalidField(w, "volumes", fmt.Sprintf("volume %q is already attached", id))
+			return
+		}
+		server.volumes[index] = id
+	}
+	if definition.Bootscript != nil && *definition.Bootscript != "" {
+		if _, ok := s.bootscripts[*definition.Bootscript]; !ok {
+			invalidField(w, "bootscript", fmt.Sprintf("bootscript %q not found", *definition.Bootscript))
+			return
+		}
+		server.bootscript = *definition.Bootscript
+	}
+	if definition.SecurityGroup != "" {
+		if _, ok := s.groups[definition.SecurityGroup]; !ok {
+			invalidField(w, "security_group", fmt.Sprintf("security group %q not found", definition.SecurityGroup))
+			return
+		}
+		server.securityGroup = definition.SecurityGroup
+	}
+	if definition.PublicIP != "" {
+		ip, ok := s.ips[definition.PublicIP]
+		if !ok {
+			invalidField(w, "public_ip", fmt.Sprintf("ip %q not found", definition.PublicIP))
+			return
+		}
+		if ip.server != "" {
+			invalidField(w, "public_ip", fmt.Sprintf("ip %q is already attached", ip.id))
+			return
+		}
+		ip.server = server.id
+		server.publicIP = ip.id
+	}
+
+	if root != nil {
+		volume := &volume{
+			record:     s.newRecord(),
+			name:       root.name,
+			size:       root.size,
+			volumeType: root.volumeType,
+		}
+		s.volumes[volume.id] = volume
+		server.volumes["0"] = volume.id
+	}
+	for _, id := range server.volumes {
+		s.volumes[id].server = server.id
+	}
+	s.servers[server.id] = server
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"server": s.renderServer(server)})
+}
+
+// refID returns the identifier of a reference sent either as a string, an
+// object with an id, or null
+func refID(raw json.RawMessage) (string, bool) {
+	var id string
+	if err := json.Unmarshal(raw, &id); err == nil {
+		return id, true
+	}
+	var ref *struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(raw, &ref); err != nil {
+		return "", false
+	}
+	if ref == nil {
+		return "", true
+	}
+	return ref.ID, true
+}
+
+func (s *Server) patchServer(w http.ResponseWriter, r *http.Request, params []string) {
+	server, ok := s.lookupServer(w, params[0])
+	if !ok {
+		return
+	}
+	var fields map[string]json.RawMessage
+	if !decode(w, r, &fields) {
+		return
+	}
+	for field, raw := range fields {
+		var err error
+		switch field {
+		case "name":
+			err = json.Unmarshal(raw, &server.name)
+		case "tags":
+			var tags []string
+			if err = json.Unmarshal(raw, &tags); err == nil {
+				server.tags = tags
+			}
+		case "dynamic_ip_required":
+			err = json.Unmarshal(raw, &server.dynamicIP)
+		case "enable_ipv6":
+			err = json.Unmarshal(raw, &server.enableIPv6)
+		case "bootscript":
+			id, ok := refID(raw)
+			if _, exists := s.bootscripts[id]; !ok || (id != "" && !exists) {
+				invalidField(w, field, fmt.Sprintf("bootscript %q not found", id))
+				return
+			}
+			server.bootscript = id
+		case "security_group":
+			id, ok := refID(raw)
+			if _, exists := s.groups[id]; !ok || !exists {
+				invalidField(w, field, fmt.Sprintf("security group %q not found", id))
+				return
+			}
+			server.securityGroup = id
+		}
+		if err != nil {
+			invalidField(w, field, err.Error())
+			return
+		}
+	}
+	server.touch()
+	writeJSON(w, http.StatusOK, map[string]interface{}{"server": s.renderServer(server)})
+}
+
+func (s *Server) deleteServer(w http.ResponseWriter, r *http.Request, params []string) {
+	server, ok := s.lookupServer(w, params[0])
+	if !ok {
+		return
+	}
+	if server.state != "stopped" {
+		invalid(w, "server should be stopped")
+		return
+	}
+	for _, id := range server.volumes {
+		if volume, ok := s.volumes[id]; ok {
+			volume.server = ""
+		}
+	}
+	if ip, ok := s.ips[server.publicIP]; ok {
+		ip.server = ""
+	}
+	delete(s.servers, server.id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listActions(w http.ResponseWriter, r *http.Request, params []string) {
+	server, ok := s.lookupServer(w, params[0])
+	if !ok {
+		return
+	}
+	// the actions allowed in the current state, none during a transition
+	names := []string{}
+	if server.transition == nil {
+		names = append(names, "backup")
+		for name, action := range actions {
+			for _, state := range action.from {
+				if server.state == state {
+					names = append(names, name)
+				}
+			}
+		}
+	}
+	sort.Strings(names)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"actions": names})
+}
+
+func (s *Server) serverAction(w http.ResponseWriter, r *http.Request, params []string) {
+	server, ok := s.lookupServer(w, params[0])
+	if !ok {
+		return
+	}
+	var body struct {
+		Action string `json:"action"`
+	}
+	if !decode(w, r, &body) {
+		return
+	}
+	href := fmt.Sprintf("/servers/%s/action", server.id)
+
+	if body.Action == "backup" {
+		if server.transition != nil {
+			invalid(w, "server is busy")
+			return
+		}
+		if !s.backup(w, server) {
+			return
+		}
+		task := s.newTask("server_backup", href)
+		s.completeTask(task.id)
+		writeJSON(w, http.StatusAccepted, map[string]interface{}{"task": renderTask(task)})
+		return
+	}
+	action, ok := actions[body.Action]
+	if !ok {
+		invalidField(w, "action", fmt.Sprintf("invalid action %q", body.Action))
+		return
+	}
+	allowed := false
+	for _, state := range action.from {
+		allowed = allowed || server.state == state
+	}
+	if !allowed || server.transition != nil {
+		invalid(w, fmt.Sprintf("server should be %s", strings.Join(action.from, " or ")))
+		return
+	}
+	task := s.newTask("server_"+body.Action, href)
+	server.state = action.transient
+	server.stateDetail = ""
+	server.touch()
+	server.transition = &transition{
+		to:        action.to,
+		terminate: body.Action == "terminate",
+		task:      task.id,
+		reads:     s.TransitionReads,
+	}
+	if s.TransitionReads == 0 {
+		s.advance(server)
+	}
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{"task": renderTask(task)})
+}
+
+// backup creates an image from the snapshots of the volumes of a server
+func (s *Server) backup(w http.ResponseWriter, server *instance) bool {
+	rootID, ok := server.volumes["0"]
+	if !ok {
+		invalid(w, "server has no root volume")
+		return false
+	}
+	if !s.quota(w, "images", s.countImages()) || !s.quota(w, "snapshots", s.countSnapshots()) {
+		return false
+	}
+	name := fmt.Sprintf("%s_%s", server.name, time.Now().UTC().Format("2006-01-02_15:04"))
+	root := s.newSnapshot(s.volumes[rootID], name)
+	image := &image{
+		record:       s.newRecord(),
+		name:         name,
+		arch:         server.arch,
+		organization: s.Organization,
+		bootscript:   server.bootscript,
+		rootSnapshot: root.id,
+	}
+	s.images[image.id] = image
+	return true
+}
+
+func (s *Server) newTask(description, href string) *task {
+	task := &task{
+		record:      s.newRecord(),
+		description: description,
+		status:      "pending",
+		hrefFrom:    href,
+	}
+	s.tasks[task.id] = task
+	return task
+}
+
+func (s *Server) completeTask(id string) {
+	if task, ok := s.tasks[id]; ok {
+		task.status = "success"
+		task.progress = 100
+		task.terminated = time.Now().UTC()
+		task.touch()
+	}
+}
+
+func renderTask(task *task) map[string]interface{} {
+	out := map[string]interface{}{
+		"id":            task.id,
+		"description":   task.description,
+		"status":        task.status,
+		"href_from":     task.hrefFrom,
+		"progress":      task.progress,
+		"started_at":    task.created.Format(dateLayout),
+		"terminated_at": nil,
+	}
+	if !task.terminated.IsZero() {
+		out["terminated_at"] = task.terminated.Format(dateLayout)
+	}
+	return out
+}
+
+func (s *Server) listTasks(w http.ResponseWriter, r *http.Request, params []string) {
+	items := []interface{}{}
+	for _, task := range sorted(s.tasks) {
+		items = append(items, renderTask(task))
+	}
+	writeList(w, r, "tasks", items)
+}
+
+func (s *Server) getTask(w http.ResponseWriter, r *http.Request, params []string) {
+	// reading the task of a transition is a read of its server
+	for _, server := range sorted(s.servers) {
+		if server.transition != nil && server.transition.task == params[0] {
+			s.advance(server)
+		}
+	}
+	task, ok := s.tasks[params[0]]
+	if !ok {
+		notFound(w, "task", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"task": renderTask(task)})
+}
+
+func (s *Server) listUserData(w http.ResponseWriter, r *http.Request, server *instance, params []string) {
+	keys := []string{}
+	for key := range server.userData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"user_data": keys})
+}
+
+func (s *Server) getUserData(w http.ResponseWriter, r *http.Request, server *instance, params []string) {
+	value, ok := server.userData[params[0]]
+	if !ok {
+		notFound(w, "user_data", params[0])
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write(value)
+}
+
+func (s *Server) patchUserData(w http.ResponseWriter, r *http.Request, server *instance, params []string) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "text/plain") {
+		invalid(w, "user_data must be sent as text/plain")
+		return
+	}
+	value, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		invalid(w, err.Error())
+		return
+	}
+	server.userData[params[0]] = value
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteUserData(w http.ResponseWriter, r *http.Request, server *instance, params []string) {
+	if _, ok := server.userData[params[0]]; !ok {
+		notFound(w, "user_data", params[0])
+		return
+	}
+	delete(server.userData, params[0])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func renderBootscript(bootscript *bootscript) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           bootscript.id,
+		"title":        bootscript.title,
+		"architecture": bootscript.arch,
+		"kernel":       bootscript.kernel,
+		"initrd":       bootscript.initrd,
+		"dtb":          "",
+		"bootcmdargs":  bootscript.bootArgs,
+		"organization": "",
+		"public":       bootscript.public,
+		"default":      bootscript.isDefault,
+	}
+}
+
+func (s *Server) listBootscripts(w http.ResponseWriter, r *http.Request, params []string) {
+	items := []interface{}{}
+	for _, bootscript := range sorted(s.bootscripts) {
+		if arch := r.URL.Query().Get("arch"); arch != "" && bootscript.arch != arch {
+			continue
+		}
+		items = append(items, renderBootscript(bootscript))
+	}
+	writeList(w, r, "bootscripts", items)
+}
+
+func (s *Server) getBootscript(w http.ResponseWriter, r *http.Request, params []string) {
+	bootscript, ok := s.bootscripts[params[0]]
+	if !ok {
+		notFound(w, "bootscript", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"bootscript": renderBootscript(bootscript)})
+}
+
+func (s *Server) dashboard(w http.ResponseWriter, r *http.Request, params []string) {
+	running := 0
+	for _, server := range s.servers {
+		if server.state == "running" {
+			running++
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"dashboard": map[string]interface{}{
+		"servers_count":         len(s.servers),
+		"running_servers_count": running,
+		"volumes_count":         len(s.volumes),
+		"snapshots_count":       s.countSnapshots(),
+		"images_count":          s.countImages(),
+		"ips_count":             len(s.ips),
+	}})
+}
+
+// listContainers lists the object storage containers, the fake has none
+func (s *Server) listContainers(w http.ResponseWriter, r *http.Request, params []string) {
+	writeList(w, r, "containers", []interface{}{})
+}
+
+func (s *Server) getContainer(w http.ResponseWriter, r *http.Request, params []string) {
+	notFound(w, "container", params[0])
+}
+
+// seed creates the resources available to every organization
+func (s *Server) seed() {
+	for _, b := range []*bootscript{
+		{title: "x86_64 mainline 4.4.6 rev1", arch: "x86_64", isDefault: true},
+		{title: "armv7l mainline 4.4.6 rev1", arch: "arm", isDefault: true},
+		{title: "arm64 mainline 4.4.6 rev1", arch: "arm64", isDefault: true},
+	} {
+		b.record = s.newRecord()
+		b.public = true
+		b.kernel = "http://169.254.42.24/kernel/" + strings.Fields(b.title)[0] + "-mainline-4.4.6-rev1"
+		b.initrd = "http://169.254.42.24/initrd/initrd-Linux-" + b.arch + "-v3.11.1.gz"
+		b.bootArgs = "LINUX_COMMON scaleway boot=local nbd.max_part=16"
+		s.bootscripts[b.id] = b
+		if b.arch == "x86_64" {
+			s.DefaultBootscript = b.id
+		}
+	}
+
+	group := &securityGroup{
+		record:              s.newRecord(),
+		name:                "Default security group",
+		description:         "Auto generated security group.",
+		organizationDefault: true,
+		rules:               map[string]*rule{},
+	}
+	s.groups[group.id] = group
+	s.DefaultSecurityGroup = group.id
+
+	market := &marketImage{
+		record:      s.newRecord(),
+		name:        "Ubuntu Xenial",
+		description: "Ubuntu is the ideal distribution for scale-out computing",
+		categories:  []string{"distribution"},
+	}
+	version := &marketVersion{record: s.newRecord(), name: "2016-09-01"}
+	for _, arch := range []string{"x86_64", "arm", "arm64"} {
+		root := &snapshot{
+			record:       s.newRecord(),
+			name:         "ubuntu-xenial-" + arch,
+			size:         50000000000,
+			volumeType:   "l_ssd",
+			state:        "available",
+			organization: marketplaceOrganization,
+		}
+		s.snapshots[root.id] = root
+		image := &image{
+			record:       s.newRecord(),
+			name:         "Ubuntu Xenial (16.04 latest)",
+			arch:         arch,
+			organization: marketplaceOrganization,
+			rootSnapshot: root.id,
+			public:       true,
+		}
+		for _, b := range s.bootscripts {
+			if b.arch == arch {
+				image.bootscript = b.id
+			}
+		}
+		s.images[image.id] = image
+		if arch == "x86_64" {
+			s.DefaultImage = image.id
+		}
+		version.localImages = append(version.localImages, marketLocalImage{id: image.id, arch: arch, zone: s.Zone})
+	}
+	market.versions = []*marketVersion{version}
+	market.current = version.id
+	s.market[market.id] = market
+
+	s.user = &user{
+		record:    record{id: s.UserID},
+		email:     "jane@example.com",
+		firstname: "Jane",
+		lastname:  "Doe",
+	}
+}
@@ -0,0 +1,238 @@
+package scwtest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+)
+
+// Fault alters how a request is answered, next serves it normally
+type Fault func(w http.ResponseWriter, r *http.Request, next http.Handler)
+
+// Rule injects a fault into the matching requests, i.e:
+//
+//	// the first two HEAD /servers fail with 503
+//	srv.Inject(scwtest.Rule{
+//		Method: "HEAD",
+//		Path:   "/compute/servers",
+//		Calls:  scwtest.Range(1, 2),
+//		Fault:  scwtest.Status(http.StatusServiceUnavailable),
+//	})
+type Rule struct {
+	// Method is the method of the requests, empty matches any
+	Method string
+
+	// Path is the path of the requests including the prefix of the API,
+	// {} matches any segment (i.e: /compute/servers/{}), empty matches any
+	Path string
+
+	// Calls are the numbers of the matching requests to alter, counted from
+	// 1 since the rule was injected; empty alters every request
+	Calls []int
+
+	Fault Fault
+}
+
+// injected is a rule and the number of requests it matched
+type injected struct {
+	Rule
+	segments []string
+	count    int
+}
+
+// Range returns the call numbers from first to last included
+func Range(first, last int) []int {
+	var calls []int
+	for i := first; i <= last; i++ {
+		calls = append(calls, i)
+	}
+	return calls
+}
+
+// Inject adds rules, the faults of several matching rules are chained in
+// the order of injection
+func (s *Server) Inject(rules ...Rule) {
+	s.faultsMu.Lock()
+	defer s.faultsMu.Unlock()
+	for _, rule := range rules {
+		s.faults = append(s.faults, &injected{Rule: rule, segments: splitPath(rule.Path)})
+	}
+}
+
+// ResetFaults removes the injected rules
+func (s *Server) ResetFaults() {
+	s.faultsMu.Lock()
+	defer s.faultsMu.Unlock()
+	s.faults = nil
+}
+
+// Calls returns the number of requests matched by the rules with method and
+// path, since their injection
+func (s *Server) Calls(method, path string) int {
+	s.faultsMu.Lock()
+	defer s.faultsMu.Unlock()
+	count := 0
+	for _, rule := range s.faults {
+		if rule.Method == method && rule.Path == path {
+			count += rule.count
+		}
+	}
+	return count
+}
+
+// matchFaults counts the request against the rules and returns the faults to apply
+func (s *Server) matchFaults(r *http.Request) []Fault {
+	s.faultsMu.Lock()
+	defer s.faultsMu.Unlock()
+
+	segments := splitPath(r.URL.Path)
+	var faults []Fault
+	for _, rule := range s.faults {
+		if rule.Method != "" && rule.Method != r.Method {
+			continue
+		}
+		if _, ok := (route{segments: rule.segments}).match(segments); rule.Path != "" && !ok {
+			continue
+		}
+		rule.count++
+		if len(rule.Calls) == 0 {
+			faults = append(faults, rule.Fault)
+			continue
+		}
+		for _, call := range rule.Calls {
+			if call == rule.count {
+				faults = append(faults, rule.Fault)
+				break
+			}
+		}
+	}
+	return faults
+}
+
+// chain applies the faults around handler, the first one is the outermost
+func chain(faults []Fault, handler http.Handler) http.Handler {
+	for i := len(faults) - 1; i >= 0; i-- {
+		fault, next := faults[i], handler
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fault(w, r, next)
+		})
+	}
+	return handler
+}
+
+// Delay holds the response for d, or until the client gives up
+func Delay(d time.Duration) Fault {
+	return func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		// the server notices that the client is gone once the body is read
+		body, _ := ioutil.ReadAll(r.Body)
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			next.ServeHTTP(w, r)
+		case <-r.Context().Done():
+		}
+	}
+}
+
+// Status answers with an error of status code, without serving the request
+func Status(code int) Fault {
+	return func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		kind := "invalid_request_error"
+		if code >= http.StatusInternalServerError {
+			kind = "server_error"
+		}
+		writeError(w, code, kind, http.StatusText(code), nil)
+	}
+}
+
+// RateLimited answers with 429 and a Retry-After of wait
+func RateLimited(wait time.Duration) Fault {
+	return func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds())))
+		writeError(w, http.StatusTooManyRequests, "rate_limited", "Too many requests", nil)
+	}
+}
+
+// Disconnect closes the connection without answering
+func Disconnect() Fault {
+	return func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// Malformed serves the first half of the body, with a matching Content-Length
+func Malformed() Fault {
+	return rewrite(func(w http.ResponseWriter, code int, body []byte) {
+		body = body[:len(body)/2]
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(code)
+		w.Write(body)
+	})
+}
+
+// Truncate announces the whole body but closes the connection after its
+// first n bytes, the client gets an unexpected EOF
+func Truncate(n int) Fault {
+	return rewrite(func(w http.ResponseWriter, code int, body []byte) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(code)
+		if n >= len(body) {
+			w.Write(body)
+			return
+		}
+		w.Write(body[:n])
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		panic(http.ErrAbortHandler)
+	})
+}
+
+// TotalCount replaces the X-Total-Count header of the response with count
+func TotalCount(count int) Fault {
+	return func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		rec := serveRecorded(w, r, next)
+		w.Header().Set("X-Total-Count", strconv.Itoa(count))
+		w.WriteHeader(rec.Code)
+		w.Write(rec.Body.Bytes())
+	}
+}
+
+// Mutate calls fn once the request is served, i.e: to change the resources
+// between the HEAD and the GET requests of a listing
+func Mutate(fn func()) Fault {
+	return func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		next.ServeHTTP(w, r)
+		fn()
+	}
+}
+
+// serveRecorded serves the request into a recorder and copies the headers to w
+func serveRecorded(w http.ResponseWriter, r *http.Request, next http.Handler) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	next.ServeHTTP(rec, r)
+	for key, values := range rec.Header() {
+		w.Header()[key] = values
+	}
+	return rec
+}
+
+// rewrite lets write alter the body of the response, the responses without
+// body are left unchanged
+func rewrite(write func(w http.ResponseWriter, code int, body []byte)) Fault {
+	return func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		rec := serveRecorded(w, r, next)
+		if r.Method == http.MethodHead || rec.Body.Len() == 0 {
+			w.WriteHeader(rec.Code)
+			w.Write(rec.Body.Bytes())
+			return
+		}
+		write(w, rec.Code, rec.Body.Bytes())
+	}
+}
@@ -0,0 +1,296 @@
+package scwtest
+
+import (
+	"net/http"
+)
+
+type marketImage struct {
+	record
+	name        string
+	description string
+	logo        string
+	categories  []string
+	current     string
+	versions    []*marketVersion
+}
+
+type marketVersion struct {
+	record
+	name        string
+	localImages []marketLocalImage
+}
+
+type marketLocalImage struct {
+	id   string
+	arch string
+	zone string
+}
+
+// marketImageDefinition is the body of the marketplace write requests
+type marketImageDefinition struct {
+	Name                 string   `json:"name"`
+	Description          string   `json:"description"`
+	Logo                 string   `json:"logo"`
+	Categories           []string `json:"categories"`
+	CurrentPublicVersion string   `json:"current_public_version"`
+}
+
+type marketVersionDefinition struct {
+	Version struct {
+		Name string `json:"name"`
+	} `json:"version"`
+}
+
+type marketLocalImageDefinition struct {
+	LocalImage struct {
+		Arch string `json:"arch"`
+		Zone string `json:"zone"`
+	} `json:"local_image"`
+}
+
+func (s *Server) registerMarketplace() {
+	s.handle("GET", MarketplacePrefix, "images", false, s.listMarketImages)
+	s.handle("POST", MarketplacePrefix, "images", true, s.createMarketImage)
+	s.handle("GET", MarketplacePrefix, "images/{}", false, s.withMarketImage(s.getMarketImage))
+	s.handle("PUT", MarketplacePrefix, "images/{}", true, s.withMarketImage(s.putMarketImage))
+	s.handle("DELETE", MarketplacePrefix, "images/{}", true, s.withMarketImage(s.deleteMarketImage))
+	s.handle("GET", MarketplacePrefix, "images/{}/versions", false, s.withMarketImage(s.listMarketVersions))
+	s.handle("POST", MarketplacePrefix, "images/{}/versions", true, s.withMarketImage(s.createMarketVersion))
+	s.handle("GET", MarketplacePrefix, "images/{}/versions/{}", false, s.withMarketVersion(s.getMarketVersion))
+	s.handle("PUT", MarketplacePrefix, "images/{}/versions/{}", true, s.withMarketVersion(s.putMarketVersion))
+	s.handle("DELETE", MarketplacePrefix, "images/{}/versions/{}", true, s.withMarketVersion(s.deleteMarketVersion))
+	s.handle("GET", MarketplacePrefix, "images/{}/versions/{}/local_images", false, s.withMarketVersion(s.listMarketLocalImages))
+	s.handle("GET", MarketplacePrefix, "images/{}/versions/{}/local_images/{}", false, s.withMarketVersion(s.getMarketLocalImage))
+	s.handle("POST", MarketplacePrefix, "images/{}/versions/{}/local_images/{}", true, s.withMarketVersion(s.postMarketLocalImage))
+	s.handle("DELETE", MarketplacePrefix, "images/{}/versions/{}/local_images/{}", true, s.withMarketVersion(s.deleteMarketLocalImage))
+
+	s.handle("GET", AvailabilityPrefix, "availability.json", false, s.getAvailability)
+}
+
+func (s *Server) registerMetadata() {
+	s.handle("GET", MetadataPrefix, "user_data", false, s.withMetadataServer(s.listUserData))
+	s.handle("GET", MetadataPrefix, "user_data/{}", false, s.withMetadataServer(s.getUserData))
+	s.handle("PATCH", MetadataPrefix, "user_data/{}", false, s.withMetadataServer(s.patchUserData))
+	s.handle("DELETE", MetadataPrefix, "user_data/{}", false, s.withMetadataServer(s.deleteUserData))
+}
+
+// withMetadataServer passes the server set by SetMetadataServer to handler
+func (s *Server) withMetadataServer(handler func(w http.ResponseWriter, r *http.Request, server *instance, params []string)) func(w http.ResponseWriter, r *http.Request, params []string) {
+	return func(w http.ResponseWriter, r *http.Request, params []string) {
+		server, ok := s.servers[s.metadata]
+		if !ok {
+			writeError(w, http.StatusForbidden, "invalid_request_error", "The metadata API is only reachable from a server", nil)
+			return
+		}
+		handler(w, r, server, params)
+	}
+}
+
+func (s *Server) getAvailability(w http.ResponseWriter, r *http.Request, params []string) {
+	writeJSON(w, http.StatusOK, s.availability)
+}
+
+func renderMarketLocalImage(local marketLocalImage) map[string]interface{} {
+	return map[string]interface{}{"id": local.id, "arch": local.arch, "zone": local.zone}
+}
+
+func renderMarketVersion(image *marketImage, version *marketVersion) map[string]interface{} {
+	locals := []interface{}{}
+	for _, local := range version.localImages {
+		locals = append(locals, renderMarketLocalImage(local))
+	}
+	return version.dates(map[string]interface{}{
+		"name":         version.name,
+		"image":        map[string]interface{}{"id": image.id, "name": image.name},
+		"local_images": locals,
+	})
+}
+
+func renderMarketImage(image *marketImage) map[string]interface{} {
+	versions := []interface{}{}
+	for _, version := range image.versions {
+		versions = append(versions, renderMarketVersion(image, version))
+	}
+	return image.dates(map[string]interface{}{
+		"name":                   image.name,
+		"description":            image.description,
+		"logo":                   image.logo,
+		"categories":             append([]string{}, image.categories...),
+		"current_public_version": image.current,
+		"organization":           map[string]interface{}{"id": marketplaceOrganization, "name": "Marketplace"},
+		"versions":               versions,
+	})
+}
+
+func (s *Server) withMarketImage(handler func(w http.ResponseWriter, r *http.Request, image *marketImage, params []string)) func(w http.ResponseWriter, r *http.Request, params []string) {
+	return func(w http.ResponseWriter, r *http.Request, params []string) {
+		image, ok := s.market[params[0]]
+		if !ok {
+			notFound(w, "image", params[0])
+			return
+		}
+		handler(w, r, image, params[1:])
+	}
+}
+
+func (s *Server) withMarketVersion(handler func(w http.ResponseWriter, r *http.Request, image *marketImage, version *marketVersion, params []string)) func(w http.ResponseWriter, r *http.Request, params []string) {
+	return s.withMarketImage(func(w http.ResponseWriter, r *http.Request, image *marketImage, params []string) {
+		id := params[0]
+		if id == "current" {
+			id = image.current
+		}
+		for _, version := range image.versions {
+			if version.id == id {
+				handler(w, r, image, version, params[1:])
+				return
+			}
+		}
+		notFound(w, "version", params[0])
+	})
+}
+
+func (s *Server) listMarketImages(w http.ResponseWriter, r *http.Request, params []string) {
+	items := []interface{}{}
+	for _, image := range sorted(s.market) {
+		items = append(items, renderMarketImage(image))
+	}
+	writeList(w, r, "images", items)
+}
+
+// getMarketImage returns the image unwrapped, as GetMarketPlaceImages expects
+func (s *Server) getMarketImage(w http.ResponseWriter, r *http.Request, image *marketImage, params []string) {
+	writeJSON(w, http.StatusOK, renderMarketImage(image))
+}
+
+func (s *Server) createMarketImage(w http.ResponseWriter, r *http.Request, params []string) {
+	var definition marketImageDefinition
+	if !decode(w, r, &definition) {
+		return
+	}
+	if definition.Name == "" {
+		invalidField(w, "name", "required key not provided")
+		return
+	}
+	image := &marketImage{record: s.newRecord()}
+	updateMarketImage(image, definition)
+	s.market[image.id] = image
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{"image": renderMarketImage(image)})
+}
+
+func updateMarketImage(image *marketImage, definition marketImageDefinition) {
+	image.name = definition.Name
+	image.description = definition.Description
+	image.logo = definition.Logo
+	image.categories = definition.Categories
+	if definition.CurrentPublicVersion != "" {
+		image.current = definition.CurrentPublicVersion
+	}
+	image.touch()
+}
+
+func (s *Server) putMarketImage(w http.ResponseWriter, r *http.Request, image *marketImage, params []string) {
+	var definition marketImageDefinition
+	if !decode(w, r, &definition) {
+		return
+	}
+	updateMarketImage(image, definition)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"image": renderMarketImage(image)})
+}
+
+func (s *Server) deleteMarketImage(w http.ResponseWriter, r *http.Request, image *marketImage, params []string) {
+	delete(s.market, image.id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listMarketVersions(w http.ResponseWriter, r *http.Request, image *marketImage, params []string) {
+	items := []interface{}{}
+	for _, version := range image.versions {
+		items = append(items, renderMarketVersion(image, version))
+	}
+	writeList(w, r, "versions", items)
+}
+
+func (s *Server) getMarketVersion(w http.ResponseWriter, r *http.Request, image *marketImage, version *marketVersion, params []string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"version": renderMarketVersion(image, version)})
+}
+
+func (s *Server) createMarketVersion(w http.ResponseWriter, r *http.Request, image *marketImage, params []string) {
+	var definition marketVersionDefinition
+	if !decode(w, r, &definition) {
+		return
+	}
+	version := &marketVersion{record: s.newRecord(), name: definition.Version.Name}
+	image.versions = append(image.versions, version)
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{"version": renderMarketVersion(image, version)})
+}
+
+func (s *Server) putMarketVersion(w http.ResponseWriter, r *http.Request, image *marketImage, version *marketVersion, params []string) {
+	var definition marketVersionDefinition
+	if !decode(w, r, &definition) {
+		return
+	}
+	version.name = definition.Version.Name
+	version.touch()
+	writeJSON(w, http.StatusOK, map[string]interface{}{"version": renderMarketVersion(image, version)})
+}
+
+func (s *Server) deleteMarketVersion(w http.ResponseWriter, r *http.Request, image *marketImage, version *marketVersion, params []string) {
+	for i, v := range image.versions {
+		if v == version {
+			image.versions = append(image.versions[:i], image.versions[i+1:]...)
+			break
+		}
+	}
+	if image.current == version.id {
+		image.current = ""
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listMarketLocalImages(w http.ResponseWriter, r *http.Request, image *marketImage, version *marketVersion, params []string) {
+	items := []interface{}{}
+	for _, local := range version.localImages {
+		items = append(items, renderMarketLocalImage(local))
+	}
+	writeList(w, r, "local_images", items)
+}
+
+func (s *Server) getMarketLocalImage(w http.ResponseWriter, r *http.Request, image *marketImage, version *marketVersion, params []string) {
+	for _, local := range version.localImages {
+		if local.id == params[0] {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"local_image": renderMarketLocalImage(local)})
+			return
+		}
+	}
+	notFound(w, "local_image", params[0])
+}
+
+// postMarketLocalImage creates a local image, or updates the existing one
+func (s *Server) postMarketLocalImage(w http.ResponseWriter, r *http.Request, image *marketImage, version *marketVersion, params []string) {
+	var definition marketLocalImageDefinition
+	if !decode(w, r, &definition) {
+		return
+	}
+	local := marketLocalImage{id: params[0], arch: definition.LocalImage.Arch, zone: definition.LocalImage.Zone}
+	body := map[string]interface{}{"local_image": renderMarketLocalImage(local)}
+	for i := range version.localImages {
+		if version.localImages[i].id == local.id {
+			version.localImages[i] = local
+			writeJSON(w, http.StatusOK, body)
+			return
+		}
+	}
+	version.localImages = append(version.localImages, local)
+	writeJSON(w, http.StatusAccepted, body)
+}
+
+func (s *Server) deleteMarketLocalImage(w http.ResponseWriter, r *http.Request, image *marketImage, version *marketVersion, params []string) {
+	for i, local := range version.localImages {
+		if local.id == params[0] {
+			version.localImages = append(version.localImages[:i], version.localImages[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	notFound(w, "local_image", params[0])
+}
@@ -0,0 +1,397 @@
+package scwtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type ip struct {
+	record
+	address string
+	reverse *string
+	server  string
+}
+
+type securityGroup struct {
+	record
+	name                string
+	description         string
+	organizationDefault bool
+	rules               map[string]*rule
+}
+
+type rule struct {
+	record
+	action    string
+	direction string
+	ipRange   string
+	protocol  string
+	portFrom  int
+	position  int
+}
+
+func (s *Server) renderIP(ip *ip) map[string]interface{} {
+	out := map[string]interface{}{
+		"id":           ip.id,
+		"address":      ip.address,
+		"organization": s.Organization,
+		"reverse":      ip.reverse,
+		"server":       nil,
+	}
+	if server, ok := s.servers[ip.server]; ok {
+		out["server"] = map[string]interface{}{"id": server.id, "name": server.name}
+	}
+	return out
+}
+
+func (s *Server) listIPs(w http.ResponseWriter, r *http.Request, params []string) {
+	items := []interface{}{}
+	for _, ip := range sorted(s.ips) {
+		items = append(items, s.renderIP(ip))
+	}
+	writeList(w, r, "ips", items)
+}
+
+func (s *Server) getIP(w http.ResponseWriter, r *http.Request, params []string) {
+	ip, ok := s.ips[params[0]]
+	if !ok {
+		notFound(w, "ip", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"ip": s.renderIP(ip)})
+}
+
+func (s *Server) createIP(w http.ResponseWriter, r *http.Request, params []string) {
+	var definition struct {
+		Organization string `json:"organization"`
+	}
+	if !decode(w, r, &definition) {
+		return
+	}
+	if definition.Organization != s.Organization {
+		invalidField(w, "organization", "invalid organization")
+		return
+	}
+	if !s.quota(w, "ips", len(s.ips)) {
+		return
+	}
+	ip := &ip{
+		record:  s.newRecord(),
+		address: s.nextAddress(),
+	}
+	s.ips[ip.id] = ip
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"ip": s.renderIP(ip)})
+}
+
+// putIP updates the reverse of an IP and attaches it to a server, or detaches
+// it if server is null
+func (s *Server) putIP(w http.ResponseWriter, r *http.Request, params []string) {
+	ip, ok := s.ips[params[0]]
+	if !ok {
+		notFound(w, "ip", params[0])
+		return
+	}
+	var definition struct {
+		Reverse *string         `json:"reverse"`
+		Server  json.RawMessage `json:"server"`
+	}
+	if !decode(w, r, &definition) {
+		return
+	}
+	serverID := ""
+	if len(definition.Server) > 0 {
+		if serverID, ok = refID(definition.Server); !ok {
+			invalidField(w, "server", "invalid server")
+			return
+		}
+	}
+	if serverID != "" && serverID != ip.server {
+		server, ok := s.servers[serverID]
+		if !ok {
+			invalidField(w, "server", fmt.Sprintf("server %q not found", serverID))
+			return
+		}
+		if current, ok := s.ips[server.publicIP]; ok && current != ip {
+			invalid(w, "server already has a public IP")
+			return
+		}
+		s.detachIP(ip)
+		ip.server = server.id
+		server.publicIP = ip.id
+		server.dynamicAddress = ""
+		server.dynamicID = ""
+	} else if serverID == "" {
+		s.detachIP(ip)
+	}
+	ip.reverse = definition.Reverse
+	ip.touch()
+	writeJSON(w, http.StatusOK, map[string]interface{}{"ip": s.renderIP(ip)})
+}
+
+func (s *Server) detachIP(ip *ip) {
+	if server, ok := s.servers[ip.server]; ok {
+		server.publicIP = ""
+	}
+	ip.server = ""
+}
+
+func (s *Server) deleteIP(w http.ResponseWriter, r *http.Request, params []string) {
+	ip, ok := s.ips[params[0]]
+	if !ok {
+		notFound(w, "ip", params[0])
+		return
+	}
+	s.detachIP(ip)
+	delete(s.ips, ip.id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) renderSecurityGroup(group *securityGroup) map[string]interface{} {
+	servers := []interface{}{}
+	for _, server := range sorted(s.servers) {
+		if server.securityGroup == group.id {
+			servers = append(servers, map[string]interface{}{"id": server.id, "name": server.name})
+		}
+	}
+	return map[string]interface{}{
+		"id":                      group.id,
+		"name":                    group.name,
+		"description":             group.description,
+		"organization":            s.Organization,
+		"organization_default":    group.organizationDefault,
+		"enable_default_security": true,
+		"servers":                 servers,
+	}
+}
+
+func (s *Server) lookupSecurityGroup(w http.ResponseWriter, id string) (*securityGroup, bool) {
+	group, ok := s.groups[id]
+	if !ok {
+		notFound(w, "security_group", id)
+	}
+	return group, ok
+}
+
+func (s *Server) listSecurityGroups(w http.ResponseWriter, r *http.Request, params []string) {
+	items := []interface{}{}
+	for _, group := range sorted(s.groups) {
+		items = append(items, s.renderSecurityGroup(group))
+	}
+	writeList(w, r, "security_groups", items)
+}
+
+func (s *Server) getSecurityGroup(w http.ResponseWriter, r *http.Request, params []string) {
+	group, ok := s.lookupSecurityGroup(w, params[0])
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"security_group": s.renderSecurityGroup(group)})
+}
+
+func (s *Server) createSecurityGroup(w http.ResponseWriter, r *http.Request, params []string) {
+	var definition struct {
+		Organization string `json:"organization"`
+		Name         string `json:"name"`
+		Description  string `json:"description"`
+	}
+	if !decode(w, r, &definition) {
+		return
+	}
+	switch {
+	case definition.Name == "":
+		invalidField(w, "name", "required key not provided")
+		return
+	case definition.Organization != s.Organization:
+		invalidField(w, "organization", "invalid organization")
+		return
+	}
+	if !s.quota(w, "security_groups", len(s.groups)) {
+		return
+	}
+	group := &securityGroup{
+		record:      s.newRecord(),
+		name:        definition.Name,
+		description: definition.Description,
+		rules:       map[string]*rule{},
+	}
+	s.groups[group.id] = group
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"security_group": s.renderSecurityGroup(group)})
+}
+
+func (s *Server) putSecurityGroup(w http.ResponseWriter, r *http.Request, params []string) {
+	group, ok := s.lookupSecurityGroup(w, params[0])
+	if !ok {
+		return
+	}
+	var definition struct {
+		Organization        string `json:"organization"`
+		Name                string `json:"name"`
+		Description         string `json:"description"`
+		OrganizationDefault bool   `json:"organization_default"`
+	}
+	if !decode(w, r, &definition) {
+		return
+	}
+	if definition.Name == "" {
+		invalidField(w, "name", "required key not provided")
+		return
+	}
+	group.name = definition.Name
+	group.description = definition.Description
+	if definition.OrganizationDefault {
+		for _, other := range s.groups {
+			other.organizationDefault = false
+		}
+		s.DefaultSecurityGroup = group.id
+	}
+	group.organizationDefault = definition.OrganizationDefault || group.id == s.DefaultSecurityGroup
+	group.touch()
+	writeJSON(w, http.StatusOK, map[string]interface{}{"security_group": s.renderSecurityGroup(group)})
+}
+
+func (s *Server) deleteSecurityGroup(w http.ResponseWriter, r *http.Request, params []string) {
+	group, ok := s.lookupSecurityGroup(w, params[0])
+	if !ok {
+		return
+	}
+	if group.id == s.DefaultSecurityGroup {
+		invalid(w, "the default security group cannot be deleted")
+		return
+	}
+	for _, server := range s.servers {
+		if server.securityGroup == group.id {
+			invalid(w, fmt.Sprintf("group is in use by server %s", server.id))
+			return
+		}
+	}
+	delete(s.groups, group.id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func renderRule(rule *rule) map[string]interface{} {
+	out := map[string]interface{}{
+		"id":           rule.id,
+		"action":       rule.action,
+		"direction":    rule.direction,
+		"ip_range":     rule.ipRange,
+		"protocol":     rule.protocol,
+		"position":     rule.position,
+		"dest_port_to": nil,
+		"editable":     true,
+	}
+	if rule.portFrom != 0 {
+		out["dest_port_from"] = rule.portFrom
+	}
+	return out
+}
+
+func (s *Server) listRules(w http.ResponseWriter, r *http.Request, params []string) {
+	group, ok := s.lookupSecurityGroup(w, params[0])
+	if !ok {
+		return
+	}
+	items := []interface{}{}
+	for _, rule := range sorted(group.rules) {
+		items = append(items, renderRule(rule))
+	}
+	writeList(w, r, "rules", items)
+}
+
+func (s *Server) getRule(w http.ResponseWriter, r *http.Request, params []string) {
+	group, ok := s.lookupSecurityGroup(w, params[0])
+	if !ok {
+		return
+	}
+	rule, ok := group.rules[params[1]]
+	if !ok {
+		notFound(w, "rule", params[1])
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"rule": renderRule(rule)})
+}
+
+// decodeRule reads and validates a rule definition into rule
+func decodeRule(w http.ResponseWriter, r *http.Request, rule *rule) bool {
+	var definition struct {
+		Action       string `json:"action"`
+		Direction    string `json:"direction"`
+		IPRange      string `json:"ip_range"`
+		Protocol     string `json:"protocol"`
+		DestPortFrom int    `json:"dest_port_from"`
+	}
+	if !decode(w, r, &definition) {
+		return false
+	}
+	switch {
+	case definition.Action != "accept" && definition.Action != "drop":
+		invalidField(w, "action", "not a valid value")
+		return false
+	case definition.Direction != "inbound" && definition.Direction != "outbound":
+		invalidField(w, "direction", "not a valid value")
+		return false
+	case definition.Protocol != "TCP" && definition.Protocol != "UDP" && definition.Protocol != "ICMP":
+		invalidField(w, "protocol", "not a valid value")
+		return false
+	case definition.IPRange == "":
+		invalidField(w, "ip_range", "required key not provided")
+		return false
+	case definition.DestPortFrom < 0 || definition.DestPortFrom > 65535:
+		invalidField(w, "dest_port_from", "not a valid port")
+		return false
+	}
+	rule.action = definition.Action
+	rule.direction = definition.Direction
+	rule.ipRange = definition.IPRange
+	rule.protocol = definition.Protocol
+	rule.portFrom = definition.DestPortFrom
+	return true
+}
+
+func (s *Server) createRule(w http.ResponseWriter, r *http.Request, params []string) {
+	group, ok := s.lookupSecurityGroup(w, params[0])
+	if !ok {
+		return
+	}
+	rule := &rule{
+		record:   s.newRecord(),
+		position: len(group.rules) + 1,
+	}
+	if !decodeRule(w, r, rule) {
+		return
+	}
+	group.rules[rule.id] = rule
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"rule": renderRule(rule)})
+}
+
+func (s *Server) putRule(w http.ResponseWriter, r *http.Request, params []string) {
+	group, ok := s.lookupSecurityGroup(w, params[0])
+	if !ok {
+		return
+	}
+	rule, ok := group.rules[params[1]]
+	if !ok {
+		notFound(w, "rule", params[1])
+		return
+	}
+	updated := *rule
+	if !decodeRule(w, r, &updated) {
+		return
+	}
+	*rule = updated
+	rule.touch()
+	writeJSON(w, http.StatusOK, map[string]interface{}{"rule": renderRule(rule)})
+}
+
+func (s *Server) deleteRule(w http.ResponseWriter, r *http.Request, params []string) {
+	group, ok := s.lookupSecurityGroup(w, params[0])
+	if !ok {
+		return
+	}
+	if _, ok := group.rules[params[1]]; !ok {
+		notFound(w, "rule", params[1])
+		return
+	}
+	delete(group.rules, params[1])
+	w.WriteHeader(http.StatusNoContent)
+}
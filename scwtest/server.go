@@ -0,0 +1,562 @@
+// Package scwtest provides an in-memory fake of the  API, to test the SDK
+// without network access:
+//
+//	srv := scwtest.NewServer()
+//	defer srv.Close()
+//
+//	client, err := api.New(srv.Organization, srv.Token, "par1", api.WithEndpoints(api.Endpoints{
+//		Compute:      srv.ComputeURL(),
+//		Availability: srv.AvailabilityURL(),
+//		Account:      srv.AccountURL(),
+//		Marketplace:  srv.MarketplaceURL(),
+//		Metadata:     srv.MetadataURL(),
+//	}))
+//
+// The fake serves the compute, account, marketplace, availability and
+// metadata APIs under distinct path prefixes of the same httptest.Server.
+// Faults (slow responses, errors, malformed bodies...) are injected per route
+// and per call number with Inject.
+package scwtest
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultPerPage = 50
+	maxPerPage     = 100
+
+	// dateLayout is the layout of the dates returned by the API
+	dateLayout = "2006-01-02T15:04:05.000000+00:00"
+)
+
+// The prefixes of the APIs
+const (
+	ComputePrefix      = "/compute"
+	AccountPrefix      = "/account"
+	MarketplacePrefix  = "/marketplace"
+	AvailabilityPrefix = "/availability"
+	MetadataPrefix     = "/metadata"
+)
+
+// Server is a fake  API, its state is only kept in memory
+type Server struct {
+	*httptest.Server
+
+	// Organization, Token and UserID identify the account of the fake,
+	// requests authenticated with another token are denied
+	Organization string
+	Token        string
+	UserID       string
+
+	// Zone is the zone reported in the location of the servers
+	Zone string
+
+	// DefaultImage is a public x86_64 image, DefaultBootscript its bootscript
+	DefaultImage      string
+	DefaultBootscript string
+
+	// DefaultSecurityGroup is the default security group of the organization
+	DefaultSecurityGroup string
+
+	// Storage holds the exported snapshots, share it between servers to
+	// import the snapshots of one into another
+	Storage *ObjectStorage
+
+	// TransitionReads is the number of reads during which a server stays in a
+	// transient state (i.e: starting) after an action, 0 completes it at once
+	TransitionReads int
+
+	mu           sync.Mutex
+	seq          int
+	addresses    int
+	routes       []route
+	servers      map[string]*instance
+	volumes      map[string]*volume
+	snapshots    map[string]*snapshot
+	images       map[string]*image
+	ips          map[string]*ip
+	groups       map[string]*securityGroup
+	bootscripts  map[string]*bootscript
+	tasks        map[string]*task
+	market       map[string]*marketImage
+	user         *user
+	quotas       map[string]int
+	availability map[string]bool
+	metadata     string
+
+	faultsMu sync.Mutex
+	faults   []*injected
+}
+
+// NewServer starts a fake API seeded with an organization, its default
+// security group, a public image and bootscripts
+func NewServer() *Server {
+	s := &Server{
+		Organization: newID(),
+		Token:        newID(),
+		UserID:       newID(),
+		Zone:         "par1",
+		Storage:      NewObjectStorage(),
+
+		servers:     map[string]*instance{},
+		volumes:     map[string]*volume{},
+		snapshots:   map[string]*snapshot{},
+		images:      map[string]*image{},
+		ips:         map[string]*ip{},
+		groups:      map[string]*securityGroup{},
+		bootscripts: map[string]*bootscript{},
+		tasks:       map[string]*task{},
+		market:      map[string]*marketImage{},
+		quotas: map[string]int{
+			"servers":         10,
+			"volumes":         20,
+			"snapshots":       20,
+			"images":          10,
+			"ips":             10,
+			"security_groups": 10,
+		},
+		availability: map[string]bool{
+			"VC1S":      true,
+			"VC1M":      true,
+			"VC1L":      true,
+			"C2S":       true,
+			"C2M":       true,
+			"C2L":       true,
+			"C1":        true,
+			"ARM64-2GB": true,
+			"ARM64-4GB": true,
+		},
+	}
+	s.seed()
+	s.registerCompute()
+	s.registerAccount()
+	s.registerMarketplace()
+	s.registerMetadata()
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// ComputeURL returns the URL of the compute API
+func (s *Server) ComputeURL() string {
+	return s.URL + ComputePrefix
+}
+
+// AccountURL returns the URL of the account API
+func (s *Server) AccountURL() string {
+	return s.URL + AccountPrefix
+}
+
+// MarketplaceURL returns the URL of the marketplace API
+func (s *Server) MarketplaceURL() string {
+	return s.URL + MarketplacePrefix
+}
+
+// AvailabilityURL returns the URL of the availability API
+func (s *Server) AvailabilityURL() string {
+	return s.URL + AvailabilityPrefix
+}
+
+// MetadataURL returns the URL of the metadata API, see SetMetadataServer
+func (s *Server) MetadataURL() string {
+	return s.URL + MetadataPrefix
+}
+
+// SetQuota sets a quota of the organization (i.e: servers, volumes, ips)
+func (s *Server) SetQuota(name string, value int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.quotas[name] = value
+}
+
+// SetAvailability sets whether a commercial type can be created
+func (s *Server) SetAvailability(commercialType string, available bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.availability[commercialType] = available
+}
+
+// SetMetadataServer sets the server answering the metadata API, as if the
+// requests came from it
+func (s *Server) SetMetadataServer(serverID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.metadata = serverID
+}
+
+// AddServer creates a stopped server from DefaultImage and returns its identifier
+func (s *Server) AddServer(name, commercialType string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	image := s.images[s.DefaultImage]
+	root := s.snapshots[image.rootSnapshot]
+	volume := &volume{
+		record:     s.newRecord(),
+		name:       root.name,
+		size:       root.size,
+		volumeType: root.volumeType,
+	}
+	server := &instance{
+		record:         s.newRecord(),
+		name:           name,
+		commercialType: commercialType,
+		arch:           archOf(commercialType),
+		state:          "stopped",
+		image:          image.id,
+		bootscript:     image.bootscript,
+		securityGroup:  s.DefaultSecurityGroup,
+		volumes:        map[string]string{"0": volume.id},
+		userData:       map[string][]byte{},
+	}
+	volume.server = server.id
+	s.volumes[volume.id] = volume
+	s.servers[server.id] = server
+	return server.id
+}
+
+// AddVolume creates an unattached volume and returns its identifier
+func (s *Server) AddVolume(name string, size uint64) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	volume := &volume{
+		record:     s.newRecord(),
+		name:       name,
+		size:       size,
+		volumeType: "l_ssd",
+	}
+	s.volumes[volume.id] = volume
+	return volume.id
+}
+
+// Remove deletes a server, volume, snapshot, image or IP without any check,
+// and reports whether it existed
+func (s *Server) Remove(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	found := false
+	for _, m := range []interface{ remove(string) bool }{
+		resources[*instance](s.servers),
+		resources[*volume](s.volumes),
+		resources[*snapshot](s.snapshots),
+		resources[*image](s.images),
+		resources[*ip](s.ips),
+	} {
+		found = m.remove(id) || found
+	}
+	return found
+}
+
+// resources allows to remove a resource from any of the maps
+type resources[T any] map[string]T
+
+func (m resources[T]) remove(id string) bool {
+	_, ok := m[id]
+	delete(m, id)
+	return ok
+}
+
+// ServerState returns the state of a server, without advancing its
+// transitions, and whether it exists
+func (s *Server) ServerState(serverID string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	server, ok := s.servers[serverID]
+	if !ok {
+		return "", false
+	}
+	return server.state, true
+}
+
+// SetServerState forces the state of a server, i.e: "locked", canceling its
+// transition, and reports whether it exists
+func (s *Server) SetServerState(serverID, state, detail string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	server, ok := s.servers[serverID]
+	if !ok {
+		return false
+	}
+	server.transition = nil
+	server.state = state
+	server.stateDetail = detail
+	server.touch()
+	return true
+}
+
+// SetTaskStatus sets the status and the progress of a task, i.e: "failure",
+// and reports whether it exists
+func (s *Server) SetTaskStatus(taskID, status string, progress int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task, ok := s.tasks[taskID]
+	if !ok {
+		return false
+	}
+	task.status = status
+	task.progress = progress
+	task.terminated = time.Time{}
+	if status == "success" || status == "failure" {
+		task.terminated = time.Now().UTC()
+	}
+	task.touch()
+	return true
+}
+
+// route is an endpoint of the fake, i.e: GET /compute/servers/{}
+type route struct {
+	method   string
+	prefix   string
+	segments []string
+	auth     bool
+	handler  func(w http.ResponseWriter, r *http.Request, params []string)
+}
+
+// handle registers an endpoint, {} in pattern matches any segment and is
+// passed to handler
+func (s *Server) handle(method, prefix, pattern string, auth bool, handler func(w http.ResponseWriter, r *http.Request, params []string)) {
+	s.routes = append(s.routes, route{
+		method:   method,
+		prefix:   prefix,
+		segments: splitPath(pattern),
+		auth:     auth,
+		handler:  handler,
+	})
+}
+
+// match returns the parameters of path if it matches the route
+func (rt route) match(segments []string) ([]string, bool) {
+	if len(segments) != len(rt.segments) {
+		return nil, false
+	}
+	var params []string
+	for i, segment := range rt.segments {
+		switch segment {
+		case "{}":
+			params = append(params, segments[i])
+		case segments[i]:
+		default:
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// splitPath returns the non-empty segments of a path, the SDK sends paths
+// with double or trailing slashes
+func splitPath(path string) []string {
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// ServeHTTP serves the requests, through the injected faults
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	chain(s.matchFaults(r), http.HandlerFunc(s.dispatch)).ServeHTTP(w, r)
+}
+
+// dispatch sends the requests to the endpoints, HEAD is served as GET
+func (s *Server) dispatch(w http.ResponseWriter, r *http.Request) {
+	segments := splitPath(r.URL.Path)
+	if len(segments) == 0 {
+		writeError(w, http.StatusNotFound, "unknown_resource", "Not found", nil)
+		return
+	}
+	prefix, segments := "/"+segments[0], segments[1:]
+	method := r.Method
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+
+	allowed := false
+	for _, rt := range s.routes {
+		if rt.prefix != prefix {
+			continue
+		}
+		params, ok := rt.match(segments)
+		if !ok {
+			continue
+		}
+		if rt.method != method {
+			allowed = true
+			continue
+		}
+		if rt.auth && r.Header.Get("X-Auth-Token") != s.Token {
+			writeError(w, http.StatusUnauthorized, "denied_authentication", "Invalid token", nil)
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		rt.handler(w, r, params)
+		return
+	}
+	if allowed {
+		writeError(w, http.StatusMethodNotAllowed, "invalid_request_error", "Method not allowed", nil)
+		return
+	}
+	writeError(w, http.StatusNotFound, "unknown_resource", "Not found", nil)
+}
+
+// record holds the fields shared by the resources
+type record struct {
+	id       string
+	seq      int
+	created  time.Time
+	modified time.Time
+}
+
+func (s *Server) newRecord() record {
+	s.seq++
+	now := time.Now().UTC()
+	return record{
+		id:       newID(),
+		seq:      s.seq,
+		created:  now,
+		modified: now,
+	}
+}
+
+// touch updates the modification date, each update moves it forward
+func (r *record) touch() {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	if !now.After(r.modified) {
+		now = r.modified.Add(time.Microsecond)
+	}
+	r.modified = now
+}
+
+func (r *record) dates(out map[string]interface{}) map[string]interface{} {
+	out["id"] = r.id
+	out["creation_date"] = r.created.Format(dateLayout)
+	out["modification_date"] = r.modified.Format(dateLayout)
+	return out
+}
+
+type sequenced interface {
+	sequence() int
+}
+
+func (r *record) sequence() int {
+	return r.seq
+}
+
+// sorted returns the values of m in creation order
+func sorted[T sequenced](m map[string]T) []T {
+	values := make([]T, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].sequence() < values[j].sequence()
+	})
+	return values
+}
+
+// newID returns a random UUID
+func newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// nextAddress returns an unused public IPv4 address
+func (s *Server) nextAddress() string {
+	s.addresses++
+	return fmt.Sprintf("51.15.%d.%d", s.addresses/250, s.addresses%250+1)
+}
+
+// quota checks the quota name against the current count, and writes an error
+// if it is exceeded
+func (s *Server) quota(w http.ResponseWriter, name string, count int) bool {
+	if limit, ok := s.quotas[name]; ok && count >= limit {
+		writeError(w, http.StatusForbidden, "quotas_exceeded", fmt.Sprintf("Quota exceeded for %s: %d", name, limit), nil)
+		return false
+	}
+	return true
+}
+
+// writeList writes a page of items, according to the page and per_page
+// parameters, with the total in X-Total-Count
+func writeList(w http.ResponseWriter, r *http.Request, key string, items []interface{}) {
+	page, perPage := 1, defaultPerPage
+	for name, dst := range map[string]*int{"page": &page, "per_page": &perPage} {
+		value := r.URL.Query().Get(name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || (name == "per_page" && n > maxPerPage) {
+			writeError(w, http.StatusBadRequest, "invalid_request_error", "Validation Error", map[string][]string{
+				name: {"invalid value"},
+			})
+			return
+		}
+		*dst = n
+	}
+	start := (page - 1) * perPage
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + perPage
+	if end > len(items) {
+		end = len(items)
+	}
+	w.Header().Set("X-Total-Count", strconv.Itoa(len(items)))
+	writeJSON(w, http.StatusOK, map[string]interface{}{key: items[start:end]})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, kind, message string, fields map[string][]string) {
+	body := map[string]interface{}{
+		"type":    kind,
+		"message": message,
+	}
+	if len(fields) > 0 {
+		body["fields"] = fields
+	}
+	writeJSON(w, status, body)
+}
+
+func notFound(w http.ResponseWriter, kind, id string) {
+	writeError(w, http.StatusNotFound, "unknown_resource", fmt.Sprintf("%s %q not found", kind, id), nil)
+}
+
+func invalid(w http.ResponseWriter, message string) {
+	writeError(w, http.StatusBadRequest, "invalid_request_error", message, nil)
+}
+
+func invalidField(w http.ResponseWriter, field, message string) {
+	writeError(w, http.StatusBadRequest, "invalid_request_error", "Validation Error", map[string][]string{
+		field: {message},
+	})
+}
+
+// decode reads the JSON body of r, and writes an error if it is malformed
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		invalid(w, fmt.Sprintf("Invalid JSON: %v", err))
+		return false
+	}
+	return true
+}
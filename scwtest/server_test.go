@@ -0,0 +1,104 @@
+package scwtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func get(t *testing.T, srv *Server, path string) (*http.Response, map[string][]json.RawMessage) {
+	t.Helper()
+	req, err := http.NewRequest("GET", srv.URL+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Auth-Token", srv.Token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var body map[string][]json.RawMessage
+	json.NewDecoder(resp.Body).Decode(&body)
+	return resp, body
+}
+
+func TestServer_pagination(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	for i := 0; i < 7; i++ {
+		req, _ := http.NewRequest("POST", srv.ComputeURL()+"/ips", strings.NewReader(`{"organization": "`+srv.Organization+`"}`))
+		req.Header.Set("X-Auth-Token", srv.Token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("expected 201, got %d", resp.StatusCode)
+		}
+	}
+
+	resp, body := get(t, srv, ComputePrefix+"/ips?per_page=3&page=3")
+	if total := resp.Header.Get("X-Total-Count"); total != "7" {
+		t.Errorf("expected X-Total-Count 7, got %q", total)
+	}
+	if len(body["ips"]) != 1 {
+		t.Errorf("expected 1 IP on the last page, got %d", len(body["ips"]))
+	}
+	if resp, _ = get(t, srv, ComputePrefix+"/ips?per_page=1000"); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 for a too large page, got %d", resp.StatusCode)
+	}
+}
+
+func TestServer_errors(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	for path, status := range map[string]int{
+		ComputePrefix + "/servers":                 http.StatusOK,
+		ComputePrefix + "/servers/unknown":         http.StatusNotFound,
+		ComputePrefix + "/unknown":                 http.StatusNotFound,
+		AvailabilityPrefix + "//availability.json": http.StatusOK,
+		MetadataPrefix + "/user_data":              http.StatusForbidden,
+	} {
+		if resp, _ := get(t, srv, path); resp.StatusCode != status {
+			t.Errorf("%s: expected %d, got %d", path, status, resp.StatusCode)
+		}
+	}
+
+	resp, err := http.Get(srv.ComputeURL() + "/servers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 without token, got %d", resp.StatusCode)
+	}
+}
+
+func TestServer_faults(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.Inject(Rule{Method: "GET", Path: ComputePrefix + "/servers/{}", Calls: []int{2}, Fault: Status(http.StatusBadGateway)})
+
+	path := ComputePrefix + "/servers/" + srv.AddServer("web", "VC1S")
+	for i, want := range []int{http.StatusOK, http.StatusBadGateway, http.StatusOK} {
+		if resp, _ := get(t, srv, path); resp.StatusCode != want {
+			t.Errorf("call %d: expected %d, got %d", i+1, want, resp.StatusCode)
+		}
+	}
+	if resp, _ := get(t, srv, ComputePrefix+"/servers"); resp.StatusCode != http.StatusOK {
+		t.Errorf("expected the other routes to be unaffected, got %d", resp.StatusCode)
+	}
+	if calls := srv.Calls("GET", ComputePrefix+"/servers/{}"); calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+
+	srv.ResetFaults()
+	srv.Inject(Rule{Fault: TotalCount(42)})
+	if resp, _ := get(t, srv, ComputePrefix+"/volumes"); resp.Header.Get("X-Total-Count") != "42" {
+		t.Errorf("expected X-Total-Count 42, got %q", resp.Header.Get("X-Total-Count"))
+	}
+}
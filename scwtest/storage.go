@@ -0,0 +1,485 @@
+package scwtest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// marketplaceOrganization owns the public images
+const marketplaceOrganization = "abaf8b3f-b0d3-4c9c-a8a5-0f4ebc25e7f0"
+
+type volume struct {
+	record
+	name       string
+	size       uint64
+	volumeType string
+	server     string
+}
+
+type snapshot struct {
+	record
+	name         string
+	size         uint64
+	volumeType   string
+	state        string
+	organization string
+	baseVolume   string
+}
+
+// ObjectStorage is a fake Object Storage holding the exported snapshots,
+// servers sharing it import the snapshots exported by each other
+type ObjectStorage struct {
+	mu      sync.Mutex
+	objects map[string]*snapshot
+}
+
+// NewObjectStorage returns an empty ObjectStorage
+func NewObjectStorage() *ObjectStorage {
+	return &ObjectStorage{objects: map[string]*snapshot{}}
+}
+
+// Objects returns the keys of the objects of bucket, sorted
+func (o *ObjectStorage) Objects(bucket string) []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	keys := []string{}
+	for path := range o.objects {
+		if strings.HasPrefix(path, bucket+"/") {
+			keys = append(keys, strings.TrimPrefix(path, bucket+"/"))
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (o *ObjectStorage) put(bucket, key string, snapshot snapshot) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.objects[bucket+"/"+key] = &snapshot
+}
+
+func (o *ObjectStorage) get(bucket, key string) (snapshot, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	object, ok := o.objects[bucket+"/"+key]
+	if !ok {
+		return snapshot{}, false
+	}
+	return *object, true
+}
+
+type image struct {
+	record
+	name         string
+	arch         string
+	organization string
+	bootscript   string
+	rootSnapshot string
+	public       bool
+}
+
+func (s *Server) renderVolume(volume *volume) map[string]interface{} {
+	out := map[string]interface{}{
+		"name":         volume.name,
+		"size":         volume.size,
+		"volume_type":  volume.volumeType,
+		"organization": s.Organization,
+		"export_uri":   nil,
+		"server":       nil,
+	}
+	if server, ok := s.servers[volume.server]; ok {
+		out["server"] = map[string]interface{}{"id": server.id, "name": server.name}
+		if server.privateIP != "" {
+			out["export_uri"] = fmt.Sprintf("nbd://%s:4096", server.privateIP)
+		}
+	}
+	return volume.dates(out)
+}
+
+func (s *Server) listVolumes(w http.ResponseWriter, r *http.Request, params []string) {
+	items := []interface{}{}
+	for _, volume := range sorted(s.volumes) {
+		items = append(items, s.renderVolume(volume))
+	}
+	writeList(w, r, "volumes", items)
+}
+
+func (s *Server) getVolume(w http.ResponseWriter, r *http.Request, params []string) {
+	volume, ok := s.volumes[params[0]]
+	if !ok {
+		notFound(w, "volume", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"volume": s.renderVolume(volume)})
+}
+
+func (s *Server) createVolume(w http.ResponseWriter, r *http.Request, params []string) {
+	var definition struct {
+		Name         string `json:"name"`
+		Size         uint64 `json:"size"`
+		Type         string `json:"volume_type"`
+		Organization string `json:"organization"`
+		BaseSnapshot string `json:"base_snapshot"`
+	}
+	if !decode(w, r, &definition) {
+		return
+	}
+	if definition.BaseSnapshot != "" {
+		snapshot, ok := s.snapshots[definition.BaseSnapshot]
+		if !ok || snapshot.organization != s.Organization {
+			invalidField(w, "base_snapshot", fmt.Sprintf("snapshot %q not found", definition.BaseSnapshot))
+			return
+		}
+		if definition.Size == 0 {
+			definition.Size = snapshot.size
+		}
+	}
+	switch {
+	case definition.Name == "":
+		invalidField(w, "name", "required key not provided")
+		return
+	case definition.Organization != s.Organization:
+		invalidField(w, "organization", "invalid organization")
+		return
+	case definition.Size == 0:
+		invalidField(w, "size", "required key not provided")
+		return
+	case definition.Type != "l_ssd" && definition.Type != "l_hdd":
+		invalidField(w, "volume_type", "not a valid value")
+		return
+	}
+	if !s.quota(w, "volumes", len(s.volumes)) {
+		return
+	}
+	volume := &volume{
+		record:     s.newRecord(),
+		name:       definition.Name,
+		size:       definition.Size,
+		volumeType: definition.Type,
+	}
+	s.volumes[volume.id] = volume
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"volume": s.renderVolume(volume)})
+}
+
+func (s *Server) putVolume(w http.ResponseWriter, r *http.Request, params []string) {
+	volume, ok := s.volumes[params[0]]
+	if !ok {
+		notFound(w, "volume", params[0])
+		return
+	}
+	var definition struct {
+		Name *string `json:"name"`
+	}
+	if !decode(w, r, &definition) {
+		return
+	}
+	if definition.Name != nil {
+		volume.name = *definition.Name
+	}
+	volume.touch()
+	writeJSON(w, http.StatusOK, map[string]interface{}{"volume": s.renderVolume(volume)})
+}
+
+func (s *Server) deleteVolume(w http.ResponseWriter, r *http.Request, params []string) {
+	volume, ok := s.volumes[params[0]]
+	if !ok {
+		notFound(w, "volume", params[0])
+		return
+	}
+	if volume.server != "" {
+		invalid(w, "a server is attached to this volume")
+		return
+	}
+	delete(s.volumes, volume.id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) renderSnapshot(snapshot *snapshot) map[string]interface{} {
+	out := map[string]interface{}{
+		"name":         snapshot.name,
+		"size":         snapshot.size,
+		"volume_type":  snapshot.volumeType,
+		"state":        snapshot.state,
+		"organization": snapshot.organization,
+		"base_volume":  nil,
+	}
+	if volume, ok := s.volumes[snapshot.baseVolume]; ok {
+		out["base_volume"] = map[string]interface{}{"id": volume.id, "name": volume.name}
+	}
+	return snapshot.dates(out)
+}
+
+// countSnapshots returns the number of snapshots of the organization
+func (s *Server) countSnapshots() int {
+	count := 0
+	for _, snapshot := range s.snapshots {
+		if snapshot.organization == s.Organization {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *Server) newSnapshot(volume *volume, name string) *snapshot {
+	snapshot := &snapshot{
+		record:       s.newRecord(),
+		name:         name,
+		size:         volume.size,
+		volumeType:   volume.volumeType,
+		state:        "available",
+		organization: s.Organization,
+		baseVolume:   volume.id,
+	}
+	s.snapshots[snapshot.id] = snapshot
+	return snapshot
+}
+
+func (s *Server) listSnapshots(w http.ResponseWriter, r *http.Request, params []string) {
+	items := []interface{}{}
+	for _, snapshot := range sorted(s.snapshots) {
+		if snapshot.organization == s.Organization {
+			items = append(items, s.renderSnapshot(snapshot))
+		}
+	}
+	writeList(w, r, "snapshots", items)
+}
+
+func (s *Server) getSnapshot(w http.ResponseWriter, r *http.Request, params []string) {
+	snapshot, ok := s.snapshots[params[0]]
+	if !ok {
+		notFound(w, "snapshot", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"snapshot": s.renderSnapshot(snapshot)})
+}
+
+func (s *Server) createSnapshot(w http.ResponseWriter, r *http.Request, params []string) {
+	var definition struct {
+		VolumeID     string `json:"volume_id"`
+		Name         string `json:"name"`
+		Organization string `json:"organization"`
+		Bucket       string `json:"bucket"`
+		Key          string `json:"key"`
+	}
+	if !decode(w, r, &definition) {
+		return
+	}
+	if definition.Organization != s.Organization {
+		invalidField(w, "organization", "invalid organization")
+		return
+	}
+	if definition.Bucket != "" {
+		s.importSnapshot(w, definition.Bucket, definition.Key, definition.Name)
+		return
+	}
+	volume, ok := s.volumes[definition.VolumeID]
+	if !ok {
+		invalidField(w, "volume_id", fmt.Sprintf("volume %q not found", definition.VolumeID))
+		return
+	}
+	if !s.quota(w, "snapshots", s.countSnapshots()) {
+		return
+	}
+	name := definition.Name
+	if name == "" {
+		name = volume.name
+	}
+	snapshot := s.newSnapshot(volume, name)
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"snapshot": s.renderSnapshot(snapshot)})
+}
+
+// importSnapshot creates a snapshot from an object of the ObjectStorage
+func (s *Server) importSnapshot(w http.ResponseWriter, bucket, key, name string) {
+	object, ok := s.Storage.get(bucket, key)
+	if !ok {
+		invalidField(w, "key", fmt.Sprintf("object %q not found in bucket %q", key, bucket))
+		return
+	}
+	if name == "" {
+		invalidField(w, "name", "required key not provided")
+		return
+	}
+	if !s.quota(w, "snapshots", s.countSnapshots()) {
+		return
+	}
+	snapshot := &snapshot{
+		record:       s.newRecord(),
+		name:         name,
+		size:         object.size,
+		volumeType:   object.volumeType,
+		state:        "available",
+		organization: s.Organization,
+	}
+	s.snapshots[snapshot.id] = snapshot
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"snapshot": s.renderSnapshot(snapshot)})
+}
+
+// exportSnapshot copies a snapshot to the ObjectStorage, its task completes at once
+func (s *Server) exportSnapshot(w http.ResponseWriter, r *http.Request, params []string) {
+	var definition struct {
+		Bucket string `json:"bucket"`
+		Key    string `json:"key"`
+	}
+	if !decode(w, r, &definition) {
+		return
+	}
+	snapshot, ok := s.snapshots[params[0]]
+	if !ok || snapshot.organization != s.Organization {
+		notFound(w, "snapshot", params[0])
+		return
+	}
+	switch {
+	case definition.Bucket == "":
+		invalidField(w, "bucket", "required key not provided")
+		return
+	case definition.Key == "":
+		invalidField(w, "key", "required key not provided")
+		return
+	}
+	s.Storage.put(definition.Bucket, definition.Key, *snapshot)
+	task := s.newTask("export_snapshot", "/snapshots/"+snapshot.id)
+	s.completeTask(task.id)
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{"task": renderTask(task)})
+}
+
+func (s *Server) deleteSnapshot(w http.ResponseWriter, r *http.Request, params []string) {
+	snapshot, ok := s.snapshots[params[0]]
+	if !ok {
+		notFound(w, "snapshot", params[0])
+		return
+	}
+	if snapshot.organization != s.Organization {
+		writeError(w, http.StatusForbidden, "invalid_request_error", "Forbidden", nil)
+		return
+	}
+	for _, image := range s.images {
+		if image.rootSnapshot == snapshot.id {
+			invalid(w, fmt.Sprintf("snapshot is used by image %s", image.id))
+			return
+		}
+	}
+	delete(s.snapshots, snapshot.id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) renderImage(image *image) map[string]interface{} {
+	out := map[string]interface{}{
+		"name":               image.name,
+		"arch":               image.arch,
+		"organization":       image.organization,
+		"public":             image.public,
+		"default_bootscript": nil,
+		"root_volume":        nil,
+	}
+	if root, ok := s.snapshots[image.rootSnapshot]; ok {
+		out["root_volume"] = map[string]interface{}{
+			"id":          root.id,
+			"name":        root.name,
+			"size":        root.size,
+			"volume_type": root.volumeType,
+		}
+	}
+	if bootscript, ok := s.bootscripts[image.bootscript]; ok {
+		out["default_bootscript"] = renderBootscript(bootscript)
+	}
+	return image.dates(out)
+}
+
+// countImages returns the number of images of the organization
+func (s *Server) countImages() int {
+	count := 0
+	for _, image := range s.images {
+		if image.organization == s.Organization {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *Server) listImages(w http.ResponseWriter, r *http.Request, params []string) {
+	query := r.URL.Query()
+	items := []interface{}{}
+	for _, image := range sorted(s.images) {
+		if organization := query.Get("organization"); organization != "" && image.organization != organization {
+			continue
+		}
+		if arch := query.Get("arch"); arch != "" && image.arch != arch {
+			continue
+		}
+		items = append(items, s.renderImage(image))
+	}
+	writeList(w, r, "images", items)
+}
+
+func (s *Server) getImage(w http.ResponseWriter, r *http.Request, params []string) {
+	image, ok := s.images[params[0]]
+	if !ok {
+		notFound(w, "image", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"image": s.renderImage(image)})
+}
+
+func (s *Server) createImage(w http.ResponseWriter, r *http.Request, params []string) {
+	var definition struct {
+		RootVolume        string  `json:"root_volume"`
+		Name              string  `json:"name"`
+		Organization      string  `json:"organization"`
+		Arch              string  `json:"arch"`
+		DefaultBootscript *string `json:"default_bootscript"`
+	}
+	if !decode(w, r, &definition) {
+		return
+	}
+	switch {
+	case definition.Name == "":
+		invalidField(w, "name", "required key not provided")
+		return
+	case definition.Organization != s.Organization:
+		invalidField(w, "organization", "invalid organization")
+		return
+	case definition.Arch == "":
+		invalidField(w, "arch", "required key not provided")
+		return
+	}
+	if _, ok := s.snapshots[definition.RootVolume]; !ok {
+		invalidField(w, "root_volume", fmt.Sprintf("snapshot %q not found", definition.RootVolume))
+		return
+	}
+	image := &image{
+		record:       s.newRecord(),
+		name:         definition.Name,
+		arch:         definition.Arch,
+		organization: s.Organization,
+		rootSnapshot: definition.RootVolume,
+	}
+	if definition.DefaultBootscript != nil && *definition.DefaultBootscript != "" {
+		if _, ok := s.bootscripts[*definition.DefaultBootscript]; !ok {
+			invalidField(w, "default_bootscript", fmt.Sprintf("bootscript %q not found", *definition.DefaultBootscript))
+			return
+		}
+		image.bootscript = *definition.DefaultBootscript
+	}
+	if !s.quota(w, "images", s.countImages()) {
+		return
+	}
+	s.images[image.id] = image
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"image": s.renderImage(image)})
+}
+
+func (s *Server) deleteImage(w http.ResponseWriter, r *http.Request, params []string) {
+	image, ok := s.images[params[0]]
+	if !ok {
+		notFound(w, "image", params[0])
+		return
+	}
+	if image.organization != s.Organization {
+		writeError(w, http.StatusForbidden, "invalid_request_error", "Forbidden", nil)
+		return
+	}
+	delete(s.images, image.id)
+	w.WriteHeader(http.StatusNoContent)
+}
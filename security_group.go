@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -54,7 +55,15 @@ type UpdateSecurityGroup struct {
 
 // DeleteSecurityGroup deletes a SecurityGroup
 func (s *API) DeleteSecurityGroup(securityGroupID string) error {
-	resp, err := s.DeleteResponse(s.computeAPI, fmt.Sprintf("security_groups/%s", securityGroupID))
+	return s.DeleteSecurityGroupContext(context.Background(), securityGroupID)
+}
+
+// DeleteSecurityGroupContext is like DeleteSecurityGroup but uses ctx for the underlying requests
+func (s *API) DeleteSecurityGroupContext(ctx context.Context, securityGroupID string) (err error) {
+	ctx, op := s.startOperation(ctx, "DeleteSecurityGroup", "security_group", securityGroupID)
+	defer op.End(&err)
+
+	resp, err := s.DeleteResponseContext(ctx, s.computeAPI, fmt.Sprintf("security_groups/%s", securityGroupID))
 	if err != nil {
 		return err
 	}
@@ -66,7 +75,15 @@ func (s *API) DeleteSecurityGroup(securityGroupID string) error {
 
 // PutSecurityGroup updates a SecurityGroup
 func (s *API) PutSecurityGroup(group UpdateSecurityGroup, securityGroupID string) error {
-	resp, err := s.PutResponse(s.computeAPI, fmt.Sprintf("security_groups/%s", securityGroupID), group)
+	return s.PutSecurityGroupContext(context.Background(), group, securityGroupID)
+}
+
+// PutSecurityGroupContext is like PutSecurityGroup but uses ctx for the underlying requests
+func (s *API) PutSecurityGroupContext(ctx context.Context, group UpdateSecurityGroup, securityGroupID string) (err error) {
+	ctx, op := s.startOperation(ctx, "PutSecurityGroup", "security_group", securityGroupID)
+	defer op.End(&err)
+
+	resp, err := s.PutResponseContext(ctx, s.computeAPI, fmt.Sprintf("security_groups/%s", securityGroupID), group)
 	if err != nil {
 		return err
 	}
@@ -78,7 +95,15 @@ func (s *API) PutSecurityGroup(group UpdateSecurityGroup, securityGroupID string
 
 // GetASecurityGroup returns a SecurityGroup
 func (s *API) GetASecurityGroup(groupsID string) (*GetSecurityGroup, error) {
-	resp, err := s.GetResponsePaginate(s.computeAPI, fmt.Sprintf("security_groups/%s", groupsID), url.Values{})
+	return s.GetASecurityGroupContext(context.Background(), groupsID)
+}
+
+// GetASecurityGroupContext is like GetASecurityGroup but uses ctx for the underlying requests
+func (s *API) GetASecurityGroupContext(ctx context.Context, groupsID string) (_ *GetSecurityGroup, err error) {
+	ctx, op := s.startOperation(ctx, "GetASecurityGroup", "security_group", groupsID)
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.computeAPI, fmt.Sprintf("security_groups/%s", groupsID), url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -98,7 +123,15 @@ func (s *API) GetASecurityGroup(groupsID string) (*GetSecurityGroup, error) {
 
 // PostSecurityGroup posts a group on a server
 func (s *API) PostSecurityGroup(group NewSecurityGroup) error {
-	resp, err := s.PostResponse(s.computeAPI, "security_groups", group)
+	return s.PostSecurityGroupContext(context.Background(), group)
+}
+
+// PostSecurityGroupContext is like PostSecurityGroup but uses ctx for the underlying requests
+func (s *API) PostSecurityGroupContext(ctx context.Context, group NewSecurityGroup) (err error) {
+	ctx, op := s.startOperation(ctx, "PostSecurityGroup", "security_group", "")
+	defer op.End(&err)
+
+	resp, err := s.PostResponseContext(ctx, s.computeAPI, "security_groups", group)
 	if err != nil {
 		return err
 	}
@@ -110,7 +143,15 @@ func (s *API) PostSecurityGroup(group NewSecurityGroup) error {
 
 // GetSecurityGroups returns a SecurityGroups
 func (s *API) GetSecurityGroups() (*GetSecurityGroups, error) {
-	resp, err := s.GetResponsePaginate(s.computeAPI, "security_groups", url.Values{})
+	return s.GetSecurityGroupsContext(context.Background())
+}
+
+// GetSecurityGroupsContext is like GetSecurityGroups but uses ctx for the underlying requests
+func (s *API) GetSecurityGroupsContext(ctx context.Context) (_ *GetSecurityGroups, err error) {
+	ctx, op := s.startOperation(ctx, "GetSecurityGroups", "security_group", "")
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.computeAPI, "security_groups", url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -127,3 +168,14 @@ func (s *API) GetSecurityGroups() (*GetSecurityGroups, error) {
 	}
 	return &securityGroups, nil
 }
+
+// ListSecurityGroupsIter returns an iterator over the security groups
+func (s *API) ListSecurityGroupsIter(ctx context.Context) *Iterator[SecurityGroups] {
+	return newIterator[SecurityGroups](ctx, s, listing{
+		operation:    "ListSecurityGroupsIter",
+		resourceType: "security_group",
+		apiURL:       s.computeAPI,
+		resource:     "security_groups",
+		key:          "security_groups",
+	}, nil)
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -20,17 +21,17 @@ type GroupRule struct {
 	ID           string `json:"id"`
 }
 
-// GetGroupRules represents the response of a GET /_group/{groupID}/rules
+// GetGroupRules represents the response of a GET /security_groups/{groupID}/rules
 type GetGroupRules struct {
 	Rules []GroupRule `json:"rules"`
 }
 
-// GetGroupRule represents the response of a GET /_group/{groupID}/rules/{ruleID}
+// GetGroupRule represents the response of a GET /security_groups/{groupID}/rules/{ruleID}
 type GetGroupRule struct {
 	Rules GroupRule `json:"rule"`
 }
 
-// NewGroupRule definition POST/PUT request /_group/{groupID}
+// NewGroupRule definition POST/PUT request /security_groups/{groupID}/rules
 type NewGroupRule struct {
 	Action       string `json:"action"`
 	Direction    string `json:"direction"`
@@ -41,7 +42,15 @@ type NewGroupRule struct {
 
 // GetGroupRules returns a GroupRules
 func (s *API) GetGroupRules(groupID string) (*GetGroupRules, error) {
-	resp, err := s.GetResponsePaginate(s.computeAPI, fmt.Sprintf("_groups/%s/rules", groupID), url.Values{})
+	return s.GetGroupRulesContext(context.Background(), groupID)
+}
+
+// GetGroupRulesContext is like GetGroupRules but uses ctx for the underlying requests
+func (s *API) GetGroupRulesContext(ctx context.Context, groupID string) (_ *GetGroupRules, err error) {
+	ctx, op := s.startOperation(ctx, "GetGroupRules", "security_group_rule", "")
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.computeAPI, fmt.Sprintf("security_groups/%s/rules", groupID), url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +70,15 @@ func (s *API) GetGroupRules(groupID string) (*GetGroupRules, error) {
 
 // GetAGroupRule returns a GroupRule
 func (s *API) GetAGroupRule(groupID string, rulesID string) (*GetGroupRule, error) {
-	resp, err := s.GetResponsePaginate(s.computeAPI, fmt.Sprintf("_groups/%s/rules/%s", groupID, rulesID), url.Values{})
+	return s.GetAGroupRuleContext(context.Background(), groupID, rulesID)
+}
+
+// GetAGroupRuleContext is like GetAGroupRule but uses ctx for the underlying requests
+func (s *API) GetAGroupRuleContext(ctx context.Context, groupID string, rulesID string) (_ *GetGroupRule, err error) {
+	ctx, op := s.startOperation(ctx, "GetAGroupRule", "security_group_rule", rulesID)
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.computeAPI, fmt.Sprintf("security_groups/%s/rules/%s", groupID, rulesID), url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +102,15 @@ type postGroupRuleResponse struct {
 
 // PostGroupRule posts a rule on a server
 func (s *API) PostGroupRule(GroupID string, rules NewGroupRule) (*GroupRule, error) {
-	resp, err := s.PostResponse(s.computeAPI, fmt.Sprintf("_groups/%s/rules", GroupID), rules)
+	return s.PostGroupRuleContext(context.Background(), GroupID, rules)
+}
+
+// PostGroupRuleContext is like PostGroupRule but uses ctx for the underlying requests
+func (s *API) PostGroupRuleContext(ctx context.Context, GroupID string, rules NewGroupRule) (_ *GroupRule, err error) {
+	ctx, op := s.startOperation(ctx, "PostGroupRule", "security_group_rule", "")
+	defer op.End(&err)
+
+	resp, err := s.PostResponseContext(ctx, s.computeAPI, fmt.Sprintf("security_groups/%s/rules", GroupID), rules)
 	if err != nil {
 		return nil, err
 	}
@@ -102,7 +127,15 @@ func (s *API) PostGroupRule(GroupID string, rules NewGroupRule) (*GroupRule, err
 
 // PutGroupRule updates a GroupRule
 func (s *API) PutGroupRule(rules NewGroupRule, GroupID, RuleID string) error {
-	resp, err := s.PutResponse(s.computeAPI, fmt.Sprintf("_groups/%s/rules/%s", GroupID, RuleID), rules)
+	return s.PutGroupRuleContext(context.Background(), rules, GroupID, RuleID)
+}
+
+// PutGroupRuleContext is like PutGroupRule but uses ctx for the underlying requests
+func (s *API) PutGroupRuleContext(ctx context.Context, rules NewGroupRule, GroupID, RuleID string) (err error) {
+	ctx, op := s.startOperation(ctx, "PutGroupRule", "security_group_rule", RuleID)
+	defer op.End(&err)
+
+	resp, err := s.PutResponseContext(ctx, s.computeAPI, fmt.Sprintf("security_groups/%s/rules/%s", GroupID, RuleID), rules)
 	if err != nil {
 		return err
 	}
@@ -114,7 +147,15 @@ func (s *API) PutGroupRule(rules NewGroupRule, GroupID, RuleID string) error {
 
 // DeleteGroupRule deletes a GroupRule
 func (s *API) DeleteGroupRule(GroupID, RuleID string) error {
-	resp, err := s.DeleteResponse(s.computeAPI, fmt.Sprintf("_groups/%s/rules/%s", GroupID, RuleID))
+	return s.DeleteGroupRuleContext(context.Background(), GroupID, RuleID)
+}
+
+// DeleteGroupRuleContext is like DeleteGroupRule but uses ctx for the underlying requests
+func (s *API) DeleteGroupRuleContext(ctx context.Context, GroupID, RuleID string) (err error) {
+	ctx, op := s.startOperation(ctx, "DeleteGroupRule", "security_group_rule", RuleID)
+	defer op.End(&err)
+
+	resp, err := s.DeleteResponseContext(ctx, s.computeAPI, fmt.Sprintf("security_groups/%s/rules/%s", GroupID, RuleID))
 	if err != nil {
 		return err
 	}
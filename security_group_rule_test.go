@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGroupRules_endpoints(t *testing.T) {
+	var requests []string
+	s := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "HEAD" {
+			requests = append(requests, r.Method+" "+r.URL.Path)
+		}
+		switch r.Method {
+		case "POST":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"rule": {"id": "rule"}}`))
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Write([]byte(`{"rules": [], "rule": {"id": "rule"}}`))
+		}
+	}))
+	rule := NewGroupRule{Action: "accept", Direction: "inbound", IPRange: "0.0.0.0/0", Protocol: "TCP"}
+
+	if _, err := s.GetGroupRules("group"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetAGroupRule("group", "rule"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.PostGroupRule("group", rule); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.PutGroupRule(rule, "group", "rule"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteGroupRule("group", "rule"); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"GET /security_groups/group/rules",
+		"GET /security_groups/group/rules/rule",
+		"POST /security_groups/group/rules",
+		"PUT /security_groups/group/rules/rule",
+		"DELETE /security_groups/group/rules/rule",
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("expected %v, got %v", expected, requests)
+	}
+}
@@ -1,13 +1,11 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
-	"time"
-
-	"golang.org/x/sync/errgroup"
 )
 
 // Server represents a  server
@@ -22,10 +20,10 @@ type Server struct {
 	Name string `json:"name,omitempty"`
 
 	// CreationDate is the creation date of the server
-	CreationDate string `json:"creation_date,omitempty"`
+	CreationDate Timestamp `json:"creation_date,omitzero"`
 
 	// ModificationDate is the date of the last modification of the server
-	ModificationDate string `json:"modification_date,omitempty"`
+	ModificationDate Timestamp `json:"modification_date,omitzero"`
 
 	// Image is the image used by the server
 	Image Image `json:"image,omitempty"`
@@ -160,7 +158,15 @@ type OneServer struct {
 
 // PatchServer updates a server
 func (s *API) PatchServer(serverID string, definition ServerPatchDefinition) error {
-	resp, err := s.PatchResponse(s.computeAPI, fmt.Sprintf("servers/%s", serverID), definition)
+	return s.PatchServerContext(context.Background(), serverID, definition)
+}
+
+// PatchServerContext is like PatchServer but uses ctx for the underlying requests
+func (s *API) PatchServerContext(ctx context.Context, serverID string, definition ServerPatchDefinition) (err error) {
+	ctx, op := s.startOperation(ctx, "PatchServer", "server", serverID)
+	defer op.End(&err)
+
+	resp, err := s.PatchResponseContext(ctx, s.computeAPI, fmt.Sprintf("servers/%s", serverID), definition)
 	if err != nil {
 		return err
 	}
@@ -172,49 +178,50 @@ func (s *API) PatchServer(serverID string, definition ServerPatchDefinition) err
 	return nil
 }
 
-// GetServers gets the list of servers from the API
+// GetServers gets the list of servers of every registered region from the
+// API, only the running ones unless all is true and at most limit if greater
+// than 0. When the compute API of the client is overridden (WithEndpoints,
+// SCW_COMPUTE_API) it isn't a regional endpoint, and only its servers are
+// listed.
+//
+// Deprecated: use ListServers for the servers of the region of the client,
+// and MultiRegionAPI for the servers of every region along with their region
 func (s *API) GetServers(all bool, limit int) (*[]Server, error) {
-	query := url.Values{}
+	return s.GetServersContext(context.Background(), all, limit)
+}
+
+// GetServersContext is like GetServers but uses ctx for the underlying requests
+//
+// Deprecated: use ListServersContext or MultiRegionAPI.GetServersContext
+func (s *API) GetServersContext(ctx context.Context, all bool, limit int) (_ *[]Server, err error) {
+	ctx, op := s.startOperation(ctx, "GetServers", "server", "")
+	defer op.End(&err)
+
+	opts := ListServersOptions{Limit: limit}
 	if !all {
-		query.Set("state", "running")
+		opts.States = []string{"running"}
 	}
-	if limit > 0 {
-		// FIXME: wait for the API to be ready
-		// query.Set("per_page", strconv.Itoa(limit))
-		panic("Not implemented yet")
-	}
-
-	var (
-		g    errgroup.Group
-		apis = []string{
-			ComputeAPIPar1,
-			ComputeAPIAms1,
-		}
-	)
-
-	serverChan := make(chan Servers, 2)
-	for _, api := range apis {
-		g.Go(s.fetchServers(api, query, serverChan))
+	if limit < 0 {
+		opts.Limit = 0
 	}
-
-	if err := g.Wait(); err != nil {
+	servers, err := s.listServers(ctx, opts, s.accountRegions()...)
+	if err != nil {
 		return nil, err
 	}
-	close(serverChan)
-	var servers Servers
-
-	for server := range serverChan {
-		servers.Servers = append(servers.Servers, server.Servers...)
-	}
+	return &servers, nil
+}
 
-	for i, server := range servers.Servers {
-		servers.Servers[i].DNSPublic = server.Identifier + URLPublicDNS
-		servers.Servers[i].DNSPrivate = server.Identifier + URLPrivateDNS
+// accountRegions returns the regions of the account: every registered region,
+// or the region of the client only when its compute API is overridden
+func (s *API) accountRegions() []Region {
+	if s.computeAPI != s.region.ComputeAPI {
+		return []Region{s.region}
 	}
-	return &servers.Servers, nil
+	return Regions()
 }
 
-// SortServers represents a wrapper to sort by CreationDate the servers
+// SortServers represents a wrapper to sort by CreationDate the servers, the
+// newest first, see SortByCreationDate
 type SortServers []Server
 
 func (s SortServers) Len() int {
@@ -226,17 +233,23 @@ func (s SortServers) Swap(i, j int) {
 }
 
 func (s SortServers) Less(i, j int) bool {
-	date1, _ := time.Parse("2006-01-02T15:04:05.000000+00:00", s[i].CreationDate)
-	date2, _ := time.Parse("2006-01-02T15:04:05.000000+00:00", s[j].CreationDate)
-	return date2.Before(date1)
+	return s[j].CreationDate.Before(s[i].CreationDate.Time)
 }
 
 // GetServer gets a server from the API
 func (s *API) GetServer(serverID string) (*Server, error) {
+	return s.GetServerContext(context.Background(), serverID)
+}
+
+// GetServerContext is like GetServer but uses ctx for the underlying requests
+func (s *API) GetServerContext(ctx context.Context, serverID string) (_ *Server, err error) {
+	ctx, op := s.startOperation(ctx, "GetServer", "server", serverID)
+	defer op.End(&err)
+
 	if serverID == "" {
 		return nil, fmt.Errorf("cannot get server without serverID")
 	}
-	resp, err := s.GetResponsePaginate(s.computeAPI, "servers/"+serverID, url.Values{})
+	resp, err := s.GetResponsePaginateContext(ctx, s.computeAPI, "servers/"+serverID, url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -253,51 +266,35 @@ func (s *API) GetServer(serverID string) (*Server, error) {
 		return nil, err
 	}
 	// FIXME arch, owner, title
-	oneServer.Server.DNSPublic = oneServer.Server.Identifier + URLPublicDNS
-	oneServer.Server.DNSPrivate = oneServer.Server.Identifier + URLPrivateDNS
+	s.region.setDNS(&oneServer.Server)
 	return &oneServer.Server, nil
 }
 
 // PostServerAction posts an action on a server
 func (s *API) PostServerAction(serverID, action string) error {
-	data := ServerAction{
-		Action: action,
-	}
-	resp, err := s.PostResponse(s.computeAPI, fmt.Sprintf("servers/%s/action", serverID), data)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	_, err = s.handleHTTPError([]int{http.StatusAccepted}, resp)
-	return err
+	return s.PostServerActionContext(context.Background(), serverID, action)
 }
 
-func (s *API) fetchServers(api string, query url.Values, out chan<- Servers) func() error {
-	return func() error {
-		resp, err := s.GetResponsePaginate(api, "servers", query)
-		if err != nil {
-			return err
-		}
-		defer resp.Body.Close()
-
-		body, err := s.handleHTTPError([]int{http.StatusOK}, resp)
-		if err != nil {
-			return err
-		}
-		var servers Servers
-
-		if err = json.Unmarshal(body, &servers); err != nil {
-			return err
-		}
-		out <- servers
-		return nil
-	}
+// PostServerActionContext is like PostServerAction but uses ctx for the underlying requests
+func (s *API) PostServerActionContext(ctx context.Context, serverID, action string) (err error) {
+	ctx, op := s.startOperation(ctx, "PostServerAction", "server", serverID)
+	defer op.End(&err)
+
+	_, err = s.postServerAction(ctx, serverID, action)
+	return err
 }
 
 // DeleteServer deletes a server
 func (s *API) DeleteServer(serverID string) error {
-	resp, err := s.DeleteResponse(s.computeAPI, fmt.Sprintf("servers/%s", serverID))
+	return s.DeleteServerContext(context.Background(), serverID)
+}
+
+// DeleteServerContext is like DeleteServer but uses ctx for the underlying requests
+func (s *API) DeleteServerContext(ctx context.Context, serverID string) (err error) {
+	ctx, op := s.startOperation(ctx, "DeleteServer", "server", serverID)
+	defer op.End(&err)
+
+	resp, err := s.DeleteResponseContext(ctx, s.computeAPI, fmt.Sprintf("servers/%s", serverID))
 	if err != nil {
 		return err
 	}
@@ -311,9 +308,17 @@ func (s *API) DeleteServer(serverID string) error {
 
 // PostServer creates a new server
 func (s *API) PostServer(definition ServerDefinition) (string, error) {
+	return s.PostServerContext(context.Background(), definition)
+}
+
+// PostServerContext is like PostServer but uses ctx for the underlying requests
+func (s *API) PostServerContext(ctx context.Context, definition ServerDefinition) (_ string, err error) {
+	ctx, op := s.startOperation(ctx, "PostServer", "server", "")
+	defer op.End(&err)
+
 	definition.Organization = s.Organization
 
-	resp, err := s.PostResponse(s.computeAPI, "servers", definition)
+	resp, err := s.PostResponseContext(ctx, s.computeAPI, "servers", definition)
 	if err != nil {
 		return "", err
 	}
@@ -330,3 +335,20 @@ func (s *API) PostServer(definition ServerDefinition) (string, error) {
 	}
 	return server.Server.Identifier, nil
 }
+
+// ListServersIter returns an iterator over the servers of the region, only
+// the running ones unless all is true
+func (s *API) ListServersIter(ctx context.Context, all bool) *Iterator[Server] {
+	query := url.Values{}
+	if !all {
+		query.Set("state", "running")
+	}
+	return newIterator(ctx, s, listing{
+		operation:    "ListServersIter",
+		resourceType: "server",
+		apiURL:       s.computeAPI,
+		resource:     "servers",
+		values:       query,
+		key:          "servers",
+	}, s.region.setDNS)
+}
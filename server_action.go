@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Actions of the servers
+const (
+	ActionPowerOn   = "poweron"
+	ActionPowerOff  = "poweroff"
+	ActionReboot    = "reboot"
+	ActionTerminate = "terminate"
+	ActionBackup    = "backup"
+)
+
+// ErrInvalidServerState is returned when an action isn't allowed in the
+// current state of the server
+var ErrInvalidServerState = errors.New("invalid server state")
+
+// actionStates are the states from which the actions may be performed
+var actionStates = map[string][]string{
+	ActionPowerOn:   {"stopped", "stopped in place"},
+	ActionPowerOff:  {"running", "stopped in place"},
+	ActionReboot:    {"running"},
+	ActionTerminate: {"running"},
+	ActionBackup:    {"running", "stopped", "stopped in place"},
+}
+
+// ActionOptions configures the actions of the servers
+type ActionOptions struct {
+	// Wait blocks until the task of the action is completed
+	Wait bool
+
+	// WaitOptions configures the polling of the task, if Wait is set
+	WaitOptions TaskWaitOptions
+}
+
+// ServerActions represents the response of a GET /servers/UUID/action API call
+type ServerActions struct {
+	Actions []string `json:"actions"`
+}
+
+// GetServerActions returns the actions the server currently supports
+func (s *API) GetServerActions(serverID string) ([]string, error) {
+	return s.GetServerActionsContext(context.Background(), serverID)
+}
+
+// GetServerActionsContext is like GetServerActions but uses ctx for the underlying requests
+func (s *API) GetServerActionsContext(ctx context.Context, serverID string) (_ []string, err error) {
+	ctx, op := s.startOperation(ctx, "GetServerActions", "server", serverID)
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.computeAPI, fmt.Sprintf("servers/%s/action", serverID), url.Values{})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := s.handleHTTPError([]int{http.StatusOK}, resp)
+	if err != nil {
+		return nil, err
+	}
+	var actions ServerActions
+
+	if err = json.Unmarshal(body, &actions); err != nil {
+		return nil, err
+	}
+	return actions.Actions, nil
+}
+
+// PowerOn starts a stopped server
+func (s *API) PowerOn(serverID string, opts ActionOptions) (*Task, error) {
+	return s.PowerOnContext(context.Background(), serverID, opts)
+}
+
+// PowerOnContext is like PowerOn but uses ctx for the underlying requests
+func (s *API) PowerOnContext(ctx context.Context, serverID string, opts ActionOptions) (*Task, error) {
+	return s.serverAction(ctx, "PowerOn", serverID, ActionPowerOn, opts)
+}
+
+// PowerOff stops a running server
+func (s *API) PowerOff(serverID string, opts ActionOptions) (*Task, error) {
+	return s.PowerOffContext(context.Background(), serverID, opts)
+}
+
+// PowerOffContext is like PowerOff but uses ctx for the underlying requests
+func (s *API) PowerOffContext(ctx context.Context, serverID string, opts ActionOptions) (*Task, error) {
+	return s.serverAction(ctx, "PowerOff", serverID, ActionPowerOff, opts)
+}
+
+// Reboot restarts a running server
+func (s *API) Reboot(serverID string, opts ActionOptions) (*Task, error) {
+	return s.RebootContext(context.Background(), serverID, opts)
+}
+
+// RebootContext is like Reboot but uses ctx for the underlying requests
+func (s *API) RebootContext(ctx context.Context, serverID string, opts ActionOptions) (*Task, error) {
+	return s.serverAction(ctx, "Reboot", serverID, ActionReboot, opts)
+}
+
+// Terminate stops a running server and deletes it with its volumes
+func (s *API) Terminate(serverID string, opts ActionOptions) (*Task, error) {
+	return s.TerminateContext(context.Background(), serverID, opts)
+}
+
+// TerminateContext is like Terminate but uses ctx for the underlying requests
+func (s *API) TerminateContext(ctx context.Context, serverID string, opts ActionOptions) (*Task, error) {
+	return s.serverAction(ctx, "Terminate", serverID, ActionTerminate, opts)
+}
+
+// Backup creates an image from the volumes of a server
+func (s *API) Backup(serverID string, opts ActionOptions) (*Task, error) {
+	return s.BackupContext(context.Background(), serverID, opts)
+}
+
+// BackupContext is like Backup but uses ctx for the underlying requests
+func (s *API) BackupContext(ctx context.Context, serverID string, opts ActionOptions) (*Task, error) {
+	return s.serverAction(ctx, "Backup", serverID, ActionBackup, opts)
+}
+
+// serverAction checks the state of the server, performs action and waits
+// for its task if requested
+func (s *API) serverAction(ctx context.Context, name, serverID, action string, opts ActionOptions) (_ *Task, err error) {
+	ctx, op := s.startOperation(ctx, name, "server", serverID)
+	defer op.End(&err)
+
+	server, err := s.GetServerContext(ctx, serverID)
+	if err != nil {
+		return nil, err
+	}
+	if allowed := actionStates[action]; !containsString(allowed, server.State) {
+		return nil, fmt.Errorf("%w: cannot %s server %s which is %s, it should be %s", ErrInvalidServerState, action, serverID, server.State, strings.Join(allowed, " or "))
+	}
+	body, err := s.postServerAction(ctx, serverID, action)
+	if err != nil {
+		return nil, err
+	}
+	var task OneTask
+
+	if err = json.Unmarshal(body, &task); err != nil {
+		return nil, err
+	}
+	if !opts.Wait {
+		return &task.Task, nil
+	}
+	return s.waitTask(ctx, &task.Task, opts.WaitOptions)
+}
+
+// postServerAction posts an action on a server and returns the body of the
+// response, holding its task
+func (s *API) postServerAction(ctx context.Context, serverID, action string) ([]byte, error) {
+	data := ServerAction{
+		Action: action,
+	}
+	resp, err := s.PostResponseContext(ctx, s.computeAPI, fmt.Sprintf("servers/%s/action", serverID), data)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return s.handleHTTPError([]int{http.StatusAccepted}, resp)
+}
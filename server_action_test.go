@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestServerActions(t *testing.T) {
+	s, srv := newFakeAPI(t)
+	srv.TransitionReads = 2
+
+	serverID := srv.AddServer("web", "VC1S")
+	actions, err := s.GetServerActions(serverID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(actions, []string{"backup", "poweron"}) {
+		t.Errorf("unexpected actions of a stopped server: %v", actions)
+	}
+	if _, err = s.PowerOff(serverID, ActionOptions{}); !errors.Is(err, ErrInvalidServerState) {
+		t.Errorf("expected powering off a stopped server to fail, got %v", err)
+	}
+
+	task, err := s.PowerOn(serverID, ActionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Identifier == "" || task.Status != "pending" || task.Description != "server_poweron" {
+		t.Errorf("unexpected task: %+v", task)
+	}
+	if actions, err = s.GetServerActions(serverID); err != nil || len(actions) != 0 {
+		t.Errorf("expected no action during a transition, got %v, %v", actions, err)
+	}
+	if _, err = s.WaitForServerState(context.Background(), serverID, []string{"running"}, testWaitOptions); err != nil {
+		t.Fatal(err)
+	}
+
+	task, err = s.Reboot(serverID, ActionOptions{Wait: true, WaitOptions: testTaskWaitOptions})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Status != "success" || task.Progress != 100 {
+		t.Errorf("expected the reboot to be completed, got %+v", task)
+	}
+	if state, _ := srv.ServerState(serverID); state != "running" {
+		t.Errorf("expected the server to be running, got %s", state)
+	}
+	if task, err = s.Backup(serverID, ActionOptions{Wait: true, WaitOptions: testTaskWaitOptions}); err != nil || task.Status != "success" {
+		t.Errorf("expected the backup to be completed, got %+v, %v", task, err)
+	}
+	if _, err = s.Terminate(serverID, ActionOptions{Wait: true, WaitOptions: testTaskWaitOptions}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := srv.ServerState(serverID); ok {
+		t.Error("expected the server to be terminated")
+	}
+}
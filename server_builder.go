@@ -0,0 +1,366 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BuildError lists the problems found by ServerBuilder, it matches ErrInvalidRequest
+type BuildError struct {
+	Problems []string
+}
+
+// Error returns a string representing the error
+func (e *BuildError) Error() string {
+	return fmt.Sprintf("invalid server definition: %s", strings.Join(e.Problems, "; "))
+}
+
+// Is matches ErrInvalidRequest
+func (e *BuildError) Is(target error) bool {
+	return target == ErrInvalidRequest
+}
+
+// ServerBuilder builds a ServerDefinition from the names of the resources
+// instead of their identifiers, and validates it before the server is created:
+//
+//	definition, err := s.NewServerBuilder("web", "VC1S").
+//		Image("ubuntu-xenial").
+//		SecurityGroup("web").
+//		Volume(50000000000).
+//		Build()
+//
+// Build resolves the names in the region of the client, checks the
+// architectures, the availability of the commercial type and the quotas, and
+// returns a *BuildError listing every problem found. The quota of servers is
+// account-wide: the servers of every registered region are counted, see
+// GetServers.
+type ServerBuilder struct {
+	api            *API
+	name           string
+	commercialType string
+	image          string
+	bootscript     string
+	securityGroup  string
+	volumes        []uint64
+	tags           []string
+	dynamicIP      *bool
+	enableIPv6     bool
+	publicIP       string
+}
+
+// NewServerBuilder returns a ServerBuilder of a server
+func (s *API) NewServerBuilder(name, commercialType string) *ServerBuilder {
+	return &ServerBuilder{
+		api:            s,
+		name:           name,
+		commercialType: commercialType,
+	}
+}
+
+// Image sets the image by identifier, name or marketplace label (i.e: ubuntu-xenial)
+func (b *ServerBuilder) Image(image string) *ServerBuilder {
+	b.image = image
+	return b
+}
+
+// Bootscript sets the bootscript by identifier or title, the one of the image by default
+func (b *ServerBuilder) Bootscript(bootscript string) *ServerBuilder {
+	b.bootscript = bootscript
+	return b
+}
+
+// SecurityGroup sets the security group by identifier or name, the default one of the organization by default
+func (b *ServerBuilder) SecurityGroup(group string) *ServerBuilder {
+	b.securityGroup = group
+	return b
+}
+
+// Volume adds a volume of size bytes, created by Create. Without image the
+// first volume is the root volume.
+func (b *ServerBuilder) Volume(size uint64) *ServerBuilder {
+	b.volumes = append(b.volumes, size)
+	return b
+}
+
+// Tags adds tags to the server
+func (b *ServerBuilder) Tags(tags ...string) *ServerBuilder {
+	b.tags = append(b.tags, tags...)
+	return b
+}
+
+// DynamicIP sets whether the server gets a dynamic public IP
+func (b *ServerBuilder) DynamicIP(required bool) *ServerBuilder {
+	b.dynamicIP = &required
+	return b
+}
+
+// EnableIPv6 enables IPv6 on the server
+func (b *ServerBuilder) EnableIPv6() *ServerBuilder {
+	b.enableIPv6 = true
+	return b
+}
+
+// PublicIP sets the identifier of a reserved IP to attach
+func (b *ServerBuilder) PublicIP(ipID string) *ServerBuilder {
+	b.publicIP = ipID
+	return b
+}
+
+// Build returns the validated definition. The volumes added with Volume
+// aren't created yet, they are missing from Volumes, see Create.
+func (b *ServerBuilder) Build() (*ServerDefinition, error) {
+	return b.BuildContext(context.Background())
+}
+
+// BuildContext is like Build but uses ctx for the underlying requests
+func (b *ServerBuilder) BuildContext(ctx context.Context) (_ *ServerDefinition, err error) {
+	s := b.api
+	ctx, op := s.startOperation(ctx, "BuildServer", "server", "")
+	defer op.End(&err)
+
+	var problems []string
+	problemf := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	definition := &ServerDefinition{
+		Name:              b.name,
+		Tags:              b.tags,
+		Organization:      s.Organization,
+		CommercialType:    b.commercialType,
+		DynamicIPRequired: b.dynamicIP,
+		EnableIPV6:        b.enableIPv6,
+		PublicIP:          b.publicIP,
+	}
+	if b.name == "" {
+		problemf("the name is required")
+	}
+	if b.image == "" && len(b.volumes) == 0 {
+		problemf("an image or a root volume is required")
+	}
+	arch := commercialTypeArch(b.commercialType)
+	if b.commercialType == "" {
+		problemf("the commercial type is required")
+	} else {
+		availabilities, err := s.GetServerAvailabilitiesContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if available, _ := availabilities[b.commercialType].(bool); !available {
+			problemf("the commercial type %s is not available", b.commercialType)
+		}
+	}
+
+	if b.image != "" {
+		images, err := s.GetImagesContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		imageID, problem := resolveImage(*images, b.image, arch, s.region.Zone())
+		if problem != "" {
+			problems = append(problems, problem)
+		} else {
+			definition.Image = &imageID
+		}
+	}
+
+	if b.bootscript != "" {
+		bootscripts, err := s.GetBootscriptsContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var found []Bootscript
+		for _, bootscript := range bootscripts {
+			if bootscript.Identifier == b.bootscript || bootscript.Title == b.bootscript {
+				found = append(found, bootscript)
+			}
+		}
+		switch {
+		case len(found) == 0:
+			problemf("bootscript %q not found", b.bootscript)
+		case len(found) > 1:
+			problemf("bootscript %q is ambiguous, %d bootscripts match", b.bootscript, len(found))
+		case found[0].Arch != arch:
+			problemf("bootscript %q is %s, %s needs %s", b.bootscript, found[0].Arch, b.commercialType, arch)
+		default:
+			definition.Bootscript = &found[0].Identifier
+		}
+	}
+
+	if b.securityGroup != "" {
+		groups, err := s.GetSecurityGroupsContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var found []string
+		for _, group := range groups.SecurityGroups {
+			if group.ID == b.securityGroup || group.Name == b.securityGroup {
+				found = append(found, group.ID)
+			}
+		}
+		switch len(found) {
+		case 0:
+			problemf("security group %q not found", b.securityGroup)
+		case 1:
+			definition.SecurityGroup = found[0]
+		default:
+			problemf("security group %q is ambiguous, %d security groups match", b.securityGroup, len(found))
+		}
+	}
+
+	quotas, err := s.GetQuotasContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if limit, ok := quotas.Quotas["servers"]; ok {
+		servers, err := s.listServers(ctx, ListServersOptions{}, s.accountRegions()...)
+		if err != nil {
+			return nil, err
+		}
+		if len(servers) >= limit {
+			problemf("the quota of servers (%d) is reached", limit)
+		}
+	}
+	if limit, ok := quotas.Quotas["volumes"]; ok {
+		volumes, err := s.GetVolumesContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		needed := len(b.volumes)
+		if b.image != "" {
+			needed++
+		}
+		if len(*volumes)+needed > limit {
+			problemf("the quota of volumes (%d) doesn't allow %d more volumes", limit, needed)
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, &BuildError{Problems: problems}
+	}
+	return definition, nil
+}
+
+// Create builds the definition, creates the volumes and the server, and
+// returns the identifier of the server. The volumes are deleted if the
+// server can't be created.
+func (b *ServerBuilder) Create() (string, error) {
+	return b.CreateContext(context.Background())
+}
+
+// CreateContext is like Create but uses ctx for the underlying requests
+func (b *ServerBuilder) CreateContext(ctx context.Context) (_ string, err error) {
+	s := b.api
+	definition, err := b.BuildContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	ctx, op := s.startOperation(ctx, "CreateServer", "server", "")
+	defer op.End(&err)
+
+	var created []string
+	defer func() {
+		if err == nil {
+			return
+		}
+		// the volumes are deleted even if ctx is canceled
+		cleanupCtx := context.WithoutCancel(ctx)
+		for _, id := range created {
+			if cleanupErr := s.DeleteVolumeContext(cleanupCtx, id); cleanupErr != nil {
+				err = errors.Join(err, fmt.Errorf("deleting volume %s: %w", id, cleanupErr))
+			}
+		}
+	}()
+
+	first := 0
+	if definition.Image != nil {
+		first = 1
+	}
+	for i, size := range b.volumes {
+		id, err := s.PostVolumeContext(ctx, VolumeDefinition{
+			Name: fmt.Sprintf("%s-%d", b.name, first+i),
+			Size: size,
+		})
+		if err != nil {
+			return "", err
+		}
+		created = append(created, id)
+		if definition.Volumes == nil {
+			definition.Volumes = map[string]string{}
+		}
+		definition.Volumes[strconv.Itoa(first+i)] = id
+	}
+	return s.PostServerContext(ctx, *definition)
+}
+
+// commercialTypeArch returns the architecture of the servers of a commercial type
+func commercialTypeArch(commercialType string) string {
+	switch {
+	case commercialType == "C1":
+		return "arm"
+	case strings.HasPrefix(commercialType, "ARM64"):
+		return "arm64"
+	}
+	return "x86_64"
+}
+
+// marketLabel returns the label of a marketplace image, i.e: ubuntu-xenial
+// for "Ubuntu Xenial"
+func marketLabel(name string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}), "-")
+}
+
+// resolveImage returns the identifier of the local image of ref for arch in
+// zone, or a problem
+func resolveImage(images []MarketImage, ref, arch, zone string) (string, string) {
+	var matches []MarketImage
+	for _, image := range images {
+		for _, version := range image.Versions {
+			for _, local := range version.LocalImages {
+				if local.ID != ref {
+					continue
+				}
+				if local.Arch != arch {
+					return "", fmt.Sprintf("image %q is %s, %s is needed", ref, local.Arch, arch)
+				}
+				return local.ID, ""
+			}
+		}
+		if (image.ID != "" && image.ID == ref) || image.Name == ref || marketLabel(image.Name) == ref {
+			matches = append(matches, image)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Sprintf("image %q not found", ref)
+	case 1:
+	default:
+		return "", fmt.Sprintf("image %q is ambiguous, %d images match", ref, len(matches))
+	}
+
+	image := matches[0]
+	var archs []string
+	for _, version := range image.Versions {
+		if version.ID != image.CurrentPublicVersion {
+			continue
+		}
+		for _, local := range version.LocalImages {
+			if local.Zone != zone {
+				continue
+			}
+			if local.Arch == arch {
+				return local.ID, ""
+			}
+			archs = append(archs, local.Arch)
+		}
+	}
+	if len(archs) == 0 {
+		return "", fmt.Sprintf("image %q is not available in %s", ref, zone)
+	}
+	return "", fmt.Sprintf("image %q is not available for %s, only for %s", ref, arch, strings.Join(archs, ", "))
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/smola/scaleway-sdk/scwtest"
+)
+
+func TestServerBuilder(t *testing.T) {
+	s, srv := newFakeAPI(t)
+
+	definition, err := s.NewServerBuilder("web", "VC1S").
+		Image("ubuntu-xenial").
+		Bootscript("x86_64 mainline 4.4.6 rev1").
+		SecurityGroup("Default security group").
+		Tags("prod").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *definition.Image != srv.DefaultImage || *definition.Bootscript != srv.DefaultBootscript ||
+		definition.SecurityGroup != srv.DefaultSecurityGroup || !reflect.DeepEqual(definition.Tags, []string{"prod"}) {
+		t.Errorf("unexpected definition: %+v", definition)
+	}
+
+	arm, err := s.NewServerBuilder("arm", "ARM64-2GB").Image("Ubuntu Xenial").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *arm.Image == srv.DefaultImage {
+		t.Errorf("expected the arm64 image, got the x86_64 one")
+	}
+
+	id, err := s.NewServerBuilder("db", "VC1S").Image(srv.DefaultImage).Volume(50000000000).Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := s.GetServer(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(server.Volumes) != 2 || server.Volumes["1"].Size != 50000000000 {
+		t.Errorf("unexpected volumes: %+v", server.Volumes)
+	}
+
+	// the volumes are deleted when the server can't be created
+	if _, err = s.NewServerBuilder("db", "VC1S").Image(srv.DefaultImage).Volume(50000000000).PublicIP("missing").Create(); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("expected the missing IP to be rejected, got %v", err)
+	}
+	volumes, err := s.GetVolumes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*volumes) != 2 {
+		t.Errorf("expected the volumes of db only, got %d volumes", len(*volumes))
+	}
+}
+
+func TestServerBuilder_problems(t *testing.T) {
+	s, srv := newFakeAPI(t)
+	srv.SetAvailability("C1", false)
+	srv.SetQuota("servers", 1)
+	srv.AddServer("web", "VC1S")
+
+	_, err := s.NewServerBuilder("db", "C1").
+		Image("ubuntu-xenial").
+		Bootscript("x86_64 mainline 4.4.6 rev1").
+		SecurityGroup("missing").
+		Build()
+	var buildErr *BuildError
+	if !errors.As(err, &buildErr) || !errors.Is(err, ErrInvalidRequest) {
+		t.Fatalf("expected a BuildError, got %v", err)
+	}
+	want := []string{
+		"the commercial type C1 is not available",
+		`bootscript "x86_64 mainline 4.4.6 rev1" is x86_64, C1 needs arm`,
+		`security group "missing" not found`,
+		"the quota of servers (1) is reached",
+	}
+	if !reflect.DeepEqual(buildErr.Problems, want) {
+		t.Errorf("unexpected problems:\n%q\nexpected:\n%q", buildErr.Problems, want)
+	}
+
+	_, err = s.NewServerBuilder("db", "VC1S").Image("debian").Build()
+	if !errors.As(err, &buildErr) || buildErr.Problems[0] != `image "debian" not found` {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	srv.SetQuota("servers", 10)
+	srv.SetQuota("volumes", 2)
+	if _, err = s.NewServerBuilder("db", "VC1S").Image(srv.DefaultImage).Volume(1000000000).Create(); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("expected the quota of volumes to be checked, got %v", err)
+	}
+}
+
+func TestServerBuilder_canceled(t *testing.T) {
+	s, srv := newFakeAPI(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// the caller gives up while the server is created
+	srv.Inject(scwtest.Rule{Method: "POST", Path: "/compute/servers", Fault: func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		cancel()
+		scwtest.Delay(time.Minute)(w, r, next)
+	}})
+
+	_, err := s.NewServerBuilder("db", "VC1S").Image(srv.DefaultImage).Volume(50000000000).CreateContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the creation to be canceled, got %v", err)
+	}
+	volumes, err := s.GetVolumes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*volumes) != 0 {
+		t.Errorf("expected the volumes to be deleted, got %d volumes", len(*volumes))
+	}
+}
+
+func TestServerBuilder_quotaAllRegions(t *testing.T) {
+	par1, ams1 := scwtest.NewServer(), scwtest.NewServer()
+	defer par1.Close()
+	defer ams1.Close()
+	ams1.Organization, ams1.Token = par1.Organization, par1.Token
+	setRegions(t,
+		Region{Name: "par1", ComputeAPI: par1.ComputeURL(), Zones: []string{"par1"}},
+		Region{Name: "ams1", ComputeAPI: ams1.ComputeURL(), Zones: []string{"ams1"}},
+	)
+	s, err := New(par1.Organization, par1.Token, "par1", WithRetryPolicy(NoRetry), WithEndpoints(Endpoints{
+		Availability: par1.AvailabilityURL(),
+		Account:      par1.AccountURL(),
+		Marketplace:  par1.MarketplaceURL(),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	par1.SetQuota("servers", 2)
+	par1.AddServer("web", "VC1S")
+	ams1.AddServer("web", "VC1S")
+
+	_, err = s.NewServerBuilder("db", "VC1S").Image(par1.DefaultImage).Build()
+	var buildErr *BuildError
+	if !errors.As(err, &buildErr) || !reflect.DeepEqual(buildErr.Problems, []string{"the quota of servers (2) is reached"}) {
+		t.Errorf("expected the servers of ams1 to count, got %v", err)
+	}
+}
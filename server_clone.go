@@ -0,0 +1,314 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// CloneOptions configures CloneServer, the zero value clones the server in
+// its region with the name "<name>-clone"
+type CloneOptions struct {
+	// Name is the name of the clone
+	Name string
+
+	// Target is the client creating the clone, the client of the server if
+	// nil. If it is in another region, the snapshots are exported to
+	// ExportBucket and imported in the region of Target.
+	Target *API
+
+	// ExportBucket is the Object Storage bucket the snapshots go through when
+	// Target is in another region, it is required then. The exported
+	// objects are left in the bucket.
+	ExportBucket string
+
+	// KeepSnapshots keeps the snapshots of the volumes, they are deleted once
+	// the clone is created by default
+	KeepSnapshots bool
+
+	// WaitOptions configures the polling of the snapshots
+	WaitOptions WaitOptions
+}
+
+// CloneError is returned when CloneServer fails, it wraps the error of the
+// failing step and of the rollback, if any
+type CloneError struct {
+	ServerID string
+	Step     string
+	Err      error
+
+	// CloneID is the identifier of the clone if the step failed once the
+	// clone was complete, the clone is kept then and nothing is rolled back
+	CloneID string
+
+	// Rollback lists the errors met while deleting the created resources,
+	// these resources are left behind
+	Rollback []error
+}
+
+// Error returns a string representing the error
+func (e *CloneError) Error() string {
+	message := fmt.Sprintf("cloning server %s: %s: %v", e.ServerID, e.Step, e.Err)
+	if e.CloneID != "" {
+		message += fmt.Sprintf(" (clone %s kept)", e.CloneID)
+	}
+	if len(e.Rollback) > 0 {
+		message += fmt.Sprintf(" (rollback: %v)", errors.Join(e.Rollback...))
+	}
+	return message
+}
+
+// Unwrap returns the errors of the step and of the rollback
+func (e *CloneError) Unwrap() []error {
+	return append([]error{e.Err}, e.Rollback...)
+}
+
+// CloneServer creates a copy of a server: it snapshots its volumes, creates
+// volumes from the snapshots and a server with the same commercial type,
+// bootscript, tags and security group, then copies its user data. In another
+// region, the snapshots are copied through opts.ExportBucket, the bootscript
+// and the security group are looked up by name. The resources created are
+// deleted if a step fails before the clone is complete; the failures of the
+// cleanup that follows keep the clone, which is returned with the error if it
+// could be read. It returns the clone, stopped.
+func (s *API) CloneServer(ctx context.Context, serverID string, opts CloneOptions) (_ *Server, err error) {
+	ctx, op := s.startOperation(ctx, "CloneServer", "server", serverID)
+	defer op.End(&err)
+
+	target := opts.Target
+	if target == nil {
+		target = s
+	}
+	var (
+		step     string
+		rollback []func() error
+	)
+	// the rollback runs even if ctx is canceled
+	cleanupCtx := context.WithoutCancel(ctx)
+	fail := func(err error) error {
+		cloneErr := &CloneError{ServerID: serverID, Step: step, Err: err}
+		for i := len(rollback) - 1; i >= 0; i-- {
+			if err := rollback[i](); err != nil && !errors.Is(err, ErrNotFound) {
+				cloneErr.Rollback = append(cloneErr.Rollback, err)
+			}
+		}
+		return cloneErr
+	}
+
+	crossRegion := target.Region != s.Region
+	step = "checking the region of the target"
+	if crossRegion && opts.ExportBucket == "" {
+		return nil, fail(fmt.Errorf("%w: cloning a server of %s in %s requires an export bucket, the snapshots are regional", ErrInvalidRequest, s.Region, target.Region))
+	}
+
+	step = "getting the server"
+	server, err := s.GetServerContext(ctx, serverID)
+	if err != nil {
+		return nil, fail(err)
+	}
+	definition := ServerDefinition{
+		Name:              opts.Name,
+		Tags:              server.Tags,
+		CommercialType:    server.CommercialType,
+		DynamicIPRequired: server.DynamicIPRequired,
+		EnableIPV6:        server.EnableIPV6,
+		Volumes:           map[string]string{},
+	}
+	if definition.Name == "" {
+		definition.Name = server.Name + "-clone"
+	}
+	step = "resolving the bootscript and the security group"
+	if err = s.cloneReferences(ctx, target, server, &definition); err != nil {
+		return nil, fail(err)
+	}
+
+	indexes := make([]string, 0, len(server.Volumes))
+	for index := range server.Volumes {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		a, _ := strconv.Atoi(indexes[i])
+		b, _ := strconv.Atoi(indexes[j])
+		return a < b
+	})
+	// the snapshots are deleted with the client of their region
+	type regionalSnapshot struct {
+		api *API
+		id  string
+	}
+	var snapshots []regionalSnapshot
+	for _, index := range indexes {
+		volume := server.Volumes[index]
+		name := fmt.Sprintf("%s-%s", definition.Name, index)
+		step = fmt.Sprintf("snapshotting volume %s", volume.Identifier)
+		snapshotID, err := s.PostSnapshotContext(ctx, volume.Identifier, name)
+		if err != nil {
+			return nil, fail(err)
+		}
+		snapshots = append(snapshots, regionalSnapshot{s, snapshotID})
+		rollback = append(rollback, func() error { return s.DeleteSnapshotContext(cleanupCtx, snapshotID) })
+		if err = s.waitSnapshot(ctx, snapshotID, opts.WaitOptions); err != nil {
+			return nil, fail(err)
+		}
+
+		baseSnapshot := snapshotID
+		if crossRegion {
+			step = fmt.Sprintf("exporting snapshot %s", snapshotID)
+			key := snapshotID + ".qcow2"
+			task, err := s.ExportSnapshotContext(ctx, snapshotID, opts.ExportBucket, key)
+			if err != nil {
+				return nil, fail(err)
+			}
+			if _, err = s.waitTask(ctx, task, TaskWaitOptions{
+				Interval:    opts.WaitOptions.Interval,
+				MaxInterval: opts.WaitOptions.MaxInterval,
+				Multiplier:  opts.WaitOptions.Multiplier,
+				Timeout:     opts.WaitOptions.Timeout,
+			}); err != nil {
+				return nil, fail(err)
+			}
+
+			step = fmt.Sprintf("importing snapshot %s in %s", snapshotID, target.Region)
+			if baseSnapshot, err = target.ImportSnapshotContext(ctx, opts.ExportBucket, key, name); err != nil {
+				return nil, fail(err)
+			}
+			imported := baseSnapshot
+			snapshots = append(snapshots, regionalSnapshot{target, imported})
+			rollback = append(rollback, func() error { return target.DeleteSnapshotContext(cleanupCtx, imported) })
+			if err = target.waitSnapshot(ctx, imported, opts.WaitOptions); err != nil {
+				return nil, fail(err)
+			}
+		}
+
+		step = fmt.Sprintf("creating volume %s from snapshot %s", index, baseSnapshot)
+		volumeID, err := target.PostVolumeContext(ctx, VolumeDefinition{
+			Name:         name,
+			Size:         volume.Size,
+			Type:         volume.VolumeType,
+			BaseSnapshot: baseSnapshot,
+		})
+		if err != nil {
+			return nil, fail(err)
+		}
+		rollback = append(rollback, func() error { return target.DeleteVolumeContext(cleanupCtx, volumeID) })
+		definition.Volumes[index] = volumeID
+	}
+
+	step = "creating the server"
+	cloneID, err := target.PostServerContext(ctx, definition)
+	if err != nil {
+		return nil, fail(err)
+	}
+	rollback = append(rollback, func() error { return target.DeleteServerContext(cleanupCtx, cloneID) })
+
+	step = "copying the user data"
+	keys, err := s.GetUserdatasContext(ctx, serverID, false)
+	if err != nil {
+		return nil, fail(err)
+	}
+	for _, key := range keys.UserData {
+		value, err := s.GetUserdataContext(ctx, serverID, key, false)
+		if err != nil {
+			return nil, fail(err)
+		}
+		if err = target.PatchUserdataContext(ctx, cloneID, key, *value, false); err != nil {
+			return nil, fail(err)
+		}
+	}
+
+	// the clone is complete, the errors of the cleanup don't roll it back
+	var cleanupErr *CloneError
+	if !opts.KeepSnapshots {
+		var errs []error
+		for _, snapshot := range snapshots {
+			if err := snapshot.api.DeleteSnapshotContext(ctx, snapshot.id); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if len(errs) > 0 {
+			cleanupErr = &CloneError{ServerID: serverID, Step: "deleting the snapshots", Err: errors.Join(errs...), CloneID: cloneID}
+		}
+	}
+	clone, err := target.GetServerContext(ctx, cloneID)
+	if err != nil && cleanupErr == nil {
+		cleanupErr = &CloneError{ServerID: serverID, Step: "getting the clone", Err: err, CloneID: cloneID}
+	}
+	if cleanupErr != nil {
+		return clone, cleanupErr
+	}
+	return clone, nil
+}
+
+// cloneReferences sets the bootscript and the security group of server in
+// definition, looking them up in the region of target if it differs
+func (s *API) cloneReferences(ctx context.Context, target *API, server *Server, definition *ServerDefinition) error {
+	sameRegion := target.Region == s.Region
+	if server.Bootscript != nil && server.Bootscript.Identifier != "" {
+		if sameRegion {
+			definition.Bootscript = &server.Bootscript.Identifier
+		} else {
+			bootscripts, err := target.GetBootscriptsContext(ctx)
+			if err != nil {
+				return err
+			}
+			for _, bootscript := range bootscripts {
+				if bootscript.Title == server.Bootscript.Title && bootscript.Arch == server.Bootscript.Arch {
+					definition.Bootscript = &bootscript.Identifier
+				}
+			}
+			if definition.Bootscript == nil {
+				return fmt.Errorf("bootscript %q not found in %s", server.Bootscript.Title, target.Region)
+			}
+		}
+	}
+	if server.SecurityGroup.Identifier != "" {
+		if sameRegion {
+			definition.SecurityGroup = server.SecurityGroup.Identifier
+		} else {
+			groups, err := target.GetSecurityGroupsContext(ctx)
+			if err != nil {
+				return err
+			}
+			for _, group := range groups.SecurityGroups {
+				if group.Name == server.SecurityGroup.Name {
+					definition.SecurityGroup = group.ID
+				}
+			}
+			if definition.SecurityGroup == "" {
+				return fmt.Errorf("security group %q not found in %s", server.SecurityGroup.Name, target.Region)
+			}
+		}
+	}
+	return nil
+}
+
+// waitSnapshot polls a snapshot until it is available
+func (s *API) waitSnapshot(ctx context.Context, snapshotID string, opts WaitOptions) error {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	interval, maxInterval, multiplier := opts.intervals()
+	for {
+		snapshot, err := s.GetSnapshotContext(ctx, snapshotID)
+		if err != nil {
+			return err
+		}
+		switch snapshot.State {
+		case "available":
+			return nil
+		case "error":
+			return fmt.Errorf("snapshot %s failed", snapshotID)
+		}
+		if err := sleep(ctx, interval); err != nil {
+			return fmt.Errorf("waiting for snapshot %s, last state %s: %w", snapshotID, snapshot.State, err)
+		}
+		if interval = time.Duration(float64(interval) * multiplier); interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/smola/scaleway-sdk/scwtest"
+)
+
+func TestCloneServer(t *testing.T) {
+	s, srv := newFakeAPI(t)
+
+	id, err := s.NewServerBuilder("web", "VC1S").Image(srv.DefaultImage).Volume(50000000000).Tags("prod").Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.PatchUserdata(id, "cloud-init", []byte("#cloud-config\n"), false); err != nil {
+		t.Fatal(err)
+	}
+
+	clone, err := s.CloneServer(context.Background(), id, CloneOptions{WaitOptions: testWaitOptions})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clone.Name != "web-clone" || clone.CommercialType != "VC1S" || !reflect.DeepEqual(clone.Tags, []string{"prod"}) {
+		t.Errorf("unexpected clone: %+v", clone)
+	}
+	if clone.SecurityGroup.Identifier != srv.DefaultSecurityGroup || clone.Bootscript == nil || clone.Bootscript.Identifier != srv.DefaultBootscript {
+		t.Errorf("unexpected security group or bootscript: %+v, %+v", clone.SecurityGroup, clone.Bootscript)
+	}
+	if len(clone.Volumes) != 2 || clone.Volumes["1"].Size != 50000000000 {
+		t.Errorf("unexpected volumes: %+v", clone.Volumes)
+	}
+	value, err := s.GetUserdata(clone.Identifier, "cloud-init", false)
+	if err != nil || value.String() != "#cloud-config\n" {
+		t.Errorf("expected the user data to be copied, got %v, %v", value, err)
+	}
+	if snapshots, err := s.GetSnapshots(); err != nil || len(*snapshots) != 0 {
+		t.Errorf("expected the snapshots to be deleted, got %v, %v", snapshots, err)
+	}
+}
+
+func TestCloneServer_rollback(t *testing.T) {
+	s, srv := newFakeAPI(t)
+
+	id, err := s.NewServerBuilder("web", "VC1S").Image(srv.DefaultImage).Volume(50000000000).Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.PatchUserdata(id, "cloud-init", []byte("#cloud-config\n"), false); err != nil {
+		t.Fatal(err)
+	}
+	srv.Inject(scwtest.Rule{Method: "PATCH", Path: "/compute/servers/{}/user_data/{}", Fault: scwtest.Status(http.StatusInternalServerError)})
+
+	_, err = s.CloneServer(context.Background(), id, CloneOptions{Name: "copy", KeepSnapshots: true, WaitOptions: testWaitOptions})
+	var cloneErr *CloneError
+	if !errors.As(err, &cloneErr) || cloneErr.Step != "copying the user data" || !errors.Is(err, ErrServerError) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cloneErr.Rollback) != 0 {
+		t.Errorf("unexpected rollback errors: %v", cloneErr.Rollback)
+	}
+	servers, err := s.GetServers(true, 0)
+	if err != nil || len(*servers) != 1 {
+		t.Errorf("expected the clone to be deleted, got %v, %v", servers, err)
+	}
+	if volumes, err := s.GetVolumes(); err != nil || len(*volumes) != 2 {
+		t.Errorf("expected the volumes of the clone to be deleted, got %v, %v", volumes, err)
+	}
+	if snapshots, err := s.GetSnapshots(); err != nil || len(*snapshots) != 0 {
+		t.Errorf("expected the snapshots to be deleted, got %v, %v", snapshots, err)
+	}
+}
+
+func TestCloneServer_cleanupFailure(t *testing.T) {
+	s, srv := newFakeAPI(t)
+
+	id, err := s.NewServerBuilder("web", "VC1S").Image(srv.DefaultImage).Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.Inject(scwtest.Rule{Method: "DELETE", Path: "/compute/snapshots/{}", Fault: scwtest.Status(http.StatusInternalServerError)})
+
+	clone, err := s.CloneServer(context.Background(), id, CloneOptions{WaitOptions: testWaitOptions})
+	var cloneErr *CloneError
+	if !errors.As(err, &cloneErr) || cloneErr.Step != "deleting the snapshots" || !errors.Is(err, ErrServerError) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if clone == nil || cloneErr.CloneID != clone.Identifier {
+		t.Fatalf("expected the clone to be returned, got %+v, %v", clone, err)
+	}
+	if _, err = s.GetServer(clone.Identifier); err != nil {
+		t.Errorf("expected the clone to be kept, got %v", err)
+	}
+	if volumes, err := s.GetVolumes(); err != nil || len(*volumes) != 2 {
+		t.Errorf("expected the volume of the clone to be kept, got %v, %v", volumes, err)
+	}
+}
+
+func TestCloneServer_otherRegion(t *testing.T) {
+	s, srv := newFakeAPI(t)
+	target, targetSrv := newFakeRegionAPI(t, "ams1")
+	targetSrv.Storage = srv.Storage
+
+	id, err := s.NewServerBuilder("web", "VC1S").Image(srv.DefaultImage).Volume(50000000000).Tags("prod").Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.PatchUserdata(id, "cloud-init", []byte("#cloud-config\n"), false); err != nil {
+		t.Fatal(err)
+	}
+
+	clone, err := s.CloneServer(context.Background(), id, CloneOptions{Target: target, ExportBucket: "clones", WaitOptions: testWaitOptions})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = target.GetServer(clone.Identifier); err != nil {
+		t.Errorf("expected the clone in ams1, got %v", err)
+	}
+	if clone.SecurityGroup.Identifier != targetSrv.DefaultSecurityGroup || clone.Bootscript == nil || clone.Bootscript.Identifier != targetSrv.DefaultBootscript {
+		t.Errorf("expected the security group and the bootscript of ams1, got %+v, %+v", clone.SecurityGroup, clone.Bootscript)
+	}
+	if len(clone.Volumes) != 2 || clone.Volumes["1"].Size != 50000000000 {
+		t.Errorf("unexpected volumes: %+v", clone.Volumes)
+	}
+	value, err := target.GetUserdata(clone.Identifier, "cloud-init", false)
+	if err != nil || value.String() != "#cloud-config\n" {
+		t.Errorf("expected the user data to be copied, got %v, %v", value, err)
+	}
+	for _, api := range []*API{s, target} {
+		if snapshots, err := api.GetSnapshots(); err != nil || len(*snapshots) != 0 {
+			t.Errorf("expected the snapshots of %s to be deleted, got %v, %v", api.Region, snapshots, err)
+		}
+	}
+	if objects := srv.Storage.Objects("clones"); len(objects) != 2 {
+		t.Errorf("expected 2 exported snapshots, got %v", objects)
+	}
+	if servers, err := s.GetServers(true, 0); err != nil || len(*servers) != 1 {
+		t.Errorf("expected no clone in par1, got %v, %v", servers, err)
+	}
+}
+
+func TestCloneServer_otherRegionWithoutBucket(t *testing.T) {
+	s, srv := newFakeAPI(t)
+	target, _ := newFakeRegionAPI(t, "ams1")
+	id := srv.AddServer("web", "VC1S")
+	// counts the snapshots requested
+	srv.Inject(scwtest.Rule{Method: "POST", Path: "/compute/snapshots", Fault: func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		next.ServeHTTP(w, r)
+	}})
+
+	_, err := s.CloneServer(context.Background(), id, CloneOptions{Target: target, WaitOptions: testWaitOptions})
+	var cloneErr *CloneError
+	if !errors.As(err, &cloneErr) || !errors.Is(err, ErrInvalidRequest) {
+		t.Fatalf("expected the clone in ams1 to be rejected, got %v", err)
+	}
+	// nothing is created before the bucket is checked
+	if calls := srv.Calls("POST", "/compute/snapshots"); calls != 0 {
+		t.Errorf("expected no snapshot, %d were requested", calls)
+	}
+	if volumes, err := target.GetVolumes(); err != nil || len(*volumes) != 0 {
+		t.Errorf("expected no volume in ams1, got %v, %v", volumes, err)
+	}
+}
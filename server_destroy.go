@@ -0,0 +1,169 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DestroyOptions configures DestroyServer, the zero value deletes the server
+// only and keeps its volumes and its reserved IP
+type DestroyOptions struct {
+	// DeleteVolumes deletes the volumes of the server
+	DeleteVolumes bool
+
+	// ReleaseIP deletes the reserved IP of the server, it is only detached otherwise
+	ReleaseIP bool
+
+	// DryRun returns the plan without changing anything
+	DryRun bool
+
+	// WaitOptions configures the polling of the server while it stops
+	WaitOptions WaitOptions
+}
+
+// DestroyStep is a step of DestroyServer
+type DestroyStep struct {
+	// Action is one of "poweroff", "delete", "detach" and "keep"
+	Action       string
+	ResourceType string
+	ID           string
+
+	// Done is true once the request of the step succeeded, never in a dry run
+	// and never for the "detach" and "keep" steps, which need no request
+	Done bool
+}
+
+// String returns the step, i.e: delete volume UUID
+func (s DestroyStep) String() string {
+	return fmt.Sprintf("%s %s %s", s.Action, s.ResourceType, s.ID)
+}
+
+// DestroyReport is the plan of DestroyServer and what was done of it
+type DestroyReport struct {
+	ServerID string
+	DryRun   bool
+	Steps    []DestroyStep
+}
+
+// Removed returns the identifiers of the deleted resources by type (i.e: volume)
+func (r *DestroyReport) Removed() map[string][]string {
+	removed := map[string][]string{}
+	for _, step := range r.Steps {
+		if step.Done && step.Action == "delete" {
+			removed[step.ResourceType] = append(removed[step.ResourceType], step.ID)
+		}
+	}
+	return removed
+}
+
+// String returns the plan, one step per line, the steps which weren't done
+// are marked as pending
+func (r *DestroyReport) String() string {
+	var lines []string
+	for _, step := range r.Steps {
+		switch {
+		case r.DryRun, step.Action == "keep", step.Action == "detach":
+			lines = append(lines, step.String())
+		case step.Done:
+			lines = append(lines, step.String()+": done")
+		default:
+			lines = append(lines, step.String()+": pending")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// DestroyServer deletes a server and, depending on opts, its volumes and its
+// reserved IP. A running server is powered off first, a dynamic IP is left to
+// disappear with it. The report lists the
+// plan and the steps done, it is returned with the error of a failing step.
+func (s *API) DestroyServer(ctx context.Context, serverID string, opts DestroyOptions) (_ *DestroyReport, err error) {
+	ctx, op := s.startOperation(ctx, "DestroyServer", "server", serverID)
+	defer op.End(&err)
+
+	server, err := s.GetServerContext(ctx, serverID)
+	if err != nil {
+		return nil, err
+	}
+	report := &DestroyReport{ServerID: serverID, DryRun: opts.DryRun}
+	add := func(action, resourceType, id string) {
+		report.Steps = append(report.Steps, DestroyStep{Action: action, ResourceType: resourceType, ID: id})
+	}
+
+	if server.State != "stopped" {
+		add("poweroff", "server", serverID)
+	}
+	add("delete", "server", serverID)
+	indexes := make([]string, 0, len(server.Volumes))
+	for index := range server.Volumes {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		a, _ := strconv.Atoi(indexes[i])
+		b, _ := strconv.Atoi(indexes[j])
+		return a < b
+	})
+	for _, index := range indexes {
+		if opts.DeleteVolumes {
+			add("delete", "volume", server.Volumes[index].Identifier)
+		} else {
+			add("keep", "volume", server.Volumes[index].Identifier)
+		}
+	}
+	dynamic := server.PublicAddress.Dynamic != nil && *server.PublicAddress.Dynamic
+	if ipID := server.PublicAddress.Identifier; ipID != "" && !dynamic {
+		if opts.ReleaseIP {
+			add("delete", "ip", ipID)
+		} else {
+			add("detach", "ip", ipID)
+		}
+	}
+	if opts.DryRun {
+		return report, nil
+	}
+
+	for i := range report.Steps {
+		step := &report.Steps[i]
+		// the volumes are kept and the IP detached by the deletion of the server
+		if step.Action == "keep" || step.Action == "detach" {
+			continue
+		}
+		switch {
+		case step.Action == "poweroff":
+			err = s.stopServer(ctx, server, opts.WaitOptions)
+		case step.Action == "delete" && step.ResourceType == "server":
+			err = s.DeleteServerContext(ctx, serverID)
+		case step.Action == "delete" && step.ResourceType == "volume":
+			err = s.DeleteVolumeContext(ctx, step.ID)
+		case step.Action == "delete" && step.ResourceType == "ip":
+			err = s.DeleteIPContext(ctx, step.ID)
+		}
+		if err != nil {
+			return report, fmt.Errorf("destroying server %s: %s: %w", serverID, step, err)
+		}
+		step.Done = true
+	}
+	return report, nil
+}
+
+// stopServer powers a server off and waits until it is stopped, waiting for
+// the end of a transition first
+func (s *API) stopServer(ctx context.Context, server *Server, opts WaitOptions) error {
+	stable := []string{"running", "stopped", "stopped in place"}
+	if !containsString(stable, server.State) {
+		var err error
+		if server, err = s.WaitForServerState(ctx, server.Identifier, stable, opts); err != nil {
+			return err
+		}
+	}
+	if server.State != "stopped" {
+		if _, err := s.PowerOffContext(ctx, server.Identifier, ActionOptions{}); err != nil {
+			return err
+		}
+	}
+	_, err := s.WaitForServerState(ctx, server.Identifier, []string{"stopped"}, opts)
+	return err
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/smola/scaleway-sdk/scwtest"
+)
+
+func TestDestroyServer(t *testing.T) {
+	s, srv := newFakeAPI(t)
+	srv.TransitionReads = 1
+
+	id, err := s.NewServerBuilder("web", "VC1S").Image(srv.DefaultImage).Volume(50000000000).Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ip, err := s.NewIP()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.AttachIP(ip.IP.ID, id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.PowerOn(id, ActionOptions{Wait: true, WaitOptions: testTaskWaitOptions}); err != nil {
+		t.Fatal(err)
+	}
+	server, err := s.GetServer(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, data := server.Volumes["0"].Identifier, server.Volumes["1"].Identifier
+
+	opts := DestroyOptions{DeleteVolumes: true, ReleaseIP: true, DryRun: true, WaitOptions: testWaitOptions}
+	plan, err := s.DestroyServer(context.Background(), id, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "poweroff server " + id + "\ndelete server " + id + "\ndelete volume " + root + "\ndelete volume " + data + "\ndelete ip " + ip.IP.ID
+	if plan.String() != want {
+		t.Errorf("unexpected plan:\n%s\nexpected:\n%s", plan, want)
+	}
+	if state, _ := srv.ServerState(id); state != "running" || len(plan.Removed()) != 0 {
+		t.Errorf("expected the dry run to change nothing, got %s, %v", state, plan.Removed())
+	}
+
+	opts.DryRun = false
+	report, err := s.DestroyServer(context.Background(), id, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	removed := map[string][]string{"server": {id}, "volume": {root, data}, "ip": {ip.IP.ID}}
+	if !reflect.DeepEqual(report.Removed(), removed) {
+		t.Errorf("unexpected removed resources: %v", report.Removed())
+	}
+	if _, err = s.GetServer(id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected the server to be deleted, got %v", err)
+	}
+	if volumes, err := s.GetVolumes(); err != nil || len(*volumes) != 0 {
+		t.Errorf("expected the volumes to be deleted, got %v, %v", volumes, err)
+	}
+	if _, err = s.GetIP(ip.IP.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected the IP to be released, got %v", err)
+	}
+}
+
+func TestDestroyServer_keep(t *testing.T) {
+	s, srv := newFakeAPI(t)
+
+	id := srv.AddServer("web", "VC1S")
+	ip, err := s.NewIP()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.AttachIP(ip.IP.ID, id); err != nil {
+		t.Fatal(err)
+	}
+	report, err := s.DestroyServer(context.Background(), id, DestroyOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(report.Removed(), map[string][]string{"server": {id}}) {
+		t.Errorf("unexpected removed resources: %v", report.Removed())
+	}
+	for _, step := range report.Steps {
+		if step.Done != (step.Action == "delete") {
+			t.Errorf("unexpected step %s, done: %v", step, step.Done)
+		}
+	}
+	if volumes, err := s.GetVolumes(); err != nil || len(*volumes) != 1 || (*volumes)[0].Server != nil {
+		t.Errorf("expected the volume to be kept and detached, got %v, %v", volumes, err)
+	}
+	if got, err := s.GetIP(ip.IP.ID); err != nil || got.IP.Server != nil {
+		t.Errorf("expected the IP to be kept and detached, got %+v, %v", got, err)
+	}
+}
+
+func TestDestroyServer_dynamicIP(t *testing.T) {
+	s, srv := newFakeAPI(t)
+	srv.Inject(scwtest.Rule{Method: "DELETE", Path: "/compute/ips/{}", Fault: func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		next.ServeHTTP(w, r)
+	}})
+
+	id, err := s.NewServerBuilder("web", "VC1S").Image(srv.DefaultImage).DynamicIP(true).Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.PowerOn(id, ActionOptions{Wait: true, WaitOptions: testTaskWaitOptions}); err != nil {
+		t.Fatal(err)
+	}
+	if server, err := s.GetServer(id); err != nil || server.PublicAddress.Identifier == "" {
+		t.Fatalf("expected a dynamic IP, got %+v, %v", server, err)
+	}
+
+	report, err := s.DestroyServer(context.Background(), id, DestroyOptions{ReleaseIP: true, WaitOptions: testWaitOptions})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, step := range report.Steps {
+		if step.ResourceType == "ip" {
+			t.Errorf("unexpected step %s for a dynamic IP", step)
+		}
+	}
+	if calls := srv.Calls("DELETE", "/compute/ips/{}"); calls != 0 {
+		t.Errorf("expected no IP to be deleted, %d were", calls)
+	}
+}
+
+func TestDestroyServer_volumeOrder(t *testing.T) {
+	s, srv := newFakeAPI(t)
+
+	builder := s.NewServerBuilder("web", "VC1S").Image(srv.DefaultImage)
+	for i := 0; i < 11; i++ {
+		builder.Volume(10000000000)
+	}
+	id, err := builder.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := s.GetServer(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := s.DestroyServer(context.Background(), id, DestroyOptions{DeleteVolumes: true, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"delete server " + id}
+	for _, index := range []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"} {
+		want = append(want, "delete volume "+server.Volumes[index].Identifier)
+	}
+	if plan.String() != strings.Join(want, "\n") {
+		t.Errorf("unexpected plan:\n%s\nexpected:\n%s", plan, strings.Join(want, "\n"))
+	}
+}
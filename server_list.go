@@ -0,0 +1,267 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+)
+
+// Orders of ListServersOptions
+const (
+	ServerSortCreationDate     = "creation_date"
+	ServerSortModificationDate = "modification_date"
+	ServerSortName             = "name"
+)
+
+// ListServersOptions selects and orders the servers of ListServers, its zero
+// value lists every server in the order of the API. The state, the name, the
+// organization and the commercial type are sent to the API, every filter is
+// also applied client-side.
+type ListServersOptions struct {
+	// States are the accepted states, any if empty
+	States []string
+
+	// Name is the exact name of the servers
+	Name string
+
+	// NamePrefix is the prefix of the name of the servers
+	NamePrefix string
+
+	// Tags are the tags the servers must all have
+	Tags []string
+
+	// AnyTags are the tags the servers must have at least one of
+	AnyTags []string
+
+	// Selector selects the servers by the labels of their tags, see ParseSelector
+	Selector Selector
+
+	// CommercialType is the commercial type of the servers (i.e: VC1S)
+	CommercialType string
+
+	// Arch is the architecture of the servers (i.e: x86_64, arm)
+	Arch string
+
+	// ImageID is the identifier of the image of the servers
+	ImageID string
+
+	// SecurityGroupID is the identifier of the security group of the servers
+	SecurityGroupID string
+
+	// Organization is the owner of the servers
+	Organization string
+
+	// CreatedAfter and CreatedBefore bound the creation date of the servers, if not zero
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+
+	// SortBy is one of ServerSortCreationDate, ServerSortModificationDate and
+	// ServerSortName, the order of the API if empty
+	SortBy string
+
+	// Descending reverses the order of SortBy
+	Descending bool
+
+	// Offset is the number of servers to skip, Limit the maximum number of
+	// servers to return if greater than 0
+	Offset int
+	Limit  int
+}
+
+// Match returns true if server is selected by the filters of the options
+func (o ListServersOptions) Match(server Server) bool {
+	switch {
+	case len(o.States) > 0 && !containsString(o.States, server.State):
+		return false
+	case o.Name != "" && server.Name != o.Name:
+		return false
+	case o.NamePrefix != "" && !strings.HasPrefix(server.Name, o.NamePrefix):
+		return false
+	case o.CommercialType != "" && server.CommercialType != o.CommercialType:
+		return false
+	case o.Arch != "" && server.Arch != o.Arch:
+		return false
+	case o.ImageID != "" && server.Image.Identifier != o.ImageID:
+		return false
+	case o.SecurityGroupID != "" && server.SecurityGroup.Identifier != o.SecurityGroupID:
+		return false
+	case o.Organization != "" && server.Organization != o.Organization:
+		return false
+	}
+	for _, tag := range o.Tags {
+		if !containsString(server.Tags, tag) {
+			return false
+		}
+	}
+	if len(o.AnyTags) > 0 {
+		found := false
+		for _, tag := range o.AnyTags {
+			found = found || containsString(server.Tags, tag)
+		}
+		if !found {
+			return false
+		}
+	}
+	if !o.Selector.Empty() && !o.Selector.Matches(server.Labels()) {
+		return false
+	}
+	created := server.CreationDate.Time
+	if !o.CreatedAfter.IsZero() && created.Before(o.CreatedAfter) {
+		return false
+	}
+	if !o.CreatedBefore.IsZero() && !created.Before(o.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+// query returns the filters supported by the API
+func (o ListServersOptions) query() url.Values {
+	query := url.Values{}
+	if len(o.States) == 1 {
+		query.Set("state", o.States[0])
+	}
+	if o.Name != "" {
+		query.Set("name", o.Name)
+	} else if o.NamePrefix != "" {
+		query.Set("name", o.NamePrefix)
+	}
+	if o.Organization != "" {
+		query.Set("organization", o.Organization)
+	}
+	if o.CommercialType != "" {
+		query.Set("commercial_type", o.CommercialType)
+	}
+	return query
+}
+
+// less returns the order of SortBy
+func (o ListServersOptions) less() (func(a, b Server) bool, error) {
+	var less func(a, b Server) bool
+	switch o.SortBy {
+	case "":
+		return nil, nil
+	case ServerSortCreationDate:
+		less = func(a, b Server) bool {
+			return a.CreationDate.Before(b.CreationDate.Time)
+		}
+	case ServerSortModificationDate:
+		less = func(a, b Server) bool {
+			return a.ModificationDate.Before(b.ModificationDate.Time)
+		}
+	case ServerSortName:
+		less = func(a, b Server) bool {
+			return a.Name < b.Name
+		}
+	default:
+		return nil, fmt.Errorf("cannot sort servers by %q", o.SortBy)
+	}
+	if o.Descending {
+		return func(a, b Server) bool { return less(b, a) }, nil
+	}
+	return less, nil
+}
+
+// ListServers returns the servers of the region selected by opts
+func (s *API) ListServers(opts ListServersOptions) ([]Server, error) {
+	return s.ListServersContext(context.Background(), opts)
+}
+
+// ListServersContext is like ListServers but uses ctx for the underlying requests
+func (s *API) ListServersContext(ctx context.Context, opts ListServersOptions) (_ []Server, err error) {
+	ctx, op := s.startOperation(ctx, "ListServers", "server", "")
+	defer op.End(&err)
+
+	return s.listServers(ctx, opts)
+}
+
+// listServers fetches the servers matching the server-side filters of opts
+// from the compute API of each region, the region of the client if none is
+// given, and applies the others
+func (s *API) listServers(ctx context.Context, opts ListServersOptions, regions ...Region) ([]Server, error) {
+	if opts.Offset < 0 || opts.Limit < 0 {
+		return nil, fmt.Errorf("invalid offset %d or limit %d", opts.Offset, opts.Limit)
+	}
+	less, err := opts.less()
+	if err != nil {
+		return nil, err
+	}
+	if len(regions) == 0 {
+		regions = []Region{s.region}
+	}
+
+	var (
+		g, gctx = errgroup.WithContext(ctx)
+		results = make([][]Server, len(regions))
+	)
+	for i, region := range regions {
+		i, region := i, region
+		api := region.ComputeAPI
+		if region.Name == s.region.Name {
+			// the endpoint of the client may be overridden
+			api = s.computeAPI
+		}
+		g.Go(func() (err error) {
+			results[i], err = s.fetchServers(gctx, region, api, opts)
+			return err
+		})
+	}
+	if err = g.Wait(); err != nil {
+		return nil, err
+	}
+	ret := []Server{}
+	for _, servers := range results {
+		ret = append(ret, servers...)
+	}
+	if less != nil {
+		sort.SliceStable(ret, func(i, j int) bool { return less(ret[i], ret[j]) })
+	}
+	if opts.Offset >= len(ret) {
+		return []Server{}, nil
+	}
+	ret = ret[opts.Offset:]
+	if opts.Limit > 0 && opts.Limit < len(ret) {
+		ret = ret[:opts.Limit]
+	}
+	return ret, nil
+}
+
+// fetchServers fetches the servers of a region from api and returns those
+// selected by opts
+func (s *API) fetchServers(ctx context.Context, region Region, api string, opts ListServersOptions) ([]Server, error) {
+	ctx, span := s.tracer.Start(ctx, "fetchServers", trace.WithAttributes(attribute.String("scaleway.region", region.Name)))
+	defer span.End()
+
+	resp, err := s.GetResponsePaginateContext(ctx, api, "servers", opts.query())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := s.handleHTTPError([]int{http.StatusOK}, resp)
+	if err != nil {
+		return nil, err
+	}
+	var servers Servers
+
+	if err = json.Unmarshal(body, &servers); err != nil {
+		return nil, err
+	}
+	ret := []Server{}
+	for _, server := range servers.Servers {
+		if opts.Match(server) {
+			region.setDNS(&server)
+			ret = append(ret, server)
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestListServers(t *testing.T) {
+	s, srv := newFakeAPI(t)
+	srv.SetQuota("servers", 100)
+
+	image := srv.DefaultImage
+	for _, server := range []struct {
+		name, commercialType string
+		tags                 []string
+	}{
+		{"web-1", "VC1S", []string{"prod", "web"}},
+		{"web-2", "VC1M", []string{"staging", "web"}},
+		{"db-1", "VC1S", []string{"prod", "db"}},
+		{"arm-1", "C1", nil},
+	} {
+		definition := ServerDefinition{Name: server.name, CommercialType: server.commercialType, Tags: server.tags}
+		if server.commercialType != "C1" {
+			definition.Image = &image
+		} else {
+			definition.Volumes = map[string]string{"0": srv.AddVolume("root", 20000000000)}
+		}
+		if _, err := s.PostServer(definition); err != nil {
+			t.Fatal(err)
+		}
+	}
+	servers, err := s.ListServers(ListServersOptions{Name: "web-1"})
+	if err != nil || len(servers) != 1 {
+		t.Fatalf("unexpected servers: %v, %v", servers, err)
+	}
+	if err = s.PostServerAction(servers[0].Identifier, "poweron"); err != nil {
+		t.Fatal(err)
+	}
+	created := servers[0].CreationDate.Time
+
+	for _, tt := range []struct {
+		name string
+		opts ListServersOptions
+		want []string
+	}{
+		{"all", ListServersOptions{}, []string{"web-1", "web-2", "db-1", "arm-1"}},
+		{"states", ListServersOptions{States: []string{"stopped", "stopping"}}, []string{"web-2", "db-1", "arm-1"}},
+		{"running", ListServersOptions{States: []string{"running"}}, []string{"web-1"}},
+		{"name", ListServersOptions{Name: "web"}, []string{}},
+		{"prefix", ListServersOptions{NamePrefix: "web"}, []string{"web-1", "web-2"}},
+		{"all tags", ListServersOptions{Tags: []string{"prod", "web"}}, []string{"web-1"}},
+		{"any tags", ListServersOptions{AnyTags: []string{"db", "staging"}}, []string{"web-2", "db-1"}},
+		{"commercial type", ListServersOptions{CommercialType: "VC1S"}, []string{"web-1", "db-1"}},
+		{"arch", ListServersOptions{Arch: "arm"}, []string{"arm-1"}},
+		{"image", ListServersOptions{ImageID: image}, []string{"web-1", "web-2", "db-1"}},
+		{"security group", ListServersOptions{SecurityGroupID: srv.DefaultSecurityGroup, Arch: "x86_64"}, []string{"web-1", "web-2", "db-1"}},
+		{"organization", ListServersOptions{Organization: "other"}, []string{}},
+		{"created after", ListServersOptions{CreatedAfter: created.Add(time.Microsecond)}, []string{"web-2", "db-1", "arm-1"}},
+		{"created before", ListServersOptions{CreatedBefore: created.Add(time.Microsecond)}, []string{"web-1"}},
+		{"sorted", ListServersOptions{SortBy: ServerSortName}, []string{"arm-1", "db-1", "web-1", "web-2"}},
+		{"descending", ListServersOptions{SortBy: ServerSortCreationDate, Descending: true}, []string{"arm-1", "db-1", "web-2", "web-1"}},
+		{"modified", ListServersOptions{SortBy: ServerSortModificationDate, Descending: true, Limit: 1}, []string{"web-1"}},
+		{"paginated", ListServersOptions{SortBy: ServerSortName, Offset: 1, Limit: 2}, []string{"db-1", "web-1"}},
+		{"past the end", ListServersOptions{Offset: 10}, []string{}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			servers, err := s.ListServers(tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			names := []string{}
+			for _, server := range servers {
+				names = append(names, server.Name)
+			}
+			if fmt.Sprint(names) != fmt.Sprint(tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, names)
+			}
+		})
+	}
+
+	if _, err = s.ListServers(ListServersOptions{SortBy: "size"}); err == nil {
+		t.Error("expected an unknown order to fail")
+	}
+	running, err := s.GetServers(false, 0)
+	if err != nil || len(*running) != 1 {
+		t.Errorf("expected the running server, got %v, %v", running, err)
+	}
+	limited, err := s.GetServers(true, 2)
+	if err != nil || len(*limited) != 2 {
+		t.Errorf("expected 2 servers, got %v, %v", limited, err)
+	}
+}
+
+func TestListServers_paginated(t *testing.T) {
+	s, srv := newFakeAPI(t)
+	srv.SetQuota("servers", 200)
+
+	for _, commercialType := range []string{"VC1M", "VC1S"} {
+		for i := 0; i < 60; i++ {
+			srv.AddServer(fmt.Sprintf("web-%d", i), commercialType)
+		}
+	}
+	// the filters are kept on every page
+	servers, err := s.ListServers(ListServersOptions{CommercialType: "VC1S"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(servers) != 60 {
+		t.Errorf("expected 60 servers, got %d", len(servers))
+	}
+}
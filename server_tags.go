@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// tagAttempts is the number of times the tags of a server are computed again
+// when a concurrent writer modifies or overwrites them
+const tagAttempts = 5
+
+// AddServerTags adds tags to a server, the tags it already has are skipped
+func (s *API) AddServerTags(serverID string, tags []string) error {
+	return s.AddServerTagsContext(context.Background(), serverID, tags)
+}
+
+// AddServerTagsContext is like AddServerTags but uses ctx for the underlying requests
+func (s *API) AddServerTagsContext(ctx context.Context, serverID string, tags []string) (err error) {
+	ctx, op := s.startOperation(ctx, "AddServerTags", "server", serverID)
+	defer op.End(&err)
+
+	return s.updateServerTags(ctx, serverID, func(current []string) []string {
+		for _, tag := range tags {
+			if !containsString(current, tag) {
+				current = append(current, tag)
+			}
+		}
+		return current
+	})
+}
+
+// RemoveServerTags removes tags from a server
+func (s *API) RemoveServerTags(serverID string, tags []string) error {
+	return s.RemoveServerTagsContext(context.Background(), serverID, tags)
+}
+
+// RemoveServerTagsContext is like RemoveServerTags but uses ctx for the underlying requests
+func (s *API) RemoveServerTagsContext(ctx context.Context, serverID string, tags []string) (err error) {
+	ctx, op := s.startOperation(ctx, "RemoveServerTags", "server", serverID)
+	defer op.End(&err)
+
+	return s.updateServerTags(ctx, serverID, func(current []string) []string {
+		ret := []string{}
+		for _, tag := range current {
+			if !containsString(tags, tag) {
+				ret = append(ret, tag)
+			}
+		}
+		return ret
+	})
+}
+
+// SetServerLabel sets the label key of a server to value: the key=value
+// tag replaces the tags of the same key
+func (s *API) SetServerLabel(serverID, key, value string) error {
+	return s.SetServerLabelContext(context.Background(), serverID, key, value)
+}
+
+// SetServerLabelContext is like SetServerLabel but uses ctx for the underlying requests
+func (s *API) SetServerLabelContext(ctx context.Context, serverID, key, value string) (err error) {
+	ctx, op := s.startOperation(ctx, "SetServerLabel", "server", serverID)
+	defer op.End(&err)
+
+	if key == "" || strings.Contains(key, "=") {
+		return fmt.Errorf("invalid label key %q", key)
+	}
+	label := key
+	if value != "" {
+		label += "=" + value
+	}
+	return s.updateServerTags(ctx, serverID, func(current []string) []string {
+		ret := []string{}
+		for _, tag := range current {
+			if tagKey, _ := splitLabel(tag); tagKey != key {
+				ret = append(ret, tag)
+			}
+		}
+		return append(ret, label)
+	})
+}
+
+// updateServerTags replaces the tags of a server with change(tags). The API
+// has no conditional update: the server is re-read before the update, and the
+// change is computed again on the tags read if its ModificationDate moved.
+// The server is re-read after the update too, and the change applied again
+// while it still changes the tags, i.e. while a concurrent writer overwrote
+// them.
+func (s *API) updateServerTags(ctx context.Context, serverID string, change func([]string) []string) error {
+	server, err := s.GetServerContext(ctx, serverID)
+	if err != nil {
+		return err
+	}
+	for attempt := 0; ; attempt++ {
+		tags := change(append([]string{}, server.Tags...))
+		if sameTags(tags, server.Tags) {
+			return nil
+		}
+		if attempt == tagAttempts {
+			return fmt.Errorf("updating the tags of server %s: modified concurrently %d times: %w", serverID, tagAttempts, ErrConflict)
+		}
+		current, err := s.GetServerContext(ctx, serverID)
+		if err != nil {
+			return err
+		}
+		if !current.ModificationDate.Equal(server.ModificationDate.Time) {
+			server = current
+			continue
+		}
+		if err = s.PatchServerContext(ctx, serverID, ServerPatchDefinition{Tags: &tags}); err != nil {
+			return err
+		}
+		if server, err = s.GetServerContext(ctx, serverID); err != nil {
+			return err
+		}
+	}
+}
+
+// sameTags returns true if a and b hold the same tags, in any order
+func sameTags(a, b []string) bool {
+	for _, tag := range a {
+		if !containsString(b, tag) {
+			return false
+		}
+	}
+	for _, tag := range b {
+		if !containsString(a, tag) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/smola/scaleway-sdk/scwtest"
+)
+
+func TestServerTags(t *testing.T) {
+	s, srv := newFakeAPI(t)
+
+	id := srv.AddServer("web", "VC1S")
+	tags := func() []string {
+		t.Helper()
+		server, err := s.GetServer(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return server.Tags
+	}
+	if err := s.AddServerTags(id, []string{"env=prod", "legacy", "legacy"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetServerLabel(id, "env", "staging"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetServerLabel(id, "role", "web"); err != nil {
+		t.Fatal(err)
+	}
+	if got := tags(); !reflect.DeepEqual(got, []string{"legacy", "env=staging", "role=web"}) {
+		t.Errorf("unexpected tags: %v", got)
+	}
+	if err := s.RemoveServerTags(id, []string{"legacy", "missing"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := tags(); !reflect.DeepEqual(got, []string{"env=staging", "role=web"}) {
+		t.Errorf("unexpected tags: %v", got)
+	}
+	if err := s.SetServerLabel(id, "a=b", "c"); err == nil {
+		t.Error("expected an invalid key to fail")
+	}
+
+	servers, err := s.ListServers(ListServersOptions{Selector: MustParseSelector("role in (web,api),!legacy")})
+	if err != nil || len(servers) != 1 {
+		t.Errorf("expected the server to be selected, got %v, %v", servers, err)
+	}
+}
+
+func TestServerTags_concurrent(t *testing.T) {
+	s, srv := newFakeAPI(t)
+
+	id := srv.AddServer("web", "VC1S")
+	// another writer adds a tag between the read and the update
+	srv.Inject(scwtest.Rule{Method: "GET", Path: "/compute/servers/{}", Calls: []int{1}, Fault: scwtest.Mutate(func() {
+		server, _ := s.GetServer(id)
+		tags := append(server.Tags, "env=staging")
+		s.PatchServer(id, ServerPatchDefinition{Tags: &tags})
+	})})
+	if err := s.AddServerTags(id, []string{"role=web"}); err != nil {
+		t.Fatal(err)
+	}
+	server, err := s.GetServer(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(server.Tags, []string{"env=staging", "role=web"}) {
+		t.Errorf("expected both tags to be kept, got %v", server.Tags)
+	}
+	if err = s.PatchServer(id, ServerPatchDefinition{Tags: &[]string{}}); err != nil {
+		t.Fatal(err)
+	}
+	srv.ResetFaults()
+
+	// another writer reads the tags between the read and the update, and
+	// writes them back with its change right after the update
+	var stale []string
+	srv.Inject(
+		scwtest.Rule{Method: "GET", Path: "/compute/servers/{}", Calls: []int{1}, Fault: scwtest.Mutate(func() {
+			server, _ := s.GetServer(id)
+			stale = server.Tags
+		})},
+		scwtest.Rule{Method: "PATCH", Path: "/compute/servers/{}", Calls: []int{1}, Fault: scwtest.Mutate(func() {
+			tags := append(stale, "env=staging")
+			s.PatchServer(id, ServerPatchDefinition{Tags: &tags})
+		})},
+	)
+	if err = s.AddServerTags(id, []string{"role=web"}); err != nil {
+		t.Fatal(err)
+	}
+	if server, err = s.GetServer(id); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(server.Tags, []string{"env=staging", "role=web"}) {
+		t.Errorf("expected the overwritten change to be applied again, got %v", server.Tags)
+	}
+	if calls := srv.Calls("PATCH", "/compute/servers/{}"); calls != 3 {
+		t.Errorf("expected 2 updates and the concurrent one, got %d", calls)
+	}
+
+	// the other writer overwrites every update
+	srv.ResetFaults()
+	writes := 0
+	srv.Inject(scwtest.Rule{Method: "PATCH", Path: "/compute/servers/{}", Calls: []int{1, 3, 5, 7, 9}, Fault: scwtest.Mutate(func() {
+		writes++
+		s.PatchServer(id, ServerPatchDefinition{Tags: &[]string{fmt.Sprintf("writer=%d", writes)}})
+	})})
+	if err = s.SetServerLabel(id, "env", "prod"); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected ErrConflict, got %v", err)
+	}
+	if writes != tagAttempts {
+		t.Errorf("expected %d updates, got %d", tagAttempts, writes)
+	}
+}
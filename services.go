@@ -0,0 +1,224 @@
+package api
+
+import (
+	"context"
+)
+
+//go:generate go run ./internal/genfakes -o fakes_gen.go services.go
+
+// The services group the methods of API by resource, so that the code using
+// them can be tested with the Fake* services instead of a client
+
+// ServerService manages the servers, their actions and tasks
+type ServerService interface {
+	GetServers(all bool, limit int) (*[]Server, error)
+	GetServersContext(ctx context.Context, all bool, limit int) (*[]Server, error)
+	ListServers(opts ListServersOptions) ([]Server, error)
+	ListServersContext(ctx context.Context, opts ListServersOptions) ([]Server, error)
+	GetServer(serverID string) (*Server, error)
+	GetServerContext(ctx context.Context, serverID string) (*Server, error)
+	PostServer(definition ServerDefinition) (string, error)
+	PostServerContext(ctx context.Context, definition ServerDefinition) (string, error)
+	PatchServer(serverID string, definition ServerPatchDefinition) error
+	PatchServerContext(ctx context.Context, serverID string, definition ServerPatchDefinition) error
+	PostServerAction(serverID, action string) error
+	PostServerActionContext(ctx context.Context, serverID, action string) error
+	DeleteServer(serverID string) error
+	DeleteServerContext(ctx context.Context, serverID string) error
+	GetServerActions(serverID string) ([]string, error)
+	GetServerActionsContext(ctx context.Context, serverID string) ([]string, error)
+	PowerOn(serverID string, opts ActionOptions) (*Task, error)
+	PowerOnContext(ctx context.Context, serverID string, opts ActionOptions) (*Task, error)
+	PowerOff(serverID string, opts ActionOptions) (*Task, error)
+	PowerOffContext(ctx context.Context, serverID string, opts ActionOptions) (*Task, error)
+	Reboot(serverID string, opts ActionOptions) (*Task, error)
+	RebootContext(ctx context.Context, serverID string, opts ActionOptions) (*Task, error)
+	Terminate(serverID string, opts ActionOptions) (*Task, error)
+	TerminateContext(ctx context.Context, serverID string, opts ActionOptions) (*Task, error)
+	Backup(serverID string, opts ActionOptions) (*Task, error)
+	BackupContext(ctx context.Context, serverID string, opts ActionOptions) (*Task, error)
+	ListServersIter(ctx context.Context, all bool) *Iterator[Server]
+	GetServerAvailabilities() (ServerAvailabilities, error)
+	GetServerAvailabilitiesContext(ctx context.Context) (ServerAvailabilities, error)
+	GetTasks() (*[]Task, error)
+	GetTasksContext(ctx context.Context) (*[]Task, error)
+	ListTasksIter(ctx context.Context) *Iterator[Task]
+	GetTask(taskID string) (*Task, error)
+	GetTaskContext(ctx context.Context, taskID string) (*Task, error)
+	FilterTasks(filter TaskFilter) ([]Task, error)
+	FilterTasksContext(ctx context.Context, filter TaskFilter) ([]Task, error)
+	WaitForTask(ctx context.Context, taskID string, opts TaskWaitOptions) (*Task, error)
+	CloneServer(ctx context.Context, serverID string, opts CloneOptions) (*Server, error)
+	DestroyServer(ctx context.Context, serverID string, opts DestroyOptions) (*DestroyReport, error)
+	AddServerTags(serverID string, tags []string) error
+	AddServerTagsContext(ctx context.Context, serverID string, tags []string) error
+	RemoveServerTags(serverID string, tags []string) error
+	RemoveServerTagsContext(ctx context.Context, serverID string, tags []string) error
+	SetServerLabel(serverID, key, value string) error
+	SetServerLabelContext(ctx context.Context, serverID, key, value string) error
+}
+
+// VolumeService manages the volumes
+type VolumeService interface {
+	GetVolumes() (*[]Volume, error)
+	GetVolumesContext(ctx context.Context) (*[]Volume, error)
+	GetVolume(volumeID string) (*Volume, error)
+	GetVolumeContext(ctx context.Context, volumeID string) (*Volume, error)
+	PostVolume(definition VolumeDefinition) (string, error)
+	PostVolumeContext(ctx context.Context, definition VolumeDefinition) (string, error)
+	PutVolume(volumeID string, definition VolumePutDefinition) error
+	PutVolumeContext(ctx context.Context, volumeID string, definition VolumePutDefinition) error
+	DeleteVolume(volumeID string) error
+	DeleteVolumeContext(ctx context.Context, volumeID string) error
+	ListVolumesIter(ctx context.Context) *Iterator[Volume]
+}
+
+// SnapshotService manages the snapshots
+type SnapshotService interface {
+	GetSnapshots() (*[]Snapshot, error)
+	GetSnapshotsContext(ctx context.Context) (*[]Snapshot, error)
+	GetSnapshot(snapshotID string) (*Snapshot, error)
+	GetSnapshotContext(ctx context.Context, snapshotID string) (*Snapshot, error)
+	PostSnapshot(volumeID string, name string) (string, error)
+	PostSnapshotContext(ctx context.Context, volumeID string, name string) (string, error)
+	ImportSnapshot(bucket, key, name string) (string, error)
+	ImportSnapshotContext(ctx context.Context, bucket, key, name string) (string, error)
+	ExportSnapshot(snapshotID, bucket, key string) (*Task, error)
+	ExportSnapshotContext(ctx context.Context, snapshotID, bucket, key string) (*Task, error)
+	DeleteSnapshot(snapshotID string) error
+	DeleteSnapshotContext(ctx context.Context, snapshotID string) error
+	ListSnapshotsIter(ctx context.Context) *Iterator[Snapshot]
+}
+
+// ImageService manages the images and lists the bootscripts
+type ImageService interface {
+	GetImages() (*[]MarketImage, error)
+	GetImagesContext(ctx context.Context) (*[]MarketImage, error)
+	GetImage(imageID string) (*Image, error)
+	GetImageContext(ctx context.Context, imageID string) (*Image, error)
+	PostImage(volumeID string, name string, bootscript string, arch string) (string, error)
+	PostImageContext(ctx context.Context, volumeID string, name string, bootscript string, arch string) (string, error)
+	DeleteImage(imageID string) error
+	DeleteImageContext(ctx context.Context, imageID string) error
+	ListImagesIter(ctx context.Context) *Iterator[Image]
+	GetBootscripts() ([]Bootscript, error)
+	GetBootscriptsContext(ctx context.Context) ([]Bootscript, error)
+	GetBootscript(bootscriptID string) (*Bootscript, error)
+	GetBootscriptContext(ctx context.Context, bootscriptID string) (*Bootscript, error)
+	ListBootscriptsIter(ctx context.Context) *Iterator[Bootscript]
+}
+
+// IPService manages the flexible IPs
+type IPService interface {
+	GetIPS() (*GetIPS, error)
+	GetIPSContext(ctx context.Context) (*GetIPS, error)
+	GetIP(ipID string) (*GetIP, error)
+	GetIPContext(ctx context.Context, ipID string) (*GetIP, error)
+	NewIP() (*GetIP, error)
+	NewIPContext(ctx context.Context) (*GetIP, error)
+	AttachIP(ipID, serverID string) error
+	AttachIPContext(ctx context.Context, ipID, serverID string) error
+	DetachIP(ipID string) error
+	DetachIPContext(ctx context.Context, ipID string) error
+	DeleteIP(ipID string) error
+	DeleteIPContext(ctx context.Context, ipID string) error
+	ListIPsIter(ctx context.Context) *Iterator[IPV4]
+}
+
+// SecurityGroupService manages the security groups and their rules
+type SecurityGroupService interface {
+	GetSecurityGroups() (*GetSecurityGroups, error)
+	GetSecurityGroupsContext(ctx context.Context) (*GetSecurityGroups, error)
+	GetASecurityGroup(groupsID string) (*GetSecurityGroup, error)
+	GetASecurityGroupContext(ctx context.Context, groupsID string) (*GetSecurityGroup, error)
+	PostSecurityGroup(group NewSecurityGroup) error
+	PostSecurityGroupContext(ctx context.Context, group NewSecurityGroup) error
+	PutSecurityGroup(group UpdateSecurityGroup, securityGroupID string) error
+	PutSecurityGroupContext(ctx context.Context, group UpdateSecurityGroup, securityGroupID string) error
+	DeleteSecurityGroup(securityGroupID string) error
+	DeleteSecurityGroupContext(ctx context.Context, securityGroupID string) error
+	ListSecurityGroupsIter(ctx context.Context) *Iterator[SecurityGroups]
+	GetGroupRules(groupID string) (*GetGroupRules, error)
+	GetGroupRulesContext(ctx context.Context, groupID string) (*GetGroupRules, error)
+	GetAGroupRule(groupID string, rulesID string) (*GetGroupRule, error)
+	GetAGroupRuleContext(ctx context.Context, groupID string, rulesID string) (*GetGroupRule, error)
+	PostGroupRule(groupID string, rules NewGroupRule) (*GroupRule, error)
+	PostGroupRuleContext(ctx context.Context, groupID string, rules NewGroupRule) (*GroupRule, error)
+	PutGroupRule(rules NewGroupRule, groupID, ruleID string) error
+	PutGroupRuleContext(ctx context.Context, rules NewGroupRule, groupID, ruleID string) error
+	DeleteGroupRule(groupID, ruleID string) error
+	DeleteGroupRuleContext(ctx context.Context, groupID, ruleID string) error
+}
+
+// UserdataService manages the user data of the servers
+type UserdataService interface {
+	GetUserdatas(serverID string, metadata bool) (*Userdatas, error)
+	GetUserdatasContext(ctx context.Context, serverID string, metadata bool) (*Userdatas, error)
+	GetUserdata(serverID, key string, metadata bool) (*Userdata, error)
+	GetUserdataContext(ctx context.Context, serverID, key string, metadata bool) (*Userdata, error)
+	PatchUserdata(serverID, key string, value []byte, metadata bool) error
+	PatchUserdataContext(ctx context.Context, serverID, key string, value []byte, metadata bool) error
+	DeleteUserdata(serverID, key string, metadata bool) error
+	DeleteUserdataContext(ctx context.Context, serverID, key string, metadata bool) error
+}
+
+// AccountService reads the user, the organization and its limits
+type AccountService interface {
+	GetUserID() (string, error)
+	GetUserIDContext(ctx context.Context) (string, error)
+	GetUser() (*UserDefinition, error)
+	GetUserContext(ctx context.Context) (*UserDefinition, error)
+	PatchUserSSHKey(userID string, definition UserPatchSSHKeyDefinition) error
+	PatchUserSSHKeyContext(ctx context.Context, userID string, definition UserPatchSSHKeyDefinition) error
+	GetOrganization() (*OrganizationsDefinition, error)
+	GetOrganizationContext(ctx context.Context) (*OrganizationsDefinition, error)
+	GetPermissions() (*PermissionDefinition, error)
+	GetPermissionsContext(ctx context.Context) (*PermissionDefinition, error)
+	GetQuotas() (*GetQuotas, error)
+	GetQuotasContext(ctx context.Context) (*GetQuotas, error)
+	GetDashboard() (*Dashboard, error)
+	GetDashboardContext(ctx context.Context) (*Dashboard, error)
+}
+
+// MarketplaceService manages the images of the marketplace
+type MarketplaceService interface {
+	GetMarketPlaceImages(uuidImage string) (*MarketImages, error)
+	GetMarketPlaceImagesContext(ctx context.Context, uuidImage string) (*MarketImages, error)
+	GetMarketPlaceImageVersions(uuidImage, uuidVersion string) (*MarketVersions, error)
+	GetMarketPlaceImageVersionsContext(ctx context.Context, uuidImage, uuidVersion string) (*MarketVersions, error)
+	GetMarketPlaceImageCurrentVersion(uuidImage string) (*MarketVersion, error)
+	GetMarketPlaceImageCurrentVersionContext(ctx context.Context, uuidImage string) (*MarketVersion, error)
+	GetMarketPlaceLocalImages(uuidImage, uuidVersion, uuidLocalImage string) (*MarketLocalImages, error)
+	GetMarketPlaceLocalImagesContext(ctx context.Context, uuidImage, uuidVersion, uuidLocalImage string) (*MarketLocalImages, error)
+	PostMarketPlaceImage(image MarketImage) error
+	PostMarketPlaceImageContext(ctx context.Context, image MarketImage) error
+	PostMarketPlaceImageVersion(uuidImage string, version MarketVersion) error
+	PostMarketPlaceImageVersionContext(ctx context.Context, uuidImage string, version MarketVersion) error
+	PostMarketPlaceLocalImage(uuidImage, uuidVersion, uuidLocalImage string, local MarketLocalImage) error
+	PostMarketPlaceLocalImageContext(ctx context.Context, uuidImage, uuidVersion, uuidLocalImage string, local MarketLocalImage) error
+	PutMarketPlaceImage(uuidImage string, image MarketImage) error
+	PutMarketPlaceImageContext(ctx context.Context, uuidImage string, image MarketImage) error
+	PutMarketPlaceImageVersion(uuidImage, uuidVersion string, version MarketVersion) error
+	PutMarketPlaceImageVersionContext(ctx context.Context, uuidImage, uuidVersion string, version MarketVersion) error
+	PutMarketPlaceLocalImage(uuidImage, uuidVersion, uuidLocalImage string, local MarketLocalImage) error
+	PutMarketPlaceLocalImageContext(ctx context.Context, uuidImage, uuidVersion, uuidLocalImage string, local MarketLocalImage) error
+	DeleteMarketPlaceImage(uuidImage string) error
+	DeleteMarketPlaceImageContext(ctx context.Context, uuidImage string) error
+	DeleteMarketPlaceImageVersion(uuidImage, uuidVersion string) error
+	DeleteMarketPlaceImageVersionContext(ctx context.Context, uuidImage, uuidVersion string) error
+	DeleteMarketPlaceLocalImage(uuidImage, uuidVersion, uuidLocalImage string) error
+	DeleteMarketPlaceLocalImageContext(ctx context.Context, uuidImage, uuidVersion, uuidLocalImage string) error
+}
+
+// API implements every service
+var (
+	_ ServerService        = (*API)(nil)
+	_ VolumeService        = (*API)(nil)
+	_ SnapshotService      = (*API)(nil)
+	_ ImageService         = (*API)(nil)
+	_ IPService            = (*API)(nil)
+	_ SecurityGroupService = (*API)(nil)
+	_ UserdataService      = (*API)(nil)
+	_ AccountService       = (*API)(nil)
+	_ MarketplaceService   = (*API)(nil)
+)
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -14,6 +15,22 @@ type SnapshotDefinition struct {
 	Organization     string `json:"organization"`
 }
 
+// SnapshotImportDefinition represents a  snapshot imported from an object
+// of Object Storage
+type SnapshotImportDefinition struct {
+	Name         string `json:"name"`
+	Organization string `json:"organization"`
+	Bucket       string `json:"bucket"`
+	Key          string `json:"key"`
+}
+
+// SnapshotExportDefinition represents the object of Object Storage a
+// snapshot is exported to
+type SnapshotExportDefinition struct {
+	Bucket string `json:"bucket"`
+	Key    string `json:"key"`
+}
+
 // Snapshot represents a  Snapshot
 type Snapshot struct {
 	// Identifier is a unique identifier for the snapshot
@@ -23,10 +40,10 @@ type Snapshot struct {
 	Name string `json:"name,omitempty"`
 
 	// CreationDate is the creation date of the snapshot
-	CreationDate string `json:"creation_date,omitempty"`
+	CreationDate Timestamp `json:"creation_date,omitzero"`
 
 	// ModificationDate is the date of the last modification of the snapshot
-	ModificationDate string `json:"modification_date,omitempty"`
+	ModificationDate Timestamp `json:"modification_date,omitzero"`
 
 	// Size is the allocated size of the volume
 	Size uint64 `json:"size,omitempty"`
@@ -57,12 +74,55 @@ type Snapshots struct {
 
 // PostSnapshot creates a new snapshot
 func (s *API) PostSnapshot(volumeID string, name string) (string, error) {
+	return s.PostSnapshotContext(context.Background(), volumeID, name)
+}
+
+// PostSnapshotContext is like PostSnapshot but uses ctx for the underlying requests
+func (s *API) PostSnapshotContext(ctx context.Context, volumeID string, name string) (_ string, err error) {
+	ctx, op := s.startOperation(ctx, "PostSnapshot", "snapshot", "")
+	defer op.End(&err)
+
 	definition := SnapshotDefinition{
 		VolumeIDentifier: volumeID,
 		Name:             name,
 		Organization:     s.Organization,
 	}
-	resp, err := s.PostResponse(s.computeAPI, "snapshots", definition)
+	resp, err := s.PostResponseContext(ctx, s.computeAPI, "snapshots", definition)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := s.handleHTTPError([]int{http.StatusCreated}, resp)
+	if err != nil {
+		return "", err
+	}
+	var snapshot OneSnapshot
+
+	if err = json.Unmarshal(body, &snapshot); err != nil {
+		return "", err
+	}
+	return snapshot.Snapshot.Identifier, nil
+}
+
+// ImportSnapshot creates a snapshot from an object of Object Storage,
+// exported by ExportSnapshot
+func (s *API) ImportSnapshot(bucket, key, name string) (string, error) {
+	return s.ImportSnapshotContext(context.Background(), bucket, key, name)
+}
+
+// ImportSnapshotContext is like ImportSnapshot but uses ctx for the underlying requests
+func (s *API) ImportSnapshotContext(ctx context.Context, bucket, key, name string) (_ string, err error) {
+	ctx, op := s.startOperation(ctx, "ImportSnapshot", "snapshot", "")
+	defer op.End(&err)
+
+	definition := SnapshotImportDefinition{
+		Name:         name,
+		Organization: s.Organization,
+		Bucket:       bucket,
+		Key:          key,
+	}
+	resp, err := s.PostResponseContext(ctx, s.computeAPI, "snapshots", definition)
 	if err != nil {
 		return "", err
 	}
@@ -80,9 +140,50 @@ func (s *API) PostSnapshot(volumeID string, name string) (string, error) {
 	return snapshot.Snapshot.Identifier, nil
 }
 
+// ExportSnapshot exports a snapshot to an object of Object Storage and
+// returns the task of the export
+func (s *API) ExportSnapshot(snapshotID, bucket, key string) (*Task, error) {
+	return s.ExportSnapshotContext(context.Background(), snapshotID, bucket, key)
+}
+
+// ExportSnapshotContext is like ExportSnapshot but uses ctx for the underlying requests
+func (s *API) ExportSnapshotContext(ctx context.Context, snapshotID, bucket, key string) (_ *Task, err error) {
+	ctx, op := s.startOperation(ctx, "ExportSnapshot", "snapshot", snapshotID)
+	defer op.End(&err)
+
+	definition := SnapshotExportDefinition{
+		Bucket: bucket,
+		Key:    key,
+	}
+	resp, err := s.PostResponseContext(ctx, s.computeAPI, fmt.Sprintf("snapshots/%s/export", snapshotID), definition)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := s.handleHTTPError([]int{http.StatusAccepted}, resp)
+	if err != nil {
+		return nil, err
+	}
+	var task OneTask
+
+	if err = json.Unmarshal(body, &task); err != nil {
+		return nil, err
+	}
+	return &task.Task, nil
+}
+
 // DeleteSnapshot deletes a snapshot
 func (s *API) DeleteSnapshot(snapshotID string) error {
-	resp, err := s.DeleteResponse(s.computeAPI, fmt.Sprintf("snapshots/%s", snapshotID))
+	return s.DeleteSnapshotContext(context.Background(), snapshotID)
+}
+
+// DeleteSnapshotContext is like DeleteSnapshot but uses ctx for the underlying requests
+func (s *API) DeleteSnapshotContext(ctx context.Context, snapshotID string) (err error) {
+	ctx, op := s.startOperation(ctx, "DeleteSnapshot", "snapshot", snapshotID)
+	defer op.End(&err)
+
+	resp, err := s.DeleteResponseContext(ctx, s.computeAPI, fmt.Sprintf("snapshots/%s", snapshotID))
 	if err != nil {
 		return err
 	}
@@ -96,9 +197,17 @@ func (s *API) DeleteSnapshot(snapshotID string) error {
 
 // GetSnapshots gets the list of snapshots from the API
 func (s *API) GetSnapshots() (*[]Snapshot, error) {
+	return s.GetSnapshotsContext(context.Background())
+}
+
+// GetSnapshotsContext is like GetSnapshots but uses ctx for the underlying requests
+func (s *API) GetSnapshotsContext(ctx context.Context) (_ *[]Snapshot, err error) {
+	ctx, op := s.startOperation(ctx, "GetSnapshots", "snapshot", "")
+	defer op.End(&err)
+
 	query := url.Values{}
 
-	resp, err := s.GetResponsePaginate(s.computeAPI, "snapshots", query)
+	resp, err := s.GetResponsePaginateContext(ctx, s.computeAPI, "snapshots", query)
 	if err != nil {
 		return nil, err
 	}
@@ -118,7 +227,15 @@ func (s *API) GetSnapshots() (*[]Snapshot, error) {
 
 // GetSnapshot gets a snapshot from the API
 func (s *API) GetSnapshot(snapshotID string) (*Snapshot, error) {
-	resp, err := s.GetResponsePaginate(s.computeAPI, "snapshots/"+snapshotID, url.Values{})
+	return s.GetSnapshotContext(context.Background(), snapshotID)
+}
+
+// GetSnapshotContext is like GetSnapshot but uses ctx for the underlying requests
+func (s *API) GetSnapshotContext(ctx context.Context, snapshotID string) (_ *Snapshot, err error) {
+	ctx, op := s.startOperation(ctx, "GetSnapshot", "snapshot", snapshotID)
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.computeAPI, "snapshots/"+snapshotID, url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -136,3 +253,14 @@ func (s *API) GetSnapshot(snapshotID string) (*Snapshot, error) {
 	// FIXME region, arch, owner, title
 	return &oneSnapshot.Snapshot, nil
 }
+
+// ListSnapshotsIter returns an iterator over the snapshots
+func (s *API) ListSnapshotsIter(ctx context.Context) *Iterator[Snapshot] {
+	return newIterator[Snapshot](ctx, s, listing{
+		operation:    "ListSnapshotsIter",
+		resourceType: "snapshot",
+		apiURL:       s.computeAPI,
+		resource:     "snapshots",
+		key:          "snapshots",
+	}, nil)
+}
@@ -1,21 +1,36 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 )
 
+// Statuses of the tasks
+const (
+	TaskPending = "pending"
+	TaskStarted = "started"
+	TaskSuccess = "success"
+	TaskFailure = "failure"
+)
+
+// ErrTaskFailed is matched by TaskError, i.e: errors.Is(err, api.ErrTaskFailed)
+var ErrTaskFailed = errors.New("task failed")
+
 // Task represents a  Task
 type Task struct {
 	// Identifier is a unique identifier for the task
 	Identifier string `json:"id,omitempty"`
 
 	// StartDate is the start date of the task
-	StartDate string `json:"started_at,omitempty"`
+	StartDate Timestamp `json:"started_at,omitzero"`
 
 	// TerminationDate is the termination date of the task
-	TerminationDate string `json:"terminated_at,omitempty"`
+	TerminationDate Timestamp `json:"terminated_at,omitzero"`
 
 	HrefFrom string `json:"href_from,omitempty"`
 
@@ -26,6 +41,72 @@ type Task struct {
 	Progress int `json:"progress,omitempty"`
 }
 
+// StartTime returns the start date of the task, zero if it isn't set
+//
+// Deprecated: use StartDate
+func (t Task) StartTime() time.Time {
+	return t.StartDate.Time
+}
+
+// TerminationTime returns the termination date of the task, zero until the
+// task terminates
+//
+// Deprecated: use TerminationDate
+func (t Task) TerminationTime() time.Time {
+	return t.TerminationDate.Time
+}
+
+// Done returns true once the task succeeded or failed
+func (t Task) Done() bool {
+	return t.Status == TaskSuccess || t.Status == TaskFailure
+}
+
+// TaskError is returned when a task ends in failure
+type TaskError struct {
+	Task Task
+}
+
+// Error returns a string representing the error
+func (e *TaskError) Error() string {
+	return fmt.Sprintf("task %s (%s) failed, from %s", e.Task.Identifier, e.Task.Description, e.Task.HrefFrom)
+}
+
+// Is matches ErrTaskFailed
+func (e *TaskError) Is(target error) bool {
+	return target == ErrTaskFailed
+}
+
+// TaskFilter selects tasks, its zero value selects every task
+type TaskFilter struct {
+	// Statuses are the accepted statuses, any if empty
+	Statuses []string
+
+	// HrefFrom is the resource the tasks come from, i.e: /servers/UUID/action
+	HrefFrom string
+
+	// StartedAfter and StartedBefore bound the start of the tasks, if not zero
+	StartedAfter  time.Time
+	StartedBefore time.Time
+}
+
+// Match returns true if task is selected by the filter
+func (f TaskFilter) Match(task Task) bool {
+	if len(f.Statuses) > 0 && !containsString(f.Statuses, task.Status) {
+		return false
+	}
+	if f.HrefFrom != "" && task.HrefFrom != f.HrefFrom {
+		return false
+	}
+	started := task.StartDate.Time
+	if !f.StartedAfter.IsZero() && started.Before(f.StartedAfter) {
+		return false
+	}
+	if !f.StartedBefore.IsZero() && !started.Before(f.StartedBefore) {
+		return false
+	}
+	return true
+}
+
 // OneTask represents the response of a GET /tasks/UUID API call
 type OneTask struct {
 	Task Task `json:"task,omitempty"`
@@ -39,8 +120,16 @@ type Tasks struct {
 
 // GetTasks get the list of tasks from the API
 func (s *API) GetTasks() (*[]Task, error) {
+	return s.GetTasksContext(context.Background())
+}
+
+// GetTasksContext is like GetTasks but uses ctx for the underlying requests
+func (s *API) GetTasksContext(ctx context.Context) (_ *[]Task, err error) {
+	ctx, op := s.startOperation(ctx, "GetTasks", "task", "")
+	defer op.End(&err)
+
 	query := url.Values{}
-	resp, err := s.GetResponsePaginate(s.computeAPI, "tasks", query)
+	resp, err := s.GetResponsePaginateContext(ctx, s.computeAPI, "tasks", query)
 	if err != nil {
 		return nil, err
 	}
@@ -57,3 +146,137 @@ func (s *API) GetTasks() (*[]Task, error) {
 	}
 	return &tasks.Tasks, nil
 }
+
+// ListTasksIter returns an iterator over the tasks
+func (s *API) ListTasksIter(ctx context.Context) *Iterator[Task] {
+	return newIterator[Task](ctx, s, listing{
+		operation:    "ListTasksIter",
+		resourceType: "task",
+		apiURL:       s.computeAPI,
+		resource:     "tasks",
+		key:          "tasks",
+	}, nil)
+}
+
+// GetTask returns a task
+func (s *API) GetTask(taskID string) (*Task, error) {
+	return s.GetTaskContext(context.Background(), taskID)
+}
+
+// GetTaskContext is like GetTask but uses ctx for the underlying requests
+func (s *API) GetTaskContext(ctx context.Context, taskID string) (_ *Task, err error) {
+	ctx, op := s.startOperation(ctx, "GetTask", "task", taskID)
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.computeAPI, fmt.Sprintf("tasks/%s", taskID), url.Values{})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := s.handleHTTPError([]int{http.StatusOK}, resp)
+	if err != nil {
+		return nil, err
+	}
+	var oneTask OneTask
+
+	if err = json.Unmarshal(body, &oneTask); err != nil {
+		return nil, err
+	}
+	return &oneTask.Task, nil
+}
+
+// FilterTasks returns the tasks selected by filter, the tasks are filtered
+// client-side as the API doesn't support it
+func (s *API) FilterTasks(filter TaskFilter) ([]Task, error) {
+	return s.FilterTasksContext(context.Background(), filter)
+}
+
+// FilterTasksContext is like FilterTasks but uses ctx for the underlying requests
+func (s *API) FilterTasksContext(ctx context.Context, filter TaskFilter) (_ []Task, err error) {
+	ctx, op := s.startOperation(ctx, "FilterTasks", "task", "")
+	defer op.End(&err)
+
+	tasks, err := s.GetTasksContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret := []Task{}
+	for _, task := range *tasks {
+		if filter.Match(task) {
+			ret = append(ret, task)
+		}
+	}
+	return ret, nil
+}
+
+// TaskWaitOptions configures the polling of WaitForTask and of the tasks of
+// the server actions, the zero value is usable
+type TaskWaitOptions struct {
+	// Interval is the delay between the first two polls, 1s by default
+	Interval time.Duration
+
+	// MaxInterval caps the delay between two polls, 10s by default
+	MaxInterval time.Duration
+
+	// Multiplier increases the delay after each poll, 1.5 by default
+	Multiplier float64
+
+	// Timeout bounds the wait, besides the context (0 means no timeout)
+	Timeout time.Duration
+
+	// OnProgress is called with the first task polled, then each time its
+	// progress or its status changes
+	OnProgress func(Task)
+}
+
+// WaitForTask polls a task until it terminates, according to opts. It returns
+// a *TaskError if the task fails.
+func (s *API) WaitForTask(ctx context.Context, taskID string, opts TaskWaitOptions) (_ *Task, err error) {
+	ctx, op := s.startOperation(ctx, "WaitForTask", "task", taskID)
+	defer op.End(&err)
+
+	task, err := s.GetTaskContext(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return s.waitTask(ctx, task, opts)
+}
+
+// waitTask polls a task until it terminates
+func (s *API) waitTask(ctx context.Context, task *Task, opts TaskWaitOptions) (*Task, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	interval, maxInterval, multiplier := WaitOptions{
+		Interval:    opts.Interval,
+		MaxInterval: opts.MaxInterval,
+		Multiplier:  opts.Multiplier,
+	}.intervals()
+	var last *Task
+	for {
+		if opts.OnProgress != nil && (last == nil || last.Progress != task.Progress || last.Status != task.Status) {
+			opts.OnProgress(*task)
+		}
+		last = task
+		switch task.Status {
+		case TaskSuccess:
+			return task, nil
+		case TaskFailure:
+			return task, &TaskError{Task: *task}
+		}
+		if err := sleep(ctx, interval); err != nil {
+			return task, fmt.Errorf("waiting for task %s, last status %s: %w", task.Identifier, task.Status, err)
+		}
+		if interval = time.Duration(float64(interval) * multiplier); interval > maxInterval {
+			interval = maxInterval
+		}
+		next, err := s.GetTaskContext(ctx, task.Identifier)
+		if err != nil {
+			return task, err
+		}
+		task = next
+	}
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+var testTaskWaitOptions = TaskWaitOptions{
+	Interval:    time.Millisecond,
+	MaxInterval: 5 * time.Millisecond,
+}
+
+func TestWaitForTask(t *testing.T) {
+	s, srv := newFakeAPI(t)
+	srv.TransitionReads = 100
+
+	serverID := srv.AddServer("web", "VC1S")
+	task, err := s.PowerOn(serverID, ActionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var progress []string
+	opts := testTaskWaitOptions
+	opts.OnProgress = func(task Task) {
+		progress = append(progress, fmt.Sprintf("%s %d", task.Status, task.Progress))
+		switch task.Progress {
+		case 0:
+			srv.SetTaskStatus(task.Identifier, TaskStarted, 50)
+		case 50:
+			srv.SetTaskStatus(task.Identifier, TaskFailure, 80)
+		}
+	}
+	done, err := s.WaitForTask(context.Background(), task.Identifier, opts)
+	var taskErr *TaskError
+	if !errors.Is(err, ErrTaskFailed) || !errors.As(err, &taskErr) || taskErr.Task.Identifier != task.Identifier {
+		t.Fatalf("expected a TaskError, got %v", err)
+	}
+	if fmt.Sprint(progress) != "[pending 0 started 50 failure 80]" {
+		t.Errorf("unexpected progress: %v", progress)
+	}
+	if done.Status != TaskFailure || done.TerminationDate.IsZero() {
+		t.Errorf("expected the task to be terminated, got %+v", done)
+	}
+	if done.StartDate.IsZero() || done.TerminationDate.Before(done.StartDate.Time) {
+		t.Errorf("unexpected dates: %s, %s", done.StartDate, done.TerminationDate)
+	}
+	if !done.StartTime().Equal(done.StartDate.Time) || !done.TerminationTime().Equal(done.TerminationDate.Time) {
+		t.Errorf("unexpected times: %s, %s", done.StartTime(), done.TerminationTime())
+	}
+
+	if _, err = s.WaitForTask(context.Background(), "missing", testTaskWaitOptions); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	// the options only apply to their call
+	pending, err := s.PowerOn(srv.AddServer("db", "VC1S"), ActionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts = testTaskWaitOptions
+	opts.Timeout = 20 * time.Millisecond
+	if _, err = s.WaitForTask(context.Background(), pending.Identifier, opts); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the wait to time out, got %v", err)
+	}
+	srv.SetTaskStatus(pending.Identifier, TaskSuccess, 100)
+	if done, err = s.WaitForTask(context.Background(), pending.Identifier, testTaskWaitOptions); err != nil || done.Status != TaskSuccess {
+		t.Errorf("expected the task to succeed, got %v", err)
+	}
+}
+
+func TestFilterTasks(t *testing.T) {
+	s, srv := newFakeAPI(t)
+	srv.TransitionReads = 100
+
+	web := srv.AddServer("web", "VC1S")
+	db := srv.AddServer("db", "VC1S")
+	before := time.Now().Add(-time.Minute)
+	first, err := s.PowerOn(web, ActionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := s.PowerOn(db, ActionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.SetTaskStatus(second.Identifier, TaskSuccess, 100)
+
+	got, err := s.GetTask(second.Identifier)
+	if err != nil || got.Status != TaskSuccess || got.Progress != 100 {
+		t.Errorf("unexpected task: %+v, %v", got, err)
+	}
+
+	metrics := NewMemoryMetrics()
+	s.SetMetrics(metrics)
+	for _, tt := range []struct {
+		name   string
+		filter TaskFilter
+		want   []string
+	}{
+		{"all", TaskFilter{}, []string{first.Identifier, second.Identifier}},
+		{"status", TaskFilter{Statuses: []string{TaskPending, TaskStarted}}, []string{first.Identifier}},
+		{"href", TaskFilter{HrefFrom: second.HrefFrom}, []string{second.Identifier}},
+		{"started after", TaskFilter{StartedAfter: before}, []string{first.Identifier, second.Identifier}},
+		{"started before", TaskFilter{StartedBefore: before}, []string{}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := s.FilterTasks(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids := []string{}
+			for _, task := range tasks {
+				ids = append(ids, task.Identifier)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, ids)
+			}
+		})
+	}
+	if stats := metrics.Operation("FilterTasks"); stats.Calls != 5 {
+		t.Errorf("expected 5 FilterTasks operations, got %+v", stats)
+	}
+}
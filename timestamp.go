@@ -0,0 +1,152 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TimestampLayout is the layout of the dates sent by the API
+const TimestampLayout = "2006-01-02T15:04:05.000000+00:00"
+
+// timestampLayouts are the layouts accepted by Timestamp, the dates without
+// timezone are in UTC
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// Timestamp is a date of the API. It keeps the text it was decoded from to
+// encode it back unchanged, the zero Timestamp is encoded as an empty string
+// and omitted from the fields tagged with omitzero.
+type Timestamp struct {
+	time.Time
+
+	raw string
+}
+
+// NewTimestamp returns the Timestamp of t, encoded with TimestampLayout
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t}
+}
+
+// ParseTimestamp parses a date in one of the formats of the API, with or
+// without fractional seconds and timezone
+func ParseTimestamp(value string) (Timestamp, error) {
+	if value == "" {
+		return Timestamp{}, nil
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return Timestamp{Time: t, raw: value}, nil
+		}
+	}
+	return Timestamp{}, fmt.Errorf("invalid timestamp %q", value)
+}
+
+// String returns the date as sent by the API, or formatted with TimestampLayout
+func (t Timestamp) String() string {
+	if t.raw != "" {
+		return t.raw
+	}
+	if t.Time.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(TimestampLayout)
+}
+
+// IsZero returns true if the date isn't set
+func (t Timestamp) IsZero() bool {
+	return t.raw == "" && t.Time.IsZero()
+}
+
+// MarshalJSON encodes the date as sent by the API
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON decodes a date of the API, null or "" give the zero Timestamp
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*t = Timestamp{}
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("invalid timestamp %s: %v", data, err)
+	}
+	parsed, err := ParseTimestamp(strings.TrimSpace(value))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// Dated is implemented by the resources with a creation and a modification date
+type Dated interface {
+	Created() Timestamp
+	Modified() Timestamp
+}
+
+// SortByCreationDate sorts items by creation date, the oldest first unless
+// descending is true. The order of the items created at the same time is kept.
+func SortByCreationDate[T Dated](items []T, descending bool) {
+	sortByDate(items, T.Created, descending)
+}
+
+// SortByModificationDate sorts items by modification date, the oldest first
+// unless descending is true. The order of the items modified at the same time is kept.
+func SortByModificationDate[T Dated](items []T, descending bool) {
+	sortByDate(items, T.Modified, descending)
+}
+
+func sortByDate[T any](items []T, date func(T) Timestamp, descending bool) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if descending {
+			return date(items[j]).Before(date(items[i]).Time)
+		}
+		return date(items[i]).Before(date(items[j]).Time)
+	})
+}
+
+// Created returns the creation date of the server
+func (s Server) Created() Timestamp { return s.CreationDate }
+
+// Modified returns the date of the last modification of the server
+func (s Server) Modified() Timestamp { return s.ModificationDate }
+
+// Created returns the creation date of the volume
+func (v Volume) Created() Timestamp { return v.CreationDate }
+
+// Modified returns the date of the last modification of the volume
+func (v Volume) Modified() Timestamp { return v.ModificationDate }
+
+// Created returns the creation date of the snapshot
+func (s Snapshot) Created() Timestamp { return s.CreationDate }
+
+// Modified returns the date of the last modification of the snapshot
+func (s Snapshot) Modified() Timestamp { return s.ModificationDate }
+
+// Created returns the creation date of the image
+func (i Image) Created() Timestamp { return i.CreationDate }
+
+// Modified returns the date of the last modification of the image
+func (i Image) Modified() Timestamp { return i.ModificationDate }
+
+// Created returns the creation date of the marketplace image
+func (m MarketImage) Created() Timestamp { return m.CreationDate }
+
+// Modified returns the date of the last modification of the marketplace image
+func (m MarketImage) Modified() Timestamp { return m.ModificationDate }
+
+// Created returns the creation date of the marketplace version
+func (m MarketVersionDefinition) Created() Timestamp { return m.CreationDate }
+
+// Modified returns the date of the last modification of the marketplace version
+func (m MarketVersionDefinition) Modified() Timestamp { return m.ModificationDate }
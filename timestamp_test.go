@@ -0,0 +1,102 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimestamp(t *testing.T) {
+	want := time.Date(2016, 9, 1, 10, 30, 15, 0, time.UTC)
+	for _, value := range []string{
+		"2016-09-01T10:30:15.000000+00:00",
+		"2016-09-01T10:30:15+00:00",
+		"2016-09-01T10:30:15Z",
+		"2016-09-01T12:30:15.000+02:00",
+		"2016-09-01T10:30:15.000000",
+		"2016-09-01T10:30:15",
+		"2016-09-01 10:30:15.000000+00:00",
+	} {
+		var volume Volume
+		content := fmt.Sprintf(`{"creation_date":%q,"modification_date":null}`, value)
+		if err := json.Unmarshal([]byte(content), &volume); err != nil {
+			t.Errorf("%s: %v", value, err)
+			continue
+		}
+		if !volume.CreationDate.Equal(want) || !volume.ModificationDate.IsZero() {
+			t.Errorf("%s: unexpected dates %v, %v", value, volume.CreationDate.Time, volume.ModificationDate.Time)
+		}
+		// the dates are encoded back unchanged
+		encoded, err := json.Marshal(volume.CreationDate)
+		if err != nil || string(encoded) != fmt.Sprintf("%q", value) {
+			t.Errorf("%s: unexpected encoding %s, %v", value, encoded, err)
+		}
+	}
+
+	var task Task
+	if err := json.Unmarshal([]byte(`{"started_at":"yesterday"}`), &task); err == nil {
+		t.Error("expected an invalid date to fail")
+	}
+	if encoded, _ := json.Marshal(NewTimestamp(want.In(time.FixedZone("CEST", 7200)))); string(encoded) != `"2016-09-01T10:30:15.000000+00:00"` {
+		t.Errorf("unexpected encoding of a new timestamp: %s", encoded)
+	}
+	if encoded, _ := json.Marshal(Timestamp{}); string(encoded) != `""` {
+		t.Errorf("unexpected encoding of the zero timestamp: %s", encoded)
+	}
+}
+
+func TestTimestamp_patchBody(t *testing.T) {
+	volumes := map[string]Volume{"0": {Identifier: "volume"}}
+	body, err := json.Marshal(ServerPatchDefinition{Image: &Image{Identifier: "image"}, Volumes: &volumes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"image":{"id":"image","root_volume":{}},"volumes":{"0":{"id":"volume"}}}`; string(body) != want {
+		t.Errorf("unexpected body:\n%s\nexpected:\n%s", body, want)
+	}
+	if body, _ = json.Marshal(MarketImage{}); !strings.Contains(string(body), `"creation_date":"","current_public_version":"","description":"","id":"","logo":"","modification_date":""`) {
+		t.Errorf("expected the zero dates to be empty strings, got %s", body)
+	}
+}
+
+func TestSortByCreationDate(t *testing.T) {
+	date := func(value string) Timestamp {
+		timestamp, err := ParseTimestamp(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return timestamp
+	}
+	servers := []Server{
+		{Name: "b", CreationDate: date("2016-09-02T00:00:00Z"), ModificationDate: date("2016-09-03T00:00:00Z")},
+		{Name: "a", CreationDate: date("2016-09-01T00:00:00.5Z"), ModificationDate: date("2016-09-04T00:00:00Z")},
+		{Name: "c", CreationDate: date("2016-09-02T00:00:00+00:00")},
+	}
+	names := func() string {
+		var ret []string
+		for _, server := range servers {
+			ret = append(ret, server.Name)
+		}
+		return fmt.Sprint(ret)
+	}
+	SortByCreationDate(servers, false)
+	if names() != "[a b c]" {
+		t.Errorf("unexpected order: %s", names())
+	}
+	SortByModificationDate(servers, true)
+	if names() != "[a b c]" {
+		t.Errorf("unexpected order: %s", names())
+	}
+	SortByCreationDate(servers, true)
+	if names() != "[b c a]" {
+		t.Errorf("unexpected order: %s", names())
+	}
+
+	snapshots := []Snapshot{{Name: "new", CreationDate: date("2017-01-01T00:00:00Z")}, {Name: "old", CreationDate: date("2016-01-01T00:00:00Z")}}
+	SortByCreationDate(snapshots, false)
+	if snapshots[0].Name != "old" {
+		t.Errorf("unexpected order: %v", snapshots)
+	}
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// tracerName is the instrumentation name of the spans
+const tracerName = "github.com/smola/scaleway-sdk"
+
+// SetTracerProvider registers the provider of the tracer used to create a
+// span for each operation, and a child span for each HTTP request it sends.
+// Spans are discarded by default.
+func (s *API) SetTracerProvider(provider trace.TracerProvider) {
+	s.tracer = provider.Tracer(tracerName)
+}
+
+func noopTracer() trace.Tracer {
+	return noop.NewTracerProvider().Tracer(tracerName)
+}
+
+// tracing wraps next to create a span for each HTTP request
+func (s *API) tracing(next HTTPClient) HTTPClient {
+	return HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+		ctx, span := s.tracer.Start(req.Context(), req.Method,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("http.request.method", req.Method),
+				attribute.String("url.full", s.redact(req.URL.String())),
+				attribute.String("server.address", req.URL.Host),
+			),
+		)
+		defer span.End()
+
+		resp, err := next.Do(req.WithContext(ctx))
+		if err != nil {
+			msg := s.redact(err.Error())
+			span.RecordError(errors.New(msg))
+			span.SetStatus(codes.Error, msg)
+			return nil, err
+		}
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		if resp.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+		}
+		return resp, nil
+	})
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+type recordedSpan struct {
+	noop.Span
+
+	name   string
+	parent *recordedSpan
+	attrs  map[attribute.Key]interface{}
+	status codes.Code
+	ended  bool
+}
+
+func (s *recordedSpan) SetAttributes(kv ...attribute.KeyValue) {
+	for _, attr := range kv {
+		s.attrs[attr.Key] = attr.Value.AsInterface()
+	}
+}
+
+func (s *recordedSpan) SetStatus(code codes.Code, description string) {
+	s.status = code
+}
+
+func (s *recordedSpan) End(options ...trace.SpanEndOption) {
+	s.ended = true
+}
+
+type recordedSpanKey struct{}
+
+// spanRecorder is a Tracer recording the spans it starts
+type spanRecorder struct {
+	noop.Tracer
+
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+type recorderProvider struct {
+	noop.TracerProvider
+
+	recorder *spanRecorder
+}
+
+func (p recorderProvider) Tracer(name string, options ...trace.TracerOption) trace.Tracer {
+	return p.recorder
+}
+
+func (r *spanRecorder) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	span := &recordedSpan{
+		name:  name,
+		attrs: make(map[attribute.Key]interface{}),
+	}
+	span.parent, _ = ctx.Value(recordedSpanKey{}).(*recordedSpan)
+	cfg := trace.NewSpanStartConfig(opts...)
+	span.SetAttributes(cfg.Attributes()...)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, span)
+	return context.WithValue(ctx, recordedSpanKey{}, span), span
+}
+
+func TestTracing_Spans(t *testing.T) {
+	s := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/servers/2" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"type": "unknown_resource", "message": "not found"}`))
+			return
+		}
+		w.Write([]byte(`{"server": {"id": "1"}}`))
+	}))
+	recorder := &spanRecorder{}
+	s.SetTracerProvider(recorderProvider{recorder: recorder})
+
+	if _, err := s.GetServer("1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(recorder.spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(recorder.spans))
+	}
+	operation := recorder.spans[0]
+	if operation.name != "GetServer" || operation.parent != nil || !operation.ended {
+		t.Errorf("expected an ended GetServer root span, got %+v", operation)
+	}
+	for key, expected := range map[attribute.Key]interface{}{
+		"scaleway.region":        "par1",
+		"scaleway.resource.type": "server",
+		"scaleway.resource.id":   "1",
+	} {
+		if operation.attrs[key] != expected {
+			t.Errorf("expected %s to be %v, got %v", key, expected, operation.attrs[key])
+		}
+	}
+	for i, method := range []string{"HEAD", "GET"} {
+		span := recorder.spans[i+1]
+		if span.name != method || span.parent != operation {
+			t.Errorf("expected a %s child span, got %s", method, span.name)
+		}
+		if span.attrs["http.response.status_code"] != int64(http.StatusOK) {
+			t.Errorf("expected status code 200, got %v", span.attrs["http.response.status_code"])
+		}
+	}
+
+	recorder.spans = nil
+	if _, err := s.GetServer("2"); err == nil {
+		t.Fatal("expected GetServer to fail")
+	}
+	if recorder.spans[0].status != codes.Error {
+		t.Errorf("expected the operation span to be marked as failed")
+	}
+}
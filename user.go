@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -63,7 +64,15 @@ type UserPatchSSHKeyDefinition struct {
 
 // PatchUserSSHKey updates a user
 func (s *API) PatchUserSSHKey(UserID string, definition UserPatchSSHKeyDefinition) error {
-	resp, err := s.PatchResponse(AccountAPI, fmt.Sprintf("users/%s", UserID), definition)
+	return s.PatchUserSSHKeyContext(context.Background(), UserID, definition)
+}
+
+// PatchUserSSHKeyContext is like PatchUserSSHKey but uses ctx for the underlying requests
+func (s *API) PatchUserSSHKeyContext(ctx context.Context, UserID string, definition UserPatchSSHKeyDefinition) (err error) {
+	ctx, op := s.startOperation(ctx, "PatchUserSSHKey", "user", UserID)
+	defer op.End(&err)
+
+	resp, err := s.PatchResponseContext(ctx, s.accountAPI, fmt.Sprintf("users/%s", UserID), definition)
 	if err != nil {
 		return err
 	}
@@ -78,7 +87,15 @@ func (s *API) PatchUserSSHKey(UserID string, definition UserPatchSSHKeyDefinitio
 
 // GetUserID returns the userID
 func (s *API) GetUserID() (string, error) {
-	resp, err := s.GetResponsePaginate(AccountAPI, fmt.Sprintf("tokens/%s", s.Token), url.Values{})
+	return s.GetUserIDContext(context.Background())
+}
+
+// GetUserIDContext is like GetUserID but uses ctx for the underlying requests
+func (s *API) GetUserIDContext(ctx context.Context) (_ string, err error) {
+	ctx, op := s.startOperation(ctx, "GetUserID", "token", "")
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.accountAPI, fmt.Sprintf("tokens/%s", s.Token), url.Values{})
 	if err != nil {
 		return "", err
 	}
@@ -98,11 +115,19 @@ func (s *API) GetUserID() (string, error) {
 
 // GetUser returns the user
 func (s *API) GetUser() (*UserDefinition, error) {
-	userID, err := s.GetUserID()
+	return s.GetUserContext(context.Background())
+}
+
+// GetUserContext is like GetUser but uses ctx for the underlying requests
+func (s *API) GetUserContext(ctx context.Context) (_ *UserDefinition, err error) {
+	ctx, op := s.startOperation(ctx, "GetUser", "user", "")
+	defer op.End(&err)
+
+	userID, err := s.GetUserIDContext(ctx)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := s.GetResponsePaginate(AccountAPI, fmt.Sprintf("users/%s", userID), url.Values{})
+	resp, err := s.GetResponsePaginateContext(ctx, s.accountAPI, fmt.Sprintf("users/%s", userID), url.Values{})
 	if err != nil {
 		return nil, err
 	}
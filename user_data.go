@@ -2,9 +2,9 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
@@ -20,17 +20,25 @@ type Userdata []byte
 
 // GetUserdatas gets list of userdata for a server
 func (s *API) GetUserdatas(serverID string, metadata bool) (*Userdatas, error) {
+	return s.GetUserdatasContext(context.Background(), serverID, metadata)
+}
+
+// GetUserdatasContext is like GetUserdatas but uses ctx for the underlying requests
+func (s *API) GetUserdatasContext(ctx context.Context, serverID string, metadata bool) (_ *Userdatas, err error) {
+	ctx, op := s.startOperation(ctx, "GetUserdatas", "userdata", serverID)
+	defer op.End(&err)
+
 	var uri, endpoint string
 
 	endpoint = s.computeAPI
 	if metadata {
 		uri = "/user_data"
-		endpoint = MetadataAPI
+		endpoint = s.metadataAPI
 	} else {
 		uri = fmt.Sprintf("servers/%s/user_data", serverID)
 	}
 
-	resp, err := s.GetResponsePaginate(endpoint, uri, url.Values{})
+	resp, err := s.GetResponsePaginateContext(ctx, endpoint, uri, url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -54,39 +62,54 @@ func (s *Userdata) String() string {
 
 // GetUserdata gets a specific userdata for a server
 func (s *API) GetUserdata(serverID, key string, metadata bool) (*Userdata, error) {
+	return s.GetUserdataContext(context.Background(), serverID, key, metadata)
+}
+
+// GetUserdataContext is like GetUserdata but uses ctx for the underlying requests
+func (s *API) GetUserdataContext(ctx context.Context, serverID, key string, metadata bool) (_ *Userdata, err error) {
+	ctx, op := s.startOperation(ctx, "GetUserdata", "userdata", serverID)
+	defer op.End(&err)
+
 	var uri, endpoint string
 
 	endpoint = s.computeAPI
 	if metadata {
 		uri = fmt.Sprintf("/user_data/%s", key)
-		endpoint = MetadataAPI
+		endpoint = s.metadataAPI
 	} else {
 		uri = fmt.Sprintf("servers/%s/user_data/%s", serverID, key)
 	}
 
-	var err error
-	resp, err := s.GetResponsePaginate(endpoint, uri, url.Values{})
+	resp, err := s.GetResponsePaginateContext(ctx, endpoint, uri, url.Values{})
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("no such user_data %q (%d)", key, resp.StatusCode)
+	body, err := s.handleHTTPError([]int{http.StatusOK}, resp)
+	if err != nil {
+		return nil, err
 	}
-	var data Userdata
-	data, err = ioutil.ReadAll(resp.Body)
-	return &data, err
+	data := Userdata(body)
+	return &data, nil
 }
 
 // PatchUserdata sets a user data
 func (s *API) PatchUserdata(serverID, key string, value []byte, metadata bool) error {
+	return s.PatchUserdataContext(context.Background(), serverID, key, value, metadata)
+}
+
+// PatchUserdataContext is like PatchUserdata but uses ctx for the underlying requests
+func (s *API) PatchUserdataContext(ctx context.Context, serverID, key string, value []byte, metadata bool) (err error) {
+	ctx, op := s.startOperation(ctx, "PatchUserdata", "userdata", serverID)
+	defer op.End(&err)
+
 	var resource, endpoint string
 
 	endpoint = s.computeAPI
 	if metadata {
 		resource = fmt.Sprintf("/user_data/%s", key)
-		endpoint = MetadataAPI
+		endpoint = s.metadataAPI
 	} else {
 		resource = fmt.Sprintf("servers/%s/user_data/%s", serverID, key)
 	}
@@ -95,41 +118,37 @@ func (s *API) PatchUserdata(serverID, key string, value []byte, metadata bool) e
 	payload := new(bytes.Buffer)
 	payload.Write(value)
 
-	req, err := http.NewRequest("PATCH", uri, payload)
-	if err != nil {
-		return err
-	}
-
-	req.Header.Set("X-Auth-Token", s.Token)
-	req.Header.Set("Content-Type", "text/plain")
-	req.Header.Set("User-Agent", s.userAgent)
-
-	resp, err := s.client.Do(req)
+	resp, err := s.request(ctx, "PATCH", uri, "text/plain", payload)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusNoContent {
-		return nil
-	}
-
-	return fmt.Errorf("cannot set user_data (%d)", resp.StatusCode)
+	_, err = s.handleHTTPError([]int{http.StatusNoContent}, resp)
+	return err
 }
 
 // DeleteUserdata deletes a server user_data
 func (s *API) DeleteUserdata(serverID, key string, metadata bool) error {
+	return s.DeleteUserdataContext(context.Background(), serverID, key, metadata)
+}
+
+// DeleteUserdataContext is like DeleteUserdata but uses ctx for the underlying requests
+func (s *API) DeleteUserdataContext(ctx context.Context, serverID, key string, metadata bool) (err error) {
+	ctx, op := s.startOperation(ctx, "DeleteUserdata", "userdata", serverID)
+	defer op.End(&err)
+
 	var url, endpoint string
 
 	endpoint = s.computeAPI
 	if metadata {
 		url = fmt.Sprintf("/user_data/%s", key)
-		endpoint = MetadataAPI
+		endpoint = s.metadataAPI
 	} else {
 		url = fmt.Sprintf("servers/%s/user_data/%s", serverID, key)
 	}
 
-	resp, err := s.DeleteResponse(endpoint, url)
+	resp, err := s.DeleteResponseContext(ctx, endpoint, url)
 	if err != nil {
 		return err
 	}
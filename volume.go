@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -16,10 +17,10 @@ type Volume struct {
 	Size uint64 `json:"size,omitempty"`
 
 	// CreationDate is the creation date of the volume
-	CreationDate string `json:"creation_date,omitempty"`
+	CreationDate Timestamp `json:"creation_date,omitzero"`
 
 	// ModificationDate is the date of the last modification of the volume
-	ModificationDate string `json:"modification_date,omitempty"`
+	ModificationDate Timestamp `json:"modification_date,omitzero"`
 
 	// Organization is the organization owning the volume
 	Organization string `json:"organization,omitempty"`
@@ -36,6 +37,9 @@ type Volume struct {
 	// VolumeType is a  identifier for the kind of volume (default: l_ssd)
 	VolumeType string `json:"volume_type,omitempty"`
 
+	// Tags represents user-defined tags
+	Tags []string `json:"tags,omitempty"`
+
 	// ExportURI represents the url used by initrd/scripts to attach the volume
 	ExportURI string `json:"export_uri,omitempty"`
 }
@@ -57,6 +61,9 @@ type VolumeDefinition struct {
 
 	// Organization is the owner of the volume
 	Organization string `json:"organization"`
+
+	// BaseSnapshot is the snapshot the volume is created from, Size may be 0 then
+	BaseSnapshot string `json:"base_snapshot,omitempty"`
 }
 
 // VolumePutDefinition represents a  volume with nullable fields (for PUT)
@@ -77,12 +84,20 @@ type VolumePutDefinition struct {
 
 // PostVolume creates a new volume
 func (s *API) PostVolume(definition VolumeDefinition) (string, error) {
+	return s.PostVolumeContext(context.Background(), definition)
+}
+
+// PostVolumeContext is like PostVolume but uses ctx for the underlying requests
+func (s *API) PostVolumeContext(ctx context.Context, definition VolumeDefinition) (_ string, err error) {
+	ctx, op := s.startOperation(ctx, "PostVolume", "volume", "")
+	defer op.End(&err)
+
 	definition.Organization = s.Organization
 	if definition.Type == "" {
 		definition.Type = "l_ssd"
 	}
 
-	resp, err := s.PostResponse(s.computeAPI, "volumes", definition)
+	resp, err := s.PostResponseContext(ctx, s.computeAPI, "volumes", definition)
 	if err != nil {
 		return "", err
 	}
@@ -102,7 +117,15 @@ func (s *API) PostVolume(definition VolumeDefinition) (string, error) {
 
 // PutVolume updates a volume
 func (s *API) PutVolume(volumeID string, definition VolumePutDefinition) error {
-	resp, err := s.PutResponse(s.computeAPI, fmt.Sprintf("volumes/%s", volumeID), definition)
+	return s.PutVolumeContext(context.Background(), volumeID, definition)
+}
+
+// PutVolumeContext is like PutVolume but uses ctx for the underlying requests
+func (s *API) PutVolumeContext(ctx context.Context, volumeID string, definition VolumePutDefinition) (err error) {
+	ctx, op := s.startOperation(ctx, "PutVolume", "volume", volumeID)
+	defer op.End(&err)
+
+	resp, err := s.PutResponseContext(ctx, s.computeAPI, fmt.Sprintf("volumes/%s", volumeID), definition)
 	if err != nil {
 		return err
 	}
@@ -114,7 +137,15 @@ func (s *API) PutVolume(volumeID string, definition VolumePutDefinition) error {
 
 // DeleteVolume deletes a volume
 func (s *API) DeleteVolume(volumeID string) error {
-	resp, err := s.DeleteResponse(s.computeAPI, fmt.Sprintf("volumes/%s", volumeID))
+	return s.DeleteVolumeContext(context.Background(), volumeID)
+}
+
+// DeleteVolumeContext is like DeleteVolume but uses ctx for the underlying requests
+func (s *API) DeleteVolumeContext(ctx context.Context, volumeID string) (err error) {
+	ctx, op := s.startOperation(ctx, "DeleteVolume", "volume", volumeID)
+	defer op.End(&err)
+
+	resp, err := s.DeleteResponseContext(ctx, s.computeAPI, fmt.Sprintf("volumes/%s", volumeID))
 	if err != nil {
 		return err
 	}
@@ -132,9 +163,17 @@ type volumesResponse struct {
 
 // GetVolumes gets the list of volumes from the API
 func (s *API) GetVolumes() (*[]Volume, error) {
+	return s.GetVolumesContext(context.Background())
+}
+
+// GetVolumesContext is like GetVolumes but uses ctx for the underlying requests
+func (s *API) GetVolumesContext(ctx context.Context) (_ *[]Volume, err error) {
+	ctx, op := s.startOperation(ctx, "GetVolumes", "volume", "")
+	defer op.End(&err)
+
 	query := url.Values{}
 
-	resp, err := s.GetResponsePaginate(s.computeAPI, "volumes", query)
+	resp, err := s.GetResponsePaginateContext(ctx, s.computeAPI, "volumes", query)
 	if err != nil {
 		return nil, err
 	}
@@ -155,7 +194,15 @@ func (s *API) GetVolumes() (*[]Volume, error) {
 
 // GetVolume gets a volume from the API
 func (s *API) GetVolume(volumeID string) (*Volume, error) {
-	resp, err := s.GetResponsePaginate(s.computeAPI, "volumes/"+volumeID, url.Values{})
+	return s.GetVolumeContext(context.Background(), volumeID)
+}
+
+// GetVolumeContext is like GetVolume but uses ctx for the underlying requests
+func (s *API) GetVolumeContext(ctx context.Context, volumeID string) (_ *Volume, err error) {
+	ctx, op := s.startOperation(ctx, "GetVolume", "volume", volumeID)
+	defer op.End(&err)
+
+	resp, err := s.GetResponsePaginateContext(ctx, s.computeAPI, "volumes/"+volumeID, url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -173,3 +220,14 @@ func (s *API) GetVolume(volumeID string) (*Volume, error) {
 	// FIXME region, arch, owner, title
 	return &oneVolume.Volume, nil
 }
+
+// ListVolumesIter returns an iterator over the volumes
+func (s *API) ListVolumesIter(ctx context.Context) *Iterator[Volume] {
+	return newIterator[Volume](ctx, s, listing{
+		operation:    "ListVolumesIter",
+		resourceType: "volume",
+		apiURL:       s.computeAPI,
+		resource:     "volumes",
+		key:          "volumes",
+	}, nil)
+}
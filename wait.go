@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ServerStateDeleted is the state of a server which doesn't exist anymore,
+// i.e: once terminated. It may be waited for with WaitForServerState.
+const ServerStateDeleted = "deleted"
+
+// DefaultFailureStates are the states in which a server stays until an
+// operator acts, WaitForServerState fails when reaching them
+var DefaultFailureStates = []string{"locked"}
+
+// ServerStatus is the state of a server and its detail
+type ServerStatus struct {
+	State       string
+	StateDetail string
+}
+
+// WaitOptions configures the polling of WaitForServerState, the zero value
+// is usable, see TaskWaitOptions for the tasks
+type WaitOptions struct {
+	// Interval is the delay between the first two polls, 1s by default
+	Interval time.Duration
+
+	// MaxInterval caps the delay between two polls, 10s by default
+	MaxInterval time.Duration
+
+	// Multiplier increases the delay after each poll, 1.5 by default
+	Multiplier float64
+
+	// Timeout bounds the wait, besides the context (0 means no timeout)
+	Timeout time.Duration
+
+	// FailureStates are the states ending the wait with a
+	// *ServerStateError, DefaultFailureStates if nil
+	FailureStates []string
+
+	// OnTransition is called with the first status of the server, then
+	// each time its state or its detail changes
+	OnTransition func(from, to ServerStatus)
+}
+
+// intervals returns the polling intervals and multiplier, with their defaults
+func (opts WaitOptions) intervals() (interval, maxInterval time.Duration, multiplier float64) {
+	interval, maxInterval, multiplier = opts.Interval, opts.MaxInterval, opts.Multiplier
+	if interval <= 0 {
+		interval = time.Second
+	}
+	if maxInterval <= 0 {
+		maxInterval = 10 * time.Second
+	}
+	if multiplier < 1 {
+		multiplier = 1.5
+	}
+	return
+}
+
+// ServerStateError is returned by WaitForServerState when the server
+// reaches a failure state
+type ServerStateError struct {
+	ServerID string
+	Status   ServerStatus
+	Targets  []string
+}
+
+// Error returns a string representing the error
+func (e *ServerStateError) Error() string {
+	return fmt.Sprintf("server %s is %s (%s) while waiting for %s", e.ServerID, e.Status.State, e.Status.StateDetail, strings.Join(e.Targets, " or "))
+}
+
+// WaitForServerState polls a server until its state is one of targetStates,
+// and returns it; on failure, the last server polled is returned. Waiting for
+// ServerStateDeleted succeeds once the server isn't found, the returned
+// server is nil then.
+func (s *API) WaitForServerState(ctx context.Context, serverID string, targetStates []string, opts WaitOptions) (_ *Server, err error) {
+	ctx, op := s.startOperation(ctx, "WaitForServerState", "server", serverID)
+	defer op.End(&err)
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	interval, maxInterval, multiplier := opts.intervals()
+	failureStates := opts.FailureStates
+	if failureStates == nil {
+		failureStates = DefaultFailureStates
+	}
+
+	var (
+		last       ServerStatus
+		lastServer *Server
+	)
+	for {
+		server, err := s.GetServerContext(ctx, serverID)
+		var status ServerStatus
+		switch {
+		case errors.Is(err, ErrNotFound) && containsString(targetStates, ServerStateDeleted):
+			status = ServerStatus{State: ServerStateDeleted}
+		case err != nil:
+			if ctx.Err() != nil {
+				return lastServer, fmt.Errorf("waiting for server %s to be %s, last state %s: %w", serverID, strings.Join(targetStates, " or "), last.State, ctx.Err())
+			}
+			return nil, err
+		default:
+			status = ServerStatus{State: server.State, StateDetail: server.StateDetail}
+		}
+
+		if status != last && opts.OnTransition != nil {
+			opts.OnTransition(last, status)
+		}
+		last, lastServer = status, server
+		if containsString(targetStates, status.State) {
+			return server, nil
+		}
+		if containsString(failureStates, status.State) {
+			return server, &ServerStateError{ServerID: serverID, Status: status, Targets: targetStates}
+		}
+
+		if err = sleep(ctx, interval); err != nil {
+			return server, fmt.Errorf("waiting for server %s to be %s, last state %s: %w", serverID, strings.Join(targetStates, " or "), status.State, err)
+		}
+		if interval = time.Duration(float64(interval) * multiplier); interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
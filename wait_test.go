@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var testWaitOptions = WaitOptions{
+	Interval:    time.Millisecond,
+	MaxInterval: 5 * time.Millisecond,
+}
+
+func TestWaitForServerState(t *testing.T) {
+	s, srv := newFakeAPI(t)
+	srv.TransitionReads = 4
+
+	serverID := srv.AddServer("web", "VC1S")
+	if err := s.PostServerAction(serverID, "poweron"); err != nil {
+		t.Fatal(err)
+	}
+	var transitions []ServerStatus
+	opts := testWaitOptions
+	opts.OnTransition = func(from, to ServerStatus) {
+		if len(transitions) > 0 && from != transitions[len(transitions)-1] {
+			t.Errorf("expected the transition from %v, got %v", transitions[len(transitions)-1], from)
+		}
+		transitions = append(transitions, to)
+	}
+	server, err := s.WaitForServerState(context.Background(), serverID, []string{"running"}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server.State != "running" {
+		t.Errorf("expected a running server, got %s", server.State)
+	}
+	if len(transitions) != 2 || transitions[0].State != "starting" || transitions[1] != (ServerStatus{"running", "booted"}) {
+		t.Errorf("unexpected transitions: %v", transitions)
+	}
+
+	if err = s.PostServerAction(serverID, "terminate"); err != nil {
+		t.Fatal(err)
+	}
+	server, err = s.WaitForServerState(context.Background(), serverID, []string{ServerStateDeleted}, testWaitOptions)
+	if err != nil || server != nil {
+		t.Errorf("expected the server to be deleted, got %+v, %v", server, err)
+	}
+	if _, err = s.WaitForServerState(context.Background(), serverID, []string{"running"}, testWaitOptions); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected a missing server not to be waited for, got %v", err)
+	}
+}
+
+func TestWaitForServerState_failure(t *testing.T) {
+	s, srv := newFakeAPI(t)
+
+	serverID := srv.AddServer("web", "VC1S")
+	srv.SetServerState(serverID, "locked", "abuse")
+	_, err := s.WaitForServerState(context.Background(), serverID, []string{"running"}, testWaitOptions)
+	var stateErr *ServerStateError
+	if !errors.As(err, &stateErr) || stateErr.Status.StateDetail != "abuse" {
+		t.Errorf("expected a ServerStateError, got %v", err)
+	}
+
+	opts := testWaitOptions
+	opts.Timeout = 20 * time.Millisecond
+	srv.SetServerState(serverID, "stopped", "")
+	server, err := s.WaitForServerState(context.Background(), serverID, []string{"running"}, opts)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the wait to time out, got %v", err)
+	}
+	if server == nil || server.State != "stopped" {
+		t.Errorf("expected the last state of the server, got %+v", server)
+	}
+}